@@ -4,40 +4,58 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
-	"time"
 
-	_ "github.com/golang-migrate/migrate/v4/database/cockroachdb"
-	_ "github.com/golang-migrate/migrate/v4/source/file"
 	"github.com/jackc/pgx/v5/pgxpool"
-	"github.com/kelseyhightower/envconfig"
+	"github.com/rubenpad/srs/internal/domain/entity"
+	"github.com/rubenpad/srs/internal/infrastructure/config"
 	"github.com/rubenpad/srs/internal/infrastructure/logging"
+	"github.com/rubenpad/srs/internal/infrastructure/marketdata/finnhub"
+	"github.com/rubenpad/srs/internal/infrastructure/marketdata/fixture"
+	"github.com/rubenpad/srs/internal/infrastructure/notifier"
 	"github.com/rubenpad/srs/internal/infrastructure/otel"
+	"github.com/rubenpad/srs/internal/infrastructure/reference"
 	"github.com/rubenpad/srs/internal/infrastructure/server"
+	"github.com/rubenpad/srs/internal/infrastructure/storage/cockroach"
+	"github.com/rubenpad/srs/internal/infrastructure/storage/memory"
+	"github.com/rubenpad/srs/internal/infrastructure/storage/sqlite"
 )
 
-type config struct {
-	// Server configuration
-	ShutdownTimeout time.Duration `default:"10s"`
-	// Database configuration
-	Database         string
-	DatabaseHost     string `required:"true" split_words:"true"`
-	DatabaseUser     string `required:"true" split_words:"true"`
-	DatabasePort     uint   `required:"true" split_words:"true"`
-	DatabasePassword string `required:"true" split_words:"true"`
-}
+// Run starts the API with the configuration file at configPath, or the one
+// named by SRS_CONFIG_FILE when empty, and the environment. With migrate, it
+// also applies the pending migrations, one replica at a time.
+func Run(configPath string, migrate bool) error {
+	configuration, err := config.Load(configPath)
+	if err != nil {
+		return err
+	}
+
+	if err := logging.Set(logging.Config{Level: configuration.LogLevel, Format: configuration.LogFormat}); err != nil {
+		return err
+	}
 
-func Run() error {
-	logging.Set()
+	slog.Info("configuration loaded", "config", configuration)
 
-	var configuration config
-	err := envconfig.Process("SRS", &configuration)
+	marketDataProvider, err := newMarketDataProvider(configuration)
 	if err != nil {
 		return err
 	}
 
-	tp, tracerError := otel.InitTracer()
+	res, err := otel.NewResource(context.Background(), otel.ResourceConfig{
+		ServiceName:    configuration.ServiceName,
+		ServiceVersion: configuration.ServiceVersion,
+		Environment:    configuration.Environment,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create telemetry resource: %w", err)
+	}
+
+	tp, tracerError := otel.InitTracer(context.Background(), otel.TracerConfig{
+		Exporter:    configuration.TraceExporter,
+		SampleRatio: configuration.TraceSampleRatio,
+		ParentBased: configuration.TraceSampleParentBased,
+	}, res)
 	if tracerError != nil {
-		return err
+		return fmt.Errorf("failed to initialize tracer: %w", tracerError)
 	}
 
 	defer func() {
@@ -46,19 +64,109 @@ func Run() error {
 		}
 	}()
 
-	connectionParams := "?sslmode=require&pool_max_conns=40&pool_max_conn_lifetime=300s&pool_max_conn_lifetime_jitter=30s"
-	connectionString := fmt.Sprintf("postgresql://%s:%s@%s:%d/%s", configuration.DatabaseUser, configuration.DatabasePassword, configuration.DatabaseHost, configuration.DatabasePort, configuration.Database) + connectionParams
+	mp, metricsHandler, err := otel.InitMeter(res)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if mpError := mp.Shutdown(context.Background()); mpError != nil {
+			slog.Error("error shutting down meter", "error", mpError.Error())
+		}
+	}()
+
+	// Only the cockroach backend uses the database; the others run without
+	// the features kept there.
+	var connectionPool *pgxpool.Pool
+	if configuration.StorageBackend == "cockroach" {
+		connectionPool, err = cockroach.NewPool(context.Background(), configuration.Database, "srs")
+		if err != nil {
+			return fmt.Errorf("failed to create connection pool: %w", err)
+		}
+
+		defer connectionPool.Close()
 
-	connectionPoolContext := context.Background()
-	connectionPool, configError := pgxpool.New(connectionPoolContext, connectionString)
+		if err := cockroach.ObservePool(connectionPool); err != nil {
+			return fmt.Errorf("failed to observe connection pool: %w", err)
+		}
+	} else if migrate {
+		return fmt.Errorf("migrations need the cockroach storage backend, not %q", configuration.StorageBackend)
+	}
 
-	if err := connectionPool.Ping(connectionPoolContext); err != nil || configError != nil {
-		return fmt.Errorf("failed to create connection pool: %v", err)
+	stockRatingRepository, closeStockRatingRepository, err := newStockRatingRepository(configuration, connectionPool)
+	if err != nil {
+		return fmt.Errorf("failed to create stock rating repository: %w", err)
 	}
 
-	defer connectionPool.Close()
+	defer closeStockRatingRepository()
 
-	ctx, srv := server.New(context.Background(), "0.0.0.0", 8080, configuration.ShutdownTimeout, connectionPool)
+	ctx, srv := server.New(context.Background(), configuration, connectionPool, stockRatingRepository, marketDataProvider, newCompanySource(configuration), newNotifiers(configuration))
+	srv.EnableMetrics(metricsHandler)
+	if configuration.AdminToken != "" {
+		srv.EnableAdmin(configuration.AdminToken.Value())
+	}
+	if migrate {
+		srv.EnableMigrations(func(ctx context.Context) error {
+			return cockroach.Migrate(ctx, connectionPool, configuration.Database, "srs-migrations")
+		})
+	}
+
+	if configuration.GrpcPort != 0 {
+		srv.EnableGrpc(configuration.Host, configuration.GrpcPort)
+	}
 
 	return srv.Run(ctx)
 }
+
+func newMarketDataProvider(configuration config.Config) (entity.MarketDataProvider, error) {
+	switch configuration.MarketDataProvider {
+	case "finnhub":
+		return finnhub.NewMarketDataProvider(configuration.FinnhubApiKey.Value()), nil
+	case "fixture":
+		return fixture.NewMarketDataProvider(configuration.MarketDataFixturePath)
+	default:
+		return nil, fmt.Errorf("unknown market data provider %q", configuration.MarketDataProvider)
+	}
+}
+
+// newStockRatingRepository returns the repository of the configured storage
+// backend and the function closing it.
+func newStockRatingRepository(configuration config.Config, connectionPool *pgxpool.Pool) (entity.IStockRatingRepository, func(), error) {
+	switch configuration.StorageBackend {
+	case "memory":
+		return memory.NewStockRatingRepository(), func() {}, nil
+	case "sqlite":
+		db, err := sqlite.Open(context.Background(), configuration.StorageSqlitePath)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return sqlite.NewStockRatingRepository(db), func() { db.Close() }, nil
+	default:
+		return cockroach.NewStockRatingRepository(connectionPool), func() {}, nil
+	}
+}
+
+func newCompanySource(configuration config.Config) entity.ICompanySource {
+	if configuration.CompanyDataPath == "" {
+		return nil
+	}
+
+	return reference.NewCompanyFileSource(configuration.CompanyDataPath)
+}
+
+func newNotifiers(configuration config.Config) map[string]entity.INotifier {
+	notifiers := map[string]entity.INotifier{
+		entity.NotifierLog: notifier.NewLogNotifier(),
+	}
+
+	if configuration.AlertWebhookUrl != "" {
+		notifiers[entity.NotifierWebhook] = notifier.NewWebhookNotifier(configuration.AlertWebhookUrl)
+	}
+
+	if configuration.AlertSmtpAddress != "" && len(configuration.AlertSmtpTo) > 0 {
+		notifiers[entity.NotifierSmtp] = notifier.NewSmtpNotifier(configuration.AlertSmtpAddress, configuration.AlertSmtpFrom, configuration.AlertSmtpTo)
+	}
+
+	return notifiers
+}
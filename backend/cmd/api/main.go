@@ -1,13 +1,18 @@
 package main
 
 import (
+	"flag"
 	"log"
 
 	"github.com/rubenpad/srs/cmd/api/bootstrap"
 )
 
 func main() {
-	if err := bootstrap.Run(); err != nil {
+	configPath := flag.String("config", "", "YAML or TOML configuration file, SRS_CONFIG_FILE by default")
+	migrate := flag.Bool("migrate", false, "apply the pending migrations at startup, reporting the server unready until they finish")
+	flag.Parse()
+
+	if err := bootstrap.Run(*configPath, *migrate); err != nil {
 		log.Fatal(err)
 	}
 }
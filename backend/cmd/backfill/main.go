@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"time"
+
+	"github.com/rubenpad/srs/internal/domain/entity"
+	"github.com/rubenpad/srs/internal/infrastructure/config"
+	"github.com/rubenpad/srs/internal/infrastructure/storage/cockroach"
+)
+
+const batchSize = 500
+
+// Converts the text targets of the stored stock ratings into the numeric
+// target_from_value, target_to_value and currency columns.
+func main() {
+	configPath := flag.String("config", "", "YAML or TOML configuration file, SRS_CONFIG_FILE by default")
+	flag.Parse()
+
+	log.Println("target values backfill started")
+	start := time.Now()
+
+	configuration, err := config.Load(*configPath)
+	if err != nil {
+		log.Fatal("error getting configuration values: ", err)
+	}
+
+	// The storage backend may not need the database, but this command does.
+	if err := configuration.Database.Validate(); err != nil {
+		log.Fatal("invalid database configuration: ", err)
+	}
+
+	ctx := context.Background()
+	connectionPool, err := cockroach.NewPool(ctx, configuration.Database, "srs-backfill")
+	if err != nil {
+		log.Fatal("error configuring connection pool: ", err)
+	}
+
+	defer connectionPool.Close()
+
+	repository := cockroach.NewStockRatingRepository(connectionPool)
+
+	var converted, failed int
+	var last entity.StockRating
+	for {
+		ratings, err := repository.GetRatingsMissingTargetValues(ctx, last, batchSize)
+		if err != nil {
+			log.Fatal("error reading stock ratings", err)
+		}
+
+		if len(ratings) == 0 {
+			break
+		}
+
+		for _, rating := range ratings {
+			targetFrom, fromCurrency, fromErr := entity.ParsePrice(rating.TargetFrom)
+			targetTo, toCurrency, toErr := entity.ParsePrice(rating.TargetTo)
+
+			if fromErr != nil || toErr != nil || fromCurrency != toCurrency {
+				log.Printf("skipping stock rating with unparseable targets: %s %s %s '%s' '%s'", rating.Ticker, rating.Brokerage, rating.Time.Format(time.DateOnly), rating.TargetFrom, rating.TargetTo)
+				failed++
+				continue
+			}
+
+			rating.TargetFromValue = &targetFrom
+			rating.TargetToValue = &targetTo
+			rating.Currency = &toCurrency
+
+			if err := repository.UpdateTargetValues(ctx, rating); err != nil {
+				log.Fatal("error updating stock rating", err)
+			}
+
+			converted++
+		}
+
+		last = ratings[len(ratings)-1]
+	}
+
+	elapsed := time.Since(start)
+	minutes := int(elapsed.Minutes())
+	seconds := int(elapsed.Seconds()) % 60
+	milliseconds := int(elapsed.Milliseconds()) % 1000
+	log.Printf("target values backfill finished: %d converted, %d skipped in %dm %ds %dms", converted, failed, minutes, seconds, milliseconds)
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/rubenpad/srs/internal/domain/entity"
+	"github.com/rubenpad/srs/internal/infrastructure/config"
+	"github.com/rubenpad/srs/internal/infrastructure/storage/cockroach"
+)
+
+// Rewrites the stored brokerage names to their canonical names and merges the
+// ratings that become duplicates. Names that are not registered are grouped by
+// their comparison key and merged into the variant with the most ratings.
+func main() {
+	dryRun := flag.Bool("dry-run", false, "print the renames without applying them")
+	configPath := flag.String("config", "", "YAML or TOML configuration file, SRS_CONFIG_FILE by default")
+	flag.Parse()
+
+	log.Println("brokerage canonicalisation started")
+	start := time.Now()
+
+	configuration, err := config.Load(*configPath)
+	if err != nil {
+		log.Fatal("error getting configuration values: ", err)
+	}
+
+	// The storage backend may not need the database, but this command does.
+	if err := configuration.Database.Validate(); err != nil {
+		log.Fatal("invalid database configuration: ", err)
+	}
+
+	ctx := context.Background()
+	connectionPool, err := cockroach.NewPool(ctx, configuration.Database, "srs-brokerages")
+	if err != nil {
+		log.Fatal("error configuring connection pool: ", err)
+	}
+
+	defer connectionPool.Close()
+
+	repository := cockroach.NewStockRatingRepository(connectionPool)
+
+	counts, err := repository.GetBrokerageCounts(ctx)
+	if err != nil {
+		log.Fatal("error reading brokerages", err)
+	}
+
+	renames := planRenames(entity.NewBrokerageRegistry(entity.DefaultBrokerages), counts)
+
+	var renamed, merged int64
+	for _, alias := range sortedKeys(renames) {
+		canonical := renames[alias]
+		log.Printf("%q -> %q (%d ratings)", alias, canonical, counts[alias])
+
+		if *dryRun {
+			continue
+		}
+
+		r, m, err := repository.MergeBrokerage(ctx, alias, canonical)
+		if err != nil {
+			log.Fatal("error merging brokerage", err)
+		}
+
+		renamed += r
+		merged += m
+	}
+
+	elapsed := time.Since(start)
+	minutes := int(elapsed.Minutes())
+	seconds := int(elapsed.Seconds()) % 60
+	milliseconds := int(elapsed.Milliseconds()) % 1000
+	log.Printf("brokerage canonicalisation finished: %d brokerages, %d ratings renamed, %d duplicates merged in %dm %ds %dms", len(renames), renamed, merged, minutes, seconds, milliseconds)
+}
+
+// planRenames maps every stored brokerage name that is not canonical to the
+// name it should be stored as.
+func planRenames(registry *entity.BrokerageRegistry, counts map[string]int64) map[string]string {
+	preferred := make(map[string]string)
+	for _, name := range sortedKeys(counts) {
+		if _, ok := registry.Lookup(name); ok {
+			continue
+		}
+
+		key := entity.BrokerageKey(name)
+		if current, ok := preferred[key]; !ok || counts[name] > counts[current] {
+			preferred[key] = name
+		}
+	}
+
+	renames := make(map[string]string)
+	for name := range counts {
+		canonical, ok := registry.Lookup(name)
+		if !ok {
+			canonical = preferred[entity.BrokerageKey(name)]
+		}
+
+		if canonical != name {
+			renames[name] = canonical
+		}
+	}
+
+	return renames
+}
+
+func sortedKeys[V any](values map[string]V) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+	return keys
+}
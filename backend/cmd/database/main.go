@@ -1,54 +1,251 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"fmt"
 	"log"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"text/tabwriter"
 	"time"
 
-	"github.com/golang-migrate/migrate/v4"
-	_ "github.com/golang-migrate/migrate/v4/database/cockroachdb"
-	_ "github.com/golang-migrate/migrate/v4/source/file"
-	"github.com/kelseyhightower/envconfig"
+	"github.com/rubenpad/srs/internal/infrastructure/config"
+	"github.com/rubenpad/srs/internal/infrastructure/storage/cockroach"
 )
 
-type config struct {
-	Database         string `required:"true"`
-	DatabaseHost     string `required:"true" split_words:"true"`
-	DatabaseUser     string `required:"true" split_words:"true"`
-	DatabasePort     uint   `required:"true" split_words:"true"`
-	DatabasePassword string `required:"true" split_words:"true"`
-}
+const usage = `Usage: run-migrations [flags] [command]
+
+Commands:
+  up [N]      apply all the pending migrations, or the next N (default)
+  down N      roll back the last N migrations, requires -yes
+  goto V      apply or roll back the migrations up to version V; rolling
+              back requires -yes
+  version     print the version of the database
+  force V     set the version to V without running any migration, to clear
+              a dirty database once it was fixed by hand; "force -- -1"
+              means no migration
+  status      list the migrations and whether they are applied
+
+Flags:
+`
 
 func main() {
-	log.Println("migrations process started")
-	start := time.Now()
+	configPath := flag.String("config", "", "YAML or TOML configuration file, SRS_CONFIG_FILE by default")
+	migrationsPath := flag.String("path", "", "directory of the migrations, SRS_DATABASE_MIGRATIONS_PATH or the embedded ones by default")
+	confirmed := flag.Bool("yes", false, "confirm the commands that roll back migrations and drop data")
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
 
-	var configuration config
-	err := envconfig.Process("SRS", &configuration)
+	arguments, err := parseArguments(flag.CommandLine, os.Args[1:])
 	if err != nil {
-		log.Fatal("error getting database configuration values")
+		os.Exit(2)
 	}
 
-	connectionParams := "?sslmode=require&pool_max_conns=40&pool_max_conn_lifetime=300s&pool_max_conn_lifetime_jitter=30s"
-	connectionString := fmt.Sprintf("cockroachdb://%s:%s@%s:%d/%s", configuration.DatabaseUser, configuration.DatabasePassword, configuration.DatabaseHost, configuration.DatabasePort, configuration.Database) + connectionParams
+	command := "up"
+	if len(arguments) > 0 {
+		command, arguments = arguments[0], arguments[1:]
+	}
 
-	migration, err := migrate.New("file://database/migrations", connectionString)
+	configuration, err := config.Load(*configPath)
+	if err != nil {
+		log.Fatal("error getting configuration values: ", err)
+	}
 
+	// The storage backend may not need the database, but this command does.
+	if err := configuration.Database.Validate(); err != nil {
+		log.Fatal("invalid database configuration: ", err)
+	}
+
+	if *migrationsPath != "" {
+		configuration.DatabaseMigrationsPath = *migrationsPath
+	}
+
+	migrator, err := cockroach.NewMigrator(configuration.Database, "srs-migrations")
 	if err != nil {
-		log.Fatal("error configuring migrations", err)
+		log.Fatal("error configuring migrations: ", err)
+	}
+	defer migrator.Close()
+
+	// An interrupted command stops after the running migration instead of
+	// leaving the database dirty.
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		migrator.Stop()
+	}()
+
+	start := time.Now()
+	if err := run(migrator, command, arguments, *confirmed); err != nil {
+		reportDirty(migrator)
+		migrator.Close()
+		log.Fatalf("error executing %s: %v", command, err)
+	}
+
+	if command == "up" || command == "down" || command == "goto" || command == "force" {
+		elapsed := time.Since(start)
+		minutes := int(elapsed.Minutes())
+		seconds := int(elapsed.Seconds()) % 60
+		milliseconds := int(elapsed.Milliseconds()) % 1000
+		log.Printf("migrations executed successfully: %dm %ds %dms", minutes, seconds, milliseconds)
+	}
+}
+
+func run(migrator *cockroach.Migrator, command string, arguments []string, confirmed bool) error {
+	switch command {
+	case "up":
+		if len(arguments) > 1 {
+			return fmt.Errorf("up takes at most one argument, got %d", len(arguments))
+		}
+
+		var n uint64
+		if len(arguments) == 1 {
+			var err error
+			if n, err = parsePositive(arguments[0]); err != nil {
+				return err
+			}
+		}
+
+		return migrator.Up(uint(n))
+	case "down":
+		if len(arguments) != 1 {
+			return fmt.Errorf("down takes the number of migrations to roll back")
+		}
+
+		n, err := parsePositive(arguments[0])
+		if err != nil {
+			return err
+		}
+
+		if !confirmed {
+			return fmt.Errorf("rolling back %d migrations drops their tables and data, run again with -yes to confirm", n)
+		}
+
+		return migrator.Down(uint(n))
+	case "goto":
+		if len(arguments) != 1 {
+			return fmt.Errorf("goto takes the target version")
+		}
+
+		target, err := strconv.ParseUint(arguments[0], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid version %q", arguments[0])
+		}
+
+		current, _, err := migrator.Version()
+		if err != nil {
+			return err
+		}
+
+		if uint(target) < current && !confirmed {
+			return fmt.Errorf("going back from version %d to %d drops tables and data, run again with -yes to confirm", current, target)
+		}
+
+		return migrator.Goto(uint(target))
+	case "force":
+		if len(arguments) != 1 {
+			return fmt.Errorf("force takes the version to set")
+		}
+
+		version, err := strconv.Atoi(arguments[0])
+		if err != nil || version < -1 {
+			return fmt.Errorf("invalid version %q", arguments[0])
+		}
+
+		return migrator.Force(version)
+	case "version":
+		version, dirty, err := migrator.Version()
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(formatVersion(version, dirty))
+		return nil
+	case "status":
+		status, err := migrator.Status()
+		if err != nil {
+			return err
+		}
+
+		printStatus(status)
+		return nil
+	default:
+		flag.Usage()
+		return fmt.Errorf("unknown command %q", command)
+	}
+}
+
+// parseArguments lets the flags follow the command and its arguments, as in
+// "down 1 -yes".
+func parseArguments(flags *flag.FlagSet, arguments []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := flags.Parse(arguments); err != nil {
+			return nil, err
+		}
+
+		arguments = flags.Args()
+		if len(arguments) == 0 {
+			return positional, nil
+		}
+
+		positional = append(positional, arguments[0])
+		arguments = arguments[1:]
 	}
+}
 
-	if err := migration.Up(); err != nil && err != migrate.ErrNoChange {
-		if downErr := migration.Down(); downErr != nil {
-			log.Fatal("error executing up & down migrations", downErr)
+func parsePositive(argument string) (uint64, error) {
+	n, err := strconv.ParseUint(argument, 10, 64)
+	if err != nil || n == 0 {
+		return 0, fmt.Errorf("invalid number of migrations %q", argument)
+	}
+
+	return n, nil
+}
+
+func printStatus(status cockroach.MigrationStatus) {
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "VERSION\tNAME\tSTATUS")
+	for _, migration := range status.Migrations {
+		state := "pending"
+		if migration.Applied {
+			state = "applied"
 		}
 
-		log.Fatal("error executing up migrations", err)
+		if status.Dirty && migration.Version == status.Version {
+			state = "dirty"
+		}
+
+		fmt.Fprintf(writer, "%d\t%s\t%s\n", migration.Version, migration.Name, state)
+	}
+	writer.Flush()
+
+	fmt.Printf("\ndatabase version: %s\n", formatVersion(status.Version, status.Dirty))
+}
+
+func formatVersion(version uint, dirty bool) string {
+	switch {
+	case version == 0:
+		return "none"
+	case dirty:
+		return fmt.Sprintf("%d (dirty)", version)
+	default:
+		return strconv.FormatUint(uint64(version), 10)
+	}
+}
+
+// reportDirty explains how to recover from a failed migration, which is
+// never rolled back automatically.
+func reportDirty(migrator *cockroach.Migrator) {
+	version, dirty, err := migrator.Version()
+	if err != nil || !dirty {
+		return
 	}
 
-	elapsed := time.Since(start)
-	minutes := int(elapsed.Minutes())
-	seconds := int(elapsed.Seconds()) % 60
-	milliseconds := int(elapsed.Milliseconds()) % 1000
-	log.Printf("migrations executed successfully: %dm %ds %dms", minutes, seconds, milliseconds)
+	log.Printf("migration %d failed and the database is dirty: fix the schema by hand, then run \"force V\" with the last fully applied version", version)
 }
@@ -0,0 +1,39 @@
+// Package database holds the schema migrations, embedded in the binaries so
+// they can tell which schema version they were built for.
+package database
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"strconv"
+	"strings"
+)
+
+//go:embed migrations/*.sql
+var Migrations embed.FS
+
+// LatestVersion returns the version of the newest migration.
+func LatestVersion() (uint, error) {
+	entries, err := fs.ReadDir(Migrations, "migrations")
+	if err != nil {
+		return 0, err
+	}
+
+	var latest uint
+	for _, entry := range entries {
+		prefix, _, found := strings.Cut(entry.Name(), "_")
+		if !found {
+			return 0, fmt.Errorf("migration %s has no version prefix", entry.Name())
+		}
+
+		version, err := strconv.ParseUint(prefix, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("migration %s has an invalid version: %w", entry.Name(), err)
+		}
+
+		latest = max(latest, uint(version))
+	}
+
+	return latest, nil
+}
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS stock_price;
\ No newline at end of file
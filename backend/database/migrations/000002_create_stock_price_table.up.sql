@@ -0,0 +1,15 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS stock_price (
+    ticker VARCHAR(50) NOT NULL,
+    time DATE NOT NULL,
+    open DECIMAL(18, 4) NOT NULL,
+    high DECIMAL(18, 4) NOT NULL,
+    low DECIMAL(18, 4) NOT NULL,
+    close DECIMAL(18, 4) NOT NULL,
+    volume INT8 NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT "primary" PRIMARY KEY (ticker, time DESC)
+);
+
+COMMIT;
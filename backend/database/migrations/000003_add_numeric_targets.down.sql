@@ -0,0 +1,3 @@
+ALTER TABLE stock_rating DROP COLUMN IF EXISTS currency;
+ALTER TABLE stock_rating DROP COLUMN IF EXISTS target_to_value;
+ALTER TABLE stock_rating DROP COLUMN IF EXISTS target_from_value;
@@ -0,0 +1,7 @@
+BEGIN;
+
+ALTER TABLE stock_rating ADD COLUMN IF NOT EXISTS target_from_value DECIMAL(18, 4) NULL;
+ALTER TABLE stock_rating ADD COLUMN IF NOT EXISTS target_to_value DECIMAL(18, 4) NULL;
+ALTER TABLE stock_rating ADD COLUMN IF NOT EXISTS currency VARCHAR(3) NULL;
+
+COMMIT;
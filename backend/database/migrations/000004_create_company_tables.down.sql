@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS company_symbol_history;
+DROP TABLE IF EXISTS company;
\ No newline at end of file
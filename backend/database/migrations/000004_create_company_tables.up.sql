@@ -0,0 +1,23 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS company (
+    ticker VARCHAR(50) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    exchange VARCHAR(100) NOT NULL DEFAULT '',
+    sector VARCHAR(100) NOT NULL DEFAULT '',
+    industry VARCHAR(100) NOT NULL DEFAULT '',
+    aliases STRING[] NOT NULL DEFAULT ARRAY[],
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT "primary" PRIMARY KEY (ticker)
+);
+
+CREATE TABLE IF NOT EXISTS company_symbol_history (
+    from_ticker VARCHAR(50) NOT NULL,
+    to_ticker VARCHAR(50) NOT NULL,
+    changed_at DATE NOT NULL,
+    CONSTRAINT "primary" PRIMARY KEY (from_ticker, changed_at),
+    INDEX company_symbol_history_to_ticker_idx (to_ticker)
+);
+
+COMMIT;
@@ -0,0 +1,23 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS alert_rule (
+    id INT8 NOT NULL DEFAULT unique_rowid(),
+    name VARCHAR(100) NOT NULL,
+    expression VARCHAR(1000) NOT NULL,
+    notifiers STRING[] NOT NULL DEFAULT ARRAY[],
+    enabled BOOL NOT NULL DEFAULT true,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT "primary" PRIMARY KEY (id)
+);
+
+CREATE TABLE IF NOT EXISTS alert_event (
+    id INT8 NOT NULL DEFAULT unique_rowid(),
+    rule_id INT8 NOT NULL,
+    rule_name VARCHAR(100) NOT NULL,
+    ticker VARCHAR(50) NOT NULL,
+    rating JSONB NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT "primary" PRIMARY KEY (id DESC)
+);
+
+COMMIT;
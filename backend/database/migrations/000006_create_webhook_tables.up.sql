@@ -0,0 +1,29 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS webhook (
+    id INT8 NOT NULL DEFAULT unique_rowid(),
+    url VARCHAR(2000) NOT NULL,
+    secret VARCHAR(200) NOT NULL,
+    events STRING[] NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT "primary" PRIMARY KEY (id)
+);
+
+CREATE TABLE IF NOT EXISTS webhook_delivery (
+    id INT8 NOT NULL DEFAULT unique_rowid(),
+    webhook_id INT8 NOT NULL REFERENCES webhook (id) ON DELETE CASCADE,
+    event VARCHAR(100) NOT NULL,
+    payload JSONB NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    attempts INT4 NOT NULL DEFAULT 0,
+    last_status_code INT4 NULL,
+    last_error STRING NULL,
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    delivered_at TIMESTAMP NULL,
+    CONSTRAINT "primary" PRIMARY KEY (id),
+    INDEX webhook_delivery_due_idx (status, next_attempt_at),
+    INDEX webhook_delivery_webhook_idx (webhook_id, id DESC)
+);
+
+COMMIT;
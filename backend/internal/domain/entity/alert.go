@@ -0,0 +1,50 @@
+package entity
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+const (
+	NotifierLog     = "log"
+	NotifierWebhook = "webhook"
+	NotifierSmtp    = "smtp"
+)
+
+var (
+	ErrAlertRuleNotFound = errors.New("alert rule not found")
+	ErrInvalidAlertRule  = errors.New("invalid alert rule")
+)
+
+// AlertRule raises an alert for every saved stock rating matching Expression,
+// delivered through the named Notifiers.
+type AlertRule struct {
+	ID         int64     `json:"id"`
+	Name       string    `json:"name"`
+	Expression string    `json:"expression"`
+	Notifiers  []string  `json:"notifiers"`
+	Enabled    bool      `json:"enabled"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type AlertEvent struct {
+	ID        int64       `json:"id"`
+	RuleID    int64       `json:"rule_id"`
+	RuleName  string      `json:"rule_name"`
+	Ticker    string      `json:"ticker"`
+	Rating    StockRating `json:"rating"`
+	CreatedAt time.Time   `json:"created_at"`
+}
+
+type IAlertRepository interface {
+	SaveRule(ctx context.Context, rule AlertRule) (*AlertRule, error)
+	GetRules(ctx context.Context) ([]AlertRule, error)
+	DeleteRule(ctx context.Context, id int64) error
+	SaveEvent(ctx context.Context, event AlertEvent) (*AlertEvent, error)
+	GetEvents(ctx context.Context, nextPage string, pageSize int) ([]AlertEvent, error)
+}
+
+type INotifier interface {
+	Notify(ctx context.Context, event AlertEvent) error
+}
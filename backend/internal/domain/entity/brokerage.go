@@ -0,0 +1,87 @@
+package entity
+
+import (
+	"strings"
+	"unicode"
+)
+
+type Brokerage struct {
+	Name    string
+	Aliases []string
+}
+
+// DefaultBrokerages are the canonical names of the brokerages seen in the
+// rating feed, with the variants they are also reported as.
+var DefaultBrokerages = []Brokerage{
+	{Name: "JPMorgan Chase & Co.", Aliases: []string{"JP Morgan", "J.P. Morgan", "JPMorgan", "JPMorgan Chase"}},
+	{Name: "The Goldman Sachs Group", Aliases: []string{"Goldman Sachs", "Goldman Sachs Group"}},
+	{Name: "Morgan Stanley"},
+	{Name: "Bank of America", Aliases: []string{"BofA Securities", "Bank of America Merrill Lynch", "BofA"}},
+	{Name: "Citigroup", Aliases: []string{"Citi", "Citigroup Inc."}},
+	{Name: "Wells Fargo & Company", Aliases: []string{"Wells Fargo"}},
+	{Name: "Keefe, Bruyette & Woods", Aliases: []string{"KBW"}},
+	{Name: "HC Wainwright", Aliases: []string{"H.C. Wainwright", "H.C. Wainwright & Co."}},
+	{Name: "Royal Bank of Canada", Aliases: []string{"RBC Capital", "RBC Capital Markets"}},
+	{Name: "Deutsche Bank Aktiengesellschaft", Aliases: []string{"Deutsche Bank"}},
+	{Name: "UBS Group", Aliases: []string{"UBS"}},
+	{Name: "Barclays", Aliases: []string{"Barclays Capital"}},
+	{Name: "Jefferies Financial Group", Aliases: []string{"Jefferies"}},
+	{Name: "Raymond James Financial", Aliases: []string{"Raymond James"}},
+	{Name: "Stifel Nicolaus", Aliases: []string{"Stifel"}},
+	{Name: "Needham & Company LLC", Aliases: []string{"Needham", "Needham & Company"}},
+	{Name: "Truist Financial", Aliases: []string{"Truist", "Truist Securities"}},
+	{Name: "Evercore ISI", Aliases: []string{"Evercore"}},
+	{Name: "TD Cowen", Aliases: []string{"Cowen", "Cowen and Company"}},
+	{Name: "BMO Capital Markets", Aliases: []string{"BMO"}},
+	{Name: "Piper Sandler"},
+	{Name: "Oppenheimer"},
+	{Name: "Mizuho"},
+	{Name: "Benchmark"},
+}
+
+// BrokerageRegistry maps the reported names of a brokerage to its canonical
+// name. Names are compared ignoring case, spacing and punctuation, so
+// "JP Morgan" and "JPMorgan" resolve to the same brokerage.
+type BrokerageRegistry struct {
+	canonical map[string]string
+}
+
+func NewBrokerageRegistry(brokerages []Brokerage) *BrokerageRegistry {
+	registry := &BrokerageRegistry{canonical: make(map[string]string)}
+
+	for _, brokerage := range brokerages {
+		registry.canonical[BrokerageKey(brokerage.Name)] = brokerage.Name
+		for _, alias := range brokerage.Aliases {
+			registry.canonical[BrokerageKey(alias)] = brokerage.Name
+		}
+	}
+
+	return registry
+}
+
+// Canonical returns the canonical name of the brokerage, or the name with its
+// whitespace collapsed when the brokerage is not registered.
+func (r *BrokerageRegistry) Canonical(name string) string {
+	if canonical, ok := r.Lookup(name); ok {
+		return canonical
+	}
+
+	return strings.Join(strings.Fields(name), " ")
+}
+
+func (r *BrokerageRegistry) Lookup(name string) (string, bool) {
+	canonical, ok := r.canonical[BrokerageKey(name)]
+	return canonical, ok
+}
+
+// BrokerageKey is the comparison key of a brokerage name.
+func BrokerageKey(name string) string {
+	var builder strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			builder.WriteRune(r)
+		}
+	}
+
+	return builder.String()
+}
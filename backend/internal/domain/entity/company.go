@@ -0,0 +1,37 @@
+package entity
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var ErrCompanyNotFound = errors.New("company not found")
+
+type Company struct {
+	Ticker        string         `json:"ticker"`
+	Name          string         `json:"name"`
+	Exchange      string         `json:"exchange"`
+	Sector        string         `json:"sector"`
+	Industry      string         `json:"industry"`
+	Aliases       []string       `json:"aliases"`
+	SymbolHistory []SymbolChange `json:"symbol_history" db:"-"`
+}
+
+// SymbolChange records that a company previously traded as FromTicker.
+type SymbolChange struct {
+	FromTicker string    `json:"from_ticker"`
+	ToTicker   string    `json:"to_ticker"`
+	ChangedAt  time.Time `json:"changed_at"`
+}
+
+type ICompanyRepository interface {
+	Save(ctx context.Context, company Company) error
+	GetCompany(ctx context.Context, ticker string) (*Company, error)
+	GetCompanies(ctx context.Context) ([]Company, error)
+}
+
+// ICompanySource provides curated company reference data, such as a local file.
+type ICompanySource interface {
+	GetCompanies(ctx context.Context) ([]Company, error)
+}
@@ -0,0 +1,54 @@
+package entity
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"time"
+)
+
+// StockRatingCursor is the position of a stock rating in the ticker,
+// brokerage, newest first order used to page through them. A cursor with
+// only Ticker set points past every rating of that ticker.
+type StockRatingCursor struct {
+	Ticker    string    `json:"t"`
+	Brokerage string    `json:"b,omitempty"`
+	Time      time.Time `json:"ts,omitzero"`
+}
+
+func NewStockRatingCursor(rating StockRating) StockRatingCursor {
+	return StockRatingCursor{Ticker: rating.Ticker, Brokerage: rating.Brokerage, Time: rating.Time}
+}
+
+// ParseStockRatingCursor decodes a cursor returned by String. The empty
+// string is the cursor of the first page.
+func ParseStockRatingCursor(value string) (StockRatingCursor, error) {
+	cursor := StockRatingCursor{}
+	if value == "" {
+		return cursor, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return cursor, ErrInvalidCursor
+	}
+
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.Ticker == "" {
+		return StockRatingCursor{}, ErrInvalidCursor
+	}
+
+	return cursor, nil
+}
+
+func (c StockRatingCursor) IsZero() bool {
+	return c.Ticker == ""
+}
+
+// String encodes the cursor as an opaque URL safe token.
+func (c StockRatingCursor) String() string {
+	if c.IsZero() {
+		return ""
+	}
+
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
@@ -0,0 +1,36 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStockRatingCursor(t *testing.T) {
+	tests := []struct {
+		name   string
+		cursor StockRatingCursor
+	}{
+		{"first page", StockRatingCursor{}},
+		{"ticker only", StockRatingCursor{Ticker: "AAPL"}},
+		{"composite", StockRatingCursor{Ticker: "AAPL", Brokerage: "JPMorgan Chase & Co.", Time: time.Date(2025, 3, 14, 0, 30, 0, 0, time.UTC)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cursor, err := ParseStockRatingCursor(tt.cursor.String())
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.cursor, cursor)
+		})
+	}
+}
+
+func TestParseStockRatingCursorInvalid(t *testing.T) {
+	for _, value := range []string{"AAPL", "e30", "bm90IGpzb24"} {
+		_, err := ParseStockRatingCursor(value)
+		assert.ErrorIs(t, err, ErrInvalidCursor, value)
+	}
+}
@@ -0,0 +1,9 @@
+package entity
+
+import "context"
+
+// HealthChecker is implemented by the external services able to tell whether
+// they can currently be used.
+type HealthChecker interface {
+	HealthCheck(ctx context.Context) error
+}
@@ -0,0 +1,56 @@
+package entity
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	IngestionStatusStarted   = "started"
+	IngestionStatusRunning   = "running"
+	IngestionStatusCompleted = "completed"
+)
+
+// IngestionSummary describes a finished stock ratings load.
+type IngestionSummary struct {
+	StartedAt  time.Time `json:"started_at"`
+	FinishedAt time.Time `json:"finished_at"`
+	Saved      int64     `json:"saved"`
+}
+
+// IngestionProgress is reported when a stock ratings load starts, after every
+// upstream page and when it completes.
+type IngestionProgress struct {
+	JobID     string    `json:"job_id"`
+	Status    string    `json:"status"`
+	StartedAt time.Time `json:"started_at"`
+	Pages     int       `json:"pages"`
+	Fetched   int64     `json:"fetched"`
+	Saved     int64     `json:"saved"`
+}
+
+// IngestionListener is implemented by stock rating listeners that also want
+// to know when a load finishes.
+type IngestionListener interface {
+	IngestionCompleted(ctx context.Context, summary IngestionSummary)
+}
+
+// IngestionProgressListener is implemented by stock rating listeners that
+// follow a load while it runs.
+type IngestionProgressListener interface {
+	IngestionProgressed(ctx context.Context, progress IngestionProgress)
+}
+
+type jobIDKey struct{}
+
+// WithJobID returns a copy of ctx carrying the ID of the ingestion job it
+// belongs to.
+func WithJobID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, jobIDKey{}, id)
+}
+
+// JobID returns the ingestion job ID carried by ctx, if any.
+func JobID(ctx context.Context) string {
+	id, _ := ctx.Value(jobIDKey{}).(string)
+	return id
+}
@@ -0,0 +1,35 @@
+package entity
+
+import (
+	"context"
+	"time"
+)
+
+type Quote struct {
+	Current       float64 `json:"current"`
+	Open          float64 `json:"open"`
+	High          float64 `json:"high"`
+	Low           float64 `json:"low"`
+	PreviousClose float64 `json:"previousClose"`
+	Change        float64 `json:"change"`
+	PercentChange float64 `json:"percentChange"`
+}
+
+type RecommendationTrend struct {
+	Period     string `json:"period"`
+	StrongBuy  int64  `json:"strongBuy"`
+	Buy        int64  `json:"buy"`
+	Hold       int64  `json:"hold"`
+	Sell       int64  `json:"sell"`
+	StrongSell int64  `json:"strongSell"`
+}
+
+// MarketDataProvider is the source of market data for a ticker. Implementations
+// return ErrStockNotFound from GetQuote and GetCompanyProfile when the ticker is
+// unknown.
+type MarketDataProvider interface {
+	GetQuote(ctx context.Context, ticker string) (*Quote, error)
+	GetRecommendationTrends(ctx context.Context, ticker string) ([]RecommendationTrend, error)
+	GetDailyCandles(ctx context.Context, ticker string, from, to time.Time) ([]Candle, error)
+	GetCompanyProfile(ctx context.Context, ticker string) (*Company, error)
+}
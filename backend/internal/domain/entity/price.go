@@ -0,0 +1,82 @@
+package entity
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+const DefaultCurrency = "USD"
+
+// currencySymbols is ordered so that prefixed dollar symbols are tried before
+// the bare "$".
+var currencySymbols = []struct {
+	symbol   string
+	currency string
+}{
+	{"US$", "USD"},
+	{"CA$", "CAD"},
+	{"HK$", "HKD"},
+	{"C$", "CAD"},
+	{"A$", "AUD"},
+	{"R$", "BRL"},
+	{"$", "USD"},
+	{"€", "EUR"},
+	{"£", "GBP"},
+	{"¥", "JPY"},
+	{"₹", "INR"},
+}
+
+// ParsePrice parses a price such as "$1,234.00", "€12.50" or "12.50 GBP" into
+// its amount and ISO 4217 currency code. Prices without a symbol or code are
+// assumed to be in DefaultCurrency.
+func ParsePrice(value string) (float64, string, error) {
+	amount := strings.TrimSpace(value)
+	currency := DefaultCurrency
+
+	if code, rest, ok := splitCurrencyCode(amount); ok {
+		currency, amount = code, rest
+	} else {
+		for _, cs := range currencySymbols {
+			if strings.HasPrefix(amount, cs.symbol) {
+				currency, amount = cs.currency, strings.TrimPrefix(amount, cs.symbol)
+				break
+			}
+		}
+	}
+
+	parsed, err := strconv.ParseFloat(strings.ReplaceAll(strings.TrimSpace(amount), ",", ""), 64)
+	if err != nil {
+		return 0, "", fmt.Errorf("invalid price '%s': %w", value, err)
+	}
+
+	return parsed, currency, nil
+}
+
+// splitCurrencyCode detects an ISO 4217 code before or after the amount.
+func splitCurrencyCode(value string) (string, string, bool) {
+	if len(value) < 4 {
+		return "", "", false
+	}
+
+	if isCurrencyCode(value[:3]) {
+		return value[:3], value[3:], true
+	}
+
+	if isCurrencyCode(value[len(value)-3:]) {
+		return value[len(value)-3:], value[:len(value)-3], true
+	}
+
+	return "", "", false
+}
+
+func isCurrencyCode(value string) bool {
+	for _, r := range value {
+		if !unicode.IsUpper(r) || r > unicode.MaxASCII {
+			return false
+		}
+	}
+
+	return len(value) == 3
+}
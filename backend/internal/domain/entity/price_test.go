@@ -0,0 +1,46 @@
+package entity
+
+import "testing"
+
+func TestParsePrice(t *testing.T) {
+	testCases := []struct {
+		value       string
+		amount      float64
+		currency    string
+		expectError bool
+	}{
+		{value: "$13.00", amount: 13, currency: "USD"},
+		{value: "$1,234.50", amount: 1234.5, currency: "USD"},
+		{value: "13.00", amount: 13, currency: "USD"},
+		{value: "€12.50", amount: 12.5, currency: "EUR"},
+		{value: "£1,000.00", amount: 1000, currency: "GBP"},
+		{value: "¥2,500.00", amount: 2500, currency: "JPY"},
+		{value: "C$45.10", amount: 45.1, currency: "CAD"},
+		{value: "HK$88.00", amount: 88, currency: "HKD"},
+		{value: "EUR 12.50", amount: 12.5, currency: "EUR"},
+		{value: "12.50 CHF", amount: 12.5, currency: "CHF"},
+		{value: "", expectError: true},
+		{value: "$abc", expectError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.value, func(t *testing.T) {
+			amount, currency, err := ParsePrice(tc.value)
+
+			if tc.expectError {
+				if err == nil {
+					t.Errorf("Expected an error, but got none")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("Expected no error, but got: %v", err)
+			}
+
+			if amount != tc.amount || currency != tc.currency {
+				t.Errorf("Expected %v %s, but got %v %s", tc.amount, tc.currency, amount, currency)
+			}
+		})
+	}
+}
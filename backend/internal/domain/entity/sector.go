@@ -0,0 +1,40 @@
+package entity
+
+import (
+	"context"
+	"time"
+)
+
+const UnclassifiedSector = "Unclassified"
+
+// TickerActivity holds the rating activity of a ticker within a window, the
+// building block of the sector and industry aggregates.
+type TickerActivity struct {
+	Sector               string
+	Industry             string
+	Ticker               string
+	Ratings              int
+	Upgrades             int
+	Downgrades           int
+	ScoreSum             float64
+	TargetPriceChangeSum float64
+}
+
+type SectorAggregate struct {
+	Sector               string            `json:"sector"`
+	Industry             string            `json:"industry,omitempty"`
+	Ratings              int               `json:"ratings"`
+	Upgrades             int               `json:"upgrades"`
+	Downgrades           int               `json:"downgrades"`
+	AvgScore             float64           `json:"avg_score"`
+	AvgTargetPriceChange float64           `json:"avg_target_price_change"`
+	TopTickers           []string          `json:"top_tickers"`
+	Industries           []SectorAggregate `json:"industries,omitempty"`
+}
+
+type ISectorRepository interface {
+	GetTickerActivity(ctx context.Context, since time.Time) ([]TickerActivity, error)
+	// GetSectorRecommendations returns the recommendations of the tickers of
+	// a sector, built from their ratings since the given time.
+	GetSectorRecommendations(ctx context.Context, sector string, since time.Time, pageSize int, sortBy string) ([]StockRatingAggregate, error)
+}
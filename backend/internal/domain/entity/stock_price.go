@@ -0,0 +1,27 @@
+package entity
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	ResolutionDay   = "D"
+	ResolutionWeek  = "W"
+	ResolutionMonth = "M"
+)
+
+type Candle struct {
+	Ticker string    `json:"ticker"`
+	Time   time.Time `json:"time"`
+	Open   float64   `json:"open"`
+	High   float64   `json:"high"`
+	Low    float64   `json:"low"`
+	Close  float64   `json:"close"`
+	Volume int64     `json:"volume"`
+}
+
+type IStockPriceRepository interface {
+	SaveCandles(ctx context.Context, candles []Candle) error
+	GetCandles(ctx context.Context, ticker string, from, to time.Time) ([]Candle, error)
+}
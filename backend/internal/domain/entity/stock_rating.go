@@ -2,9 +2,8 @@ package entity
 
 import (
 	"context"
+	"errors"
 	"time"
-
-	"github.com/Finnhub-Stock-API/finnhub-go/v2"
 )
 
 type StockRating struct {
@@ -16,37 +15,112 @@ type StockRating struct {
 	RatingTo          string    `json:"rating_to"`
 	TargetFrom        string    `json:"target_from"`
 	TargetTo          string    `json:"target_to"`
+	TargetFromValue   *float64  `json:"target_from_value"`
+	TargetToValue     *float64  `json:"target_to_value"`
+	Currency          *string   `json:"currency"`
 	Time              time.Time `json:"time"`
 	TargetPriceChange float64   `json:"target_price_change"`
 	Score             float32   `json:"score"`
+	ImpliedUpside     *float64  `json:"implied_upside"`
 }
 type StockRatingAggregate struct {
-	Ticker            string    `json:"ticker"`
-	Time              time.Time `json:"time"`
-	StrongBuyRatings  int       `json:"strong_buy_ratings"`
-	BuyRatings        int       `json:"buy_ratings"`
-	HoldRatings       int       `json:"hold_ratings"`
-	SellRatings       int       `json:"sell_ratings"`
-	Rating            string    `json:"rating"`
-	TargetPriceChange float64   `json:"target_price_change"`
-	Score             float32   `json:"score"`
+	Ticker                string    `json:"ticker"`
+	Time                  time.Time `json:"time"`
+	StrongBuyRatings      int       `json:"strong_buy_ratings"`
+	BuyRatings            int       `json:"buy_ratings"`
+	HoldRatings           int       `json:"hold_ratings"`
+	SellRatings           int       `json:"sell_ratings"`
+	Rating                string    `json:"rating"`
+	TargetPriceChange     float64   `json:"target_price_change"`
+	Score                 float32   `json:"score"`
+	CurrentPrice          *float64  `json:"current_price"`
+	ConsensusTargetMedian *float64  `json:"consensus_target_median"`
+	ConsensusTargetMean   *float64  `json:"consensus_target_mean"`
+	ConsensusTargetHigh   *float64  `json:"consensus_target_high"`
+	ConsensusTargetLow    *float64  `json:"consensus_target_low"`
+	ImpliedUpside         *float64  `json:"implied_upside"`
+}
+
+const (
+	RecommendationSortDefault = ""
+	RecommendationSortUpside  = "upside"
+)
+
+const (
+	SectionStatusOk    = "ok"
+	SectionStatusError = "error"
+
+	SectionErrorTimeout       = "timeout"
+	SectionErrorUpstreamError = "upstream_error"
+	SectionErrorNotFound      = "not_found"
+)
+
+var (
+	ErrDuplicateStockRating = errors.New("duplicate stock rating")
+	ErrInvalidCursor        = errors.New("invalid cursor")
+	ErrStockNotFound        = errors.New("stock not found")
+	ErrUpstreamTimeout      = errors.New("upstream request timed out")
+	ErrUpstreamUnavailable  = errors.New("upstream request failed")
+)
+
+// SectionStatus reports whether a section of the stock details could be
+// loaded and, when it could not, why.
+type SectionStatus struct {
+	Status  string `json:"status"`
+	Code    string `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+type StockDetailsStatus struct {
+	Quote           SectionStatus `json:"quote"`
+	Recommendations SectionStatus `json:"recommendations"`
+	KeyFacts        SectionStatus `json:"keyFacts"`
 }
 
 type StockDetails struct {
-	KeyFacts        string                         `json:"keyFacts"`
-	Quote           *finnhub.Quote                 `json:"quote"`
-	Recommendations *[]finnhub.RecommendationTrend `json:"recommendations"`
+	KeyFacts        string                `json:"keyFacts"`
+	Quote           *Quote                `json:"quote"`
+	Recommendations []RecommendationTrend `json:"recommendations"`
+	Status          StockDetailsStatus    `json:"status"`
 }
 
 type IStockRatingApi interface {
-	GetStockDetails(ctx context.Context, ticker string) *StockDetails
+	GetStockDetails(ctx context.Context, ticker string) (*StockDetails, error)
 	GetStockRatings(ctx context.Context, nextPage string, useCustomFormat bool) ([]StockRating, string, error)
 }
 
+// StockRatingListener is notified of every stock rating saved by the ingestion.
+type StockRatingListener interface {
+	StockRatingSaved(ctx context.Context, rating StockRating)
+}
+
 type IStockRatingRepository interface {
-	Save(ctx context.Context, stock StockRating)
-	GetStockRatings(ctx context.Context, nextPage string, pageSize int, search string) ([]StockRating, error)
-	GetStockRecommendations(ctx context.Context, pageSize int) ([]StockRatingAggregate, error)
+	Save(ctx context.Context, stock StockRating) error
+	// GetStockRatings returns the ratings after the cursor ordered by ticker,
+	// brokerage and newest first.
+	GetStockRatings(ctx context.Context, after StockRatingCursor, pageSize int, search string) ([]StockRating, error)
+	GetStockRecommendations(ctx context.Context, pageSize int, sortBy string, sector string) ([]StockRatingAggregate, error)
+	GetTickers(ctx context.Context) ([]string, error)
+	// GetLatestStockRatings returns, for each ticker, its limit most recent
+	// ratings, newest first.
+	GetLatestStockRatings(ctx context.Context, tickers []string, limit int) ([]StockRating, error)
+}
+
+func NewSectionOk() SectionStatus {
+	return SectionStatus{Status: SectionStatusOk}
+}
+
+func NewSectionError(code, message string) SectionStatus {
+	return SectionStatus{Status: SectionStatusError, Code: code, Message: message}
+}
+
+func (s SectionStatus) IsOk() bool {
+	return s.Status == SectionStatusOk
+}
+
+// IsOk reports whether every section of the stock details was loaded.
+func (s StockDetailsStatus) IsOk() bool {
+	return s.Quote.IsOk() && s.Recommendations.IsOk() && s.KeyFacts.IsOk()
 }
 
 func NewStockRating(brokerage, action, company, ticker, ratingFrom, ratingTo, targetFrom, targetTo string, time time.Time, targetPriceChange float64) StockRating {
@@ -0,0 +1,76 @@
+package entity
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+const (
+	EventIngestionCompleted     = "ingestion.completed"
+	EventRatingCreated          = "rating.created"
+	EventRecommendationsUpdated = "recommendations.updated"
+
+	DeliveryStatusPending   = "pending"
+	DeliveryStatusDelivered = "delivered"
+	DeliveryStatusFailed    = "failed"
+)
+
+var WebhookEvents = []string{EventIngestionCompleted, EventRatingCreated, EventRecommendationsUpdated}
+
+var (
+	ErrWebhookNotFound = errors.New("webhook not found")
+	ErrInvalidWebhook  = errors.New("invalid webhook")
+)
+
+// Webhook subscribes Url to Events. Payloads are signed with Secret, which is
+// only returned when the webhook is created.
+type Webhook struct {
+	ID        int64     `json:"id"`
+	Url       string    `json:"url"`
+	Secret    string    `json:"secret,omitempty"`
+	Events    []string  `json:"events"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// WebhookDelivery is one event queued for one webhook. Pending deliveries are
+// retried at NextAttemptAt until they are delivered or run out of attempts.
+type WebhookDelivery struct {
+	ID             int64           `json:"id"`
+	WebhookID      int64           `json:"webhook_id"`
+	Event          string          `json:"event"`
+	Payload        json.RawMessage `json:"payload"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	LastStatusCode *int            `json:"last_status_code"`
+	LastError      *string         `json:"last_error"`
+	NextAttemptAt  time.Time       `json:"next_attempt_at"`
+	CreatedAt      time.Time       `json:"created_at"`
+	DeliveredAt    *time.Time      `json:"delivered_at"`
+}
+
+// WebhookDispatch is a claimed delivery together with its webhook.
+type WebhookDispatch struct {
+	Webhook  Webhook
+	Delivery WebhookDelivery
+}
+
+type IWebhookRepository interface {
+	SaveWebhook(ctx context.Context, webhook Webhook) (*Webhook, error)
+	GetWebhooks(ctx context.Context) ([]Webhook, error)
+	DeleteWebhook(ctx context.Context, id int64) error
+	// EnqueueDeliveries queues payload for every webhook subscribed to event.
+	EnqueueDeliveries(ctx context.Context, event string, payload []byte) (int64, error)
+	// ClaimDeliveries returns up to limit pending deliveries that are due and
+	// pushes their next attempt to leaseUntil so no other dispatcher takes them.
+	ClaimDeliveries(ctx context.Context, limit int, leaseUntil time.Time) ([]WebhookDispatch, error)
+	UpdateDelivery(ctx context.Context, delivery WebhookDelivery) error
+	GetDeliveries(ctx context.Context, webhookID int64, nextPage string, pageSize int) ([]WebhookDelivery, error)
+}
+
+type IWebhookSender interface {
+	// Send posts the delivery payload to the webhook and returns the response
+	// status code, if any.
+	Send(ctx context.Context, webhook Webhook, delivery WebhookDelivery) (int, error)
+}
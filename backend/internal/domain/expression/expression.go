@@ -0,0 +1,380 @@
+// Package expression implements the small language used by alert rules to
+// match stock ratings, for example:
+//
+//	action == "upgraded by" && ticker in ["AAPL", "MSFT"]
+//	target_change > 25 || (rating_to == "Buy" && score >= 3.5)
+//
+// Expressions combine comparisons (==, !=, >, >=, <, <=, in) of the rating
+// fields with &&, || and !. String comparisons ignore case.
+package expression
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/rubenpad/srs/internal/domain/entity"
+)
+
+type valueKind int
+
+const (
+	kindString valueKind = iota
+	kindNumber
+	kindBool
+)
+
+type value struct {
+	kind   valueKind
+	str    string
+	number float64
+	bool   bool
+}
+
+// fields are the rating fields an expression can refer to.
+var fields = map[string]struct {
+	kind    valueKind
+	extract func(entity.StockRating) value
+}{
+	"ticker":      {kindString, func(r entity.StockRating) value { return stringValue(r.Ticker) }},
+	"company":     {kindString, func(r entity.StockRating) value { return stringValue(r.Company) }},
+	"brokerage":   {kindString, func(r entity.StockRating) value { return stringValue(r.Brokerage) }},
+	"action":      {kindString, func(r entity.StockRating) value { return stringValue(r.Action) }},
+	"rating_from": {kindString, func(r entity.StockRating) value { return stringValue(r.RatingFrom) }},
+	"rating_to":   {kindString, func(r entity.StockRating) value { return stringValue(r.RatingTo) }},
+	"currency":    {kindString, func(r entity.StockRating) value { return stringValue(deref(r.Currency, "")) }},
+	"target_from": {kindNumber, func(r entity.StockRating) value { return numberValue(deref(r.TargetFromValue, 0)) }},
+	"target_to":   {kindNumber, func(r entity.StockRating) value { return numberValue(deref(r.TargetToValue, 0)) }},
+	// target_change is the target price change in percent.
+	"target_change": {kindNumber, func(r entity.StockRating) value { return numberValue(r.TargetPriceChange * 100) }},
+	"score":         {kindNumber, func(r entity.StockRating) value { return numberValue(float64(r.Score)) }},
+}
+
+type node interface {
+	kind() valueKind
+	evaluate(rating entity.StockRating) value
+}
+
+// Expression is a compiled rule expression.
+type Expression struct {
+	source string
+	root   node
+}
+
+// Compile parses and type checks an expression.
+func Compile(source string) (*Expression, error) {
+	tokens, err := tokenize(source)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if next := p.peek(); next.kind != tokenEOF {
+		return nil, fmt.Errorf("unexpected %q at position %d", next.value, next.position)
+	}
+
+	if root.kind() != kindBool {
+		return nil, fmt.Errorf("expression must evaluate to a boolean")
+	}
+
+	return &Expression{source: source, root: root}, nil
+}
+
+// Match reports whether the rating satisfies the expression.
+func (e *Expression) Match(rating entity.StockRating) bool {
+	return e.root.evaluate(rating).bool
+}
+
+func (e *Expression) String() string {
+	return e.source
+}
+
+type parser struct {
+	tokens   []token
+	position int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.position]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.position]
+	if t.kind != tokenEOF {
+		p.position++
+	}
+	return t
+}
+
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek().kind == tokenOperator && p.peek().value == "||" {
+		operator := p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		if left, err = newLogical(operator, left, right); err != nil {
+			return nil, err
+		}
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek().kind == tokenOperator && p.peek().value == "&&" {
+		operator := p.next()
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		if left, err = newLogical(operator, left, right); err != nil {
+			return nil, err
+		}
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseNot() (node, error) {
+	if p.peek().kind == tokenOperator && p.peek().value == "!" {
+		operator := p.next()
+		operand, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		if operand.kind() != kindBool {
+			return nil, fmt.Errorf("operator ! at position %d expects a boolean", operator.position)
+		}
+		return &notNode{operand}, nil
+	}
+
+	return p.parseComparison()
+}
+
+func (p *parser) parseComparison() (node, error) {
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	t := p.peek()
+	switch {
+	case t.kind == tokenIdent && t.value == "in":
+		p.next()
+		list, err := p.parseList(left.kind())
+		if err != nil {
+			return nil, err
+		}
+		return &inNode{left, list}, nil
+	case t.kind == tokenOperator && t.value != "&&" && t.value != "||" && t.value != "!":
+		p.next()
+		right, err := p.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		return newComparison(t, left, right)
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseOperand() (node, error) {
+	t := p.next()
+
+	switch t.kind {
+	case tokenLeftParen:
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.kind != tokenRightParen {
+			return nil, fmt.Errorf("expected ) at position %d", closing.position)
+		}
+		return inner, nil
+	case tokenString:
+		return &literalNode{stringValue(t.value)}, nil
+	case tokenNumber:
+		number, err := strconv.ParseFloat(t.value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q at position %d", t.value, t.position)
+		}
+		return &literalNode{numberValue(number)}, nil
+	case tokenIdent:
+		switch t.value {
+		case "true", "false":
+			return &literalNode{value{kind: kindBool, bool: t.value == "true"}}, nil
+		}
+		field, ok := fields[t.value]
+		if !ok {
+			return nil, fmt.Errorf("unknown field %q at position %d", t.value, t.position)
+		}
+		return &fieldNode{field.kind, field.extract}, nil
+	case tokenEOF:
+		return nil, fmt.Errorf("unexpected end of expression")
+	}
+
+	return nil, fmt.Errorf("unexpected %q at position %d", t.value, t.position)
+}
+
+func (p *parser) parseList(kind valueKind) ([]value, error) {
+	if opening := p.next(); opening.kind != tokenLeftBracket {
+		return nil, fmt.Errorf("expected [ at position %d", opening.position)
+	}
+
+	var list []value
+	for p.peek().kind != tokenRightBracket {
+		if len(list) > 0 {
+			if comma := p.next(); comma.kind != tokenComma {
+				return nil, fmt.Errorf("expected , at position %d", comma.position)
+			}
+		}
+
+		operand, err := p.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+
+		literal, ok := operand.(*literalNode)
+		if !ok || literal.value.kind != kind {
+			return nil, fmt.Errorf("list items must be literals of the same type as the compared field")
+		}
+		list = append(list, literal.value)
+	}
+
+	p.next()
+	return list, nil
+}
+
+func newLogical(operator token, left, right node) (node, error) {
+	if left.kind() != kindBool || right.kind() != kindBool {
+		return nil, fmt.Errorf("operator %s at position %d expects booleans", operator.value, operator.position)
+	}
+
+	return &logicalNode{operator.value, left, right}, nil
+}
+
+func newComparison(operator token, left, right node) (node, error) {
+	if left.kind() != right.kind() {
+		return nil, fmt.Errorf("operator %s at position %d compares values of different types", operator.value, operator.position)
+	}
+
+	if left.kind() != kindNumber && operator.value != "==" && operator.value != "!=" {
+		return nil, fmt.Errorf("operator %s at position %d expects numbers", operator.value, operator.position)
+	}
+
+	return &comparisonNode{operator.value, left, right}, nil
+}
+
+type literalNode struct{ value value }
+
+func (n *literalNode) kind() valueKind                   { return n.value.kind }
+func (n *literalNode) evaluate(entity.StockRating) value { return n.value }
+
+type fieldNode struct {
+	valueKind valueKind
+	extract   func(entity.StockRating) value
+}
+
+func (n *fieldNode) kind() valueKind                          { return n.valueKind }
+func (n *fieldNode) evaluate(rating entity.StockRating) value { return n.extract(rating) }
+
+type notNode struct{ operand node }
+
+func (n *notNode) kind() valueKind { return kindBool }
+func (n *notNode) evaluate(rating entity.StockRating) value {
+	return boolValue(!n.operand.evaluate(rating).bool)
+}
+
+type logicalNode struct {
+	operator    string
+	left, right node
+}
+
+func (n *logicalNode) kind() valueKind { return kindBool }
+func (n *logicalNode) evaluate(rating entity.StockRating) value {
+	if n.operator == "&&" {
+		return boolValue(n.left.evaluate(rating).bool && n.right.evaluate(rating).bool)
+	}
+
+	return boolValue(n.left.evaluate(rating).bool || n.right.evaluate(rating).bool)
+}
+
+type comparisonNode struct {
+	operator    string
+	left, right node
+}
+
+func (n *comparisonNode) kind() valueKind { return kindBool }
+func (n *comparisonNode) evaluate(rating entity.StockRating) value {
+	left, right := n.left.evaluate(rating), n.right.evaluate(rating)
+
+	switch n.operator {
+	case "==":
+		return boolValue(equal(left, right))
+	case "!=":
+		return boolValue(!equal(left, right))
+	case ">":
+		return boolValue(left.number > right.number)
+	case ">=":
+		return boolValue(left.number >= right.number)
+	case "<":
+		return boolValue(left.number < right.number)
+	default:
+		return boolValue(left.number <= right.number)
+	}
+}
+
+type inNode struct {
+	operand node
+	list    []value
+}
+
+func (n *inNode) kind() valueKind { return kindBool }
+func (n *inNode) evaluate(rating entity.StockRating) value {
+	operand := n.operand.evaluate(rating)
+	for _, item := range n.list {
+		if equal(operand, item) {
+			return boolValue(true)
+		}
+	}
+
+	return boolValue(false)
+}
+
+func equal(left, right value) bool {
+	switch left.kind {
+	case kindString:
+		return strings.EqualFold(left.str, right.str)
+	case kindNumber:
+		return left.number == right.number
+	default:
+		return left.bool == right.bool
+	}
+}
+
+func stringValue(s string) value  { return value{kind: kindString, str: s} }
+func numberValue(n float64) value { return value{kind: kindNumber, number: n} }
+func boolValue(b bool) value      { return value{kind: kindBool, bool: b} }
+
+func deref[T any](pointer *T, fallback T) T {
+	if pointer == nil {
+		return fallback
+	}
+	return *pointer
+}
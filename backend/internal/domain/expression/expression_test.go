@@ -0,0 +1,82 @@
+package expression
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rubenpad/srs/internal/domain/entity"
+)
+
+func TestExpressionMatch(t *testing.T) {
+	targetTo := 15.0
+	rating := entity.StockRating{
+		Ticker:            "AAPL",
+		Brokerage:         "Benchmark",
+		Action:            "upgraded by",
+		RatingFrom:        "Hold",
+		RatingTo:          "Buy",
+		TargetToValue:     &targetTo,
+		TargetPriceChange: 0.5,
+		Score:             4.2,
+	}
+
+	testCases := []struct {
+		expression string
+		expected   bool
+	}{
+		{`action == "upgraded by"`, true},
+		{`action == 'UPGRADED BY' && ticker in ["MSFT", "AAPL"]`, true},
+		{`ticker in ["MSFT"]`, false},
+		{`target_change > 25`, true},
+		{`target_change > 50`, false},
+		{`target_to >= 15 && score < 5`, true},
+		{`!(rating_to == "Buy") || brokerage != "Benchmark"`, false},
+		{`rating_from == "Sell" || (rating_to == "Buy" && score >= 4)`, true},
+		{`currency == ""`, true},
+		{`true`, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expression, func(t *testing.T) {
+			compiled, err := Compile(tc.expression)
+			if err != nil {
+				t.Fatalf("Expected no error, but got: %v", err)
+			}
+
+			if actual := compiled.Match(rating); actual != tc.expected {
+				t.Errorf("Expected %v, but got %v", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestExpressionCompileErrors(t *testing.T) {
+	testCases := []struct {
+		expression string
+		errorMsg   string
+	}{
+		{`price > 10`, "unknown field"},
+		{`ticker > 10`, "different types"},
+		{`ticker > "A"`, "expects numbers"},
+		{`ticker`, "must evaluate to a boolean"},
+		{`ticker == "AAPL" &&`, "unexpected end"},
+		{`ticker == "AAPL`, "unterminated string"},
+		{`ticker in ["AAPL", 1]`, "same type"},
+		{`(score > 1`, "expected )"},
+		{`score > 1 score`, "unexpected"},
+		{`score # 1`, "unexpected character"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expression, func(t *testing.T) {
+			_, err := Compile(tc.expression)
+			if err == nil {
+				t.Fatalf("Expected an error, but got none")
+			}
+
+			if !strings.Contains(err.Error(), tc.errorMsg) {
+				t.Errorf("Expected error message containing '%s', but got '%v'", tc.errorMsg, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,186 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rubenpad/srs/internal/domain/entity"
+	"github.com/rubenpad/srs/internal/domain/expression"
+)
+
+const (
+	alertRulesRefreshInterval = 1 * time.Minute
+	alertDeliveryTimeout      = 30 * time.Second
+)
+
+type compiledAlertRule struct {
+	rule       entity.AlertRule
+	expression *expression.Expression
+}
+
+// AlertService evaluates the alert rules against every saved stock rating and
+// delivers the resulting alert events through the notifiers.
+type AlertService struct {
+	mu              sync.Mutex
+	rules           []compiledAlertRule
+	rulesLoadedAt   time.Time
+	alertRepository entity.IAlertRepository
+	notifiers       map[string]entity.INotifier
+}
+
+func NewAlertService(alertRepository entity.IAlertRepository, notifiers map[string]entity.INotifier) *AlertService {
+	return &AlertService{
+		alertRepository: alertRepository,
+		notifiers:       notifiers,
+	}
+}
+
+func (s *AlertService) CreateRule(ctx context.Context, rule entity.AlertRule) (*entity.AlertRule, error) {
+	if strings.TrimSpace(rule.Name) == "" {
+		return nil, fmt.Errorf("%w: name is required", entity.ErrInvalidAlertRule)
+	}
+
+	if _, err := expression.Compile(rule.Expression); err != nil {
+		return nil, fmt.Errorf("%w: %v", entity.ErrInvalidAlertRule, err)
+	}
+
+	if len(rule.Notifiers) == 0 {
+		rule.Notifiers = []string{entity.NotifierLog}
+	}
+
+	for _, name := range rule.Notifiers {
+		if _, ok := s.notifiers[name]; !ok {
+			return nil, fmt.Errorf("%w: notifier %q is not configured", entity.ErrInvalidAlertRule, name)
+		}
+	}
+
+	saved, err := s.alertRepository.SaveRule(ctx, rule)
+	if err != nil {
+		return nil, err
+	}
+
+	s.invalidateRules()
+	return saved, nil
+}
+
+func (s *AlertService) GetRules(ctx context.Context) (*serviceResponse[entity.AlertRule], error) {
+	rules, err := s.alertRepository.GetRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &serviceResponse[entity.AlertRule]{Data: rules}, nil
+}
+
+func (s *AlertService) DeleteRule(ctx context.Context, id int64) error {
+	if err := s.alertRepository.DeleteRule(ctx, id); err != nil {
+		return err
+	}
+
+	s.invalidateRules()
+	return nil
+}
+
+func (s *AlertService) GetAlerts(ctx context.Context, nextPage string, pageSize int) (*serviceResponse[entity.AlertEvent], error) {
+	pageSizePlusOne := pageSize + 1
+	events, err := s.alertRepository.GetEvents(ctx, nextPage, pageSizePlusOne)
+	if err != nil {
+		return nil, err
+	}
+
+	nNextPage := ""
+	if len(events) == pageSizePlusOne {
+		events = events[:pageSize]
+		nNextPage = fmt.Sprint(events[pageSize-1].ID)
+	}
+
+	return &serviceResponse[entity.AlertEvent]{
+		Data:     events,
+		NextPage: nNextPage,
+	}, nil
+}
+
+// StockRatingSaved implements entity.StockRatingListener.
+func (s *AlertService) StockRatingSaved(ctx context.Context, rating entity.StockRating) {
+	for _, rule := range s.activeRules(ctx) {
+		if !rule.expression.Match(rating) {
+			continue
+		}
+
+		event, err := s.alertRepository.SaveEvent(ctx, entity.AlertEvent{
+			RuleID:   rule.rule.ID,
+			RuleName: rule.rule.Name,
+			Ticker:   rating.Ticker,
+			Rating:   rating,
+		})
+		if err != nil {
+			continue
+		}
+
+		slog.InfoContext(ctx, "alert rule matched", "rule", rule.rule.Name, "ticker", rating.Ticker, "brokerage", rating.Brokerage)
+		go s.deliver(context.WithoutCancel(ctx), rule.rule.Notifiers, *event)
+	}
+}
+
+func (s *AlertService) deliver(ctx context.Context, notifiers []string, event entity.AlertEvent) {
+	ctx, cancel := context.WithTimeout(ctx, alertDeliveryTimeout)
+	defer cancel()
+
+	for _, name := range notifiers {
+		notifier, ok := s.notifiers[name]
+		if !ok {
+			slog.WarnContext(ctx, "alert notifier not configured", "notifier", name, "rule", event.RuleName)
+			continue
+		}
+
+		if err := notifier.Notify(ctx, event); err != nil {
+			slog.ErrorContext(ctx, "error delivering alert", "error", err, "notifier", name, "rule", event.RuleName)
+		}
+	}
+}
+
+// activeRules returns the enabled rules, reloading them from the repository
+// at most once per alertRulesRefreshInterval.
+func (s *AlertService) activeRules(ctx context.Context) []compiledAlertRule {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if time.Since(s.rulesLoadedAt) < alertRulesRefreshInterval {
+		return s.rules
+	}
+
+	rules, err := s.alertRepository.GetRules(ctx)
+	if err != nil {
+		return s.rules
+	}
+
+	compiled := make([]compiledAlertRule, 0, len(rules))
+	for _, rule := range rules {
+		if !rule.Enabled {
+			continue
+		}
+
+		compiledExpression, err := expression.Compile(rule.Expression)
+		if err != nil {
+			slog.WarnContext(ctx, "skipping invalid alert rule", "rule", rule.Name, "error", err)
+			continue
+		}
+
+		compiled = append(compiled, compiledAlertRule{rule, compiledExpression})
+	}
+
+	s.rules = compiled
+	s.rulesLoadedAt = time.Now()
+	return s.rules
+}
+
+func (s *AlertService) invalidateRules() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.rulesLoadedAt = time.Time{}
+}
@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rubenpad/srs/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockAlertRepository struct {
+	mock.Mock
+}
+
+func (m *MockAlertRepository) SaveRule(ctx context.Context, rule entity.AlertRule) (*entity.AlertRule, error) {
+	args := m.Called(ctx, rule)
+	return args.Get(0).(*entity.AlertRule), args.Error(1)
+}
+
+func (m *MockAlertRepository) GetRules(ctx context.Context) ([]entity.AlertRule, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]entity.AlertRule), args.Error(1)
+}
+
+func (m *MockAlertRepository) DeleteRule(ctx context.Context, id int64) error {
+	return m.Called(ctx, id).Error(0)
+}
+
+func (m *MockAlertRepository) SaveEvent(ctx context.Context, event entity.AlertEvent) (*entity.AlertEvent, error) {
+	args := m.Called(ctx, event)
+	return args.Get(0).(*entity.AlertEvent), args.Error(1)
+}
+
+func (m *MockAlertRepository) GetEvents(ctx context.Context, nextPage string, pageSize int) ([]entity.AlertEvent, error) {
+	args := m.Called(ctx, nextPage, pageSize)
+	return args.Get(0).([]entity.AlertEvent), args.Error(1)
+}
+
+func TestAlertServiceCreateRuleValidation(t *testing.T) {
+	service := NewAlertService(new(MockAlertRepository), map[string]entity.INotifier{})
+
+	testCases := []struct {
+		name string
+		rule entity.AlertRule
+	}{
+		{name: "Missing Name", rule: entity.AlertRule{Expression: `ticker == "AAPL"`}},
+		{name: "Invalid Expression", rule: entity.AlertRule{Name: "apple", Expression: `ticker ==`}},
+		{name: "Unknown Notifier", rule: entity.AlertRule{Name: "apple", Expression: `ticker == "AAPL"`, Notifiers: []string{"pager"}}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := service.CreateRule(context.Background(), tc.rule)
+			assert.ErrorIs(t, err, entity.ErrInvalidAlertRule)
+		})
+	}
+}
+
+func TestAlertServiceStockRatingSaved(t *testing.T) {
+	ctx := context.Background()
+	mockRepository := new(MockAlertRepository)
+	service := NewAlertService(mockRepository, map[string]entity.INotifier{})
+
+	mockRepository.On("GetRules", ctx).Return([]entity.AlertRule{
+		{ID: 1, Name: "upgrades", Expression: `action == "upgraded by"`, Enabled: true},
+		{ID: 2, Name: "disabled", Expression: `ticker == "AAPL"`, Enabled: false},
+	}, nil).Once()
+	mockRepository.On("SaveEvent", ctx, mock.MatchedBy(func(event entity.AlertEvent) bool {
+		return event.RuleID == 1 && event.Ticker == "AAPL"
+	})).Return(&entity.AlertEvent{ID: 10, RuleID: 1}, nil).Once()
+
+	service.StockRatingSaved(ctx, entity.StockRating{Ticker: "AAPL", Action: "upgraded by"})
+	service.StockRatingSaved(ctx, entity.StockRating{Ticker: "AAPL", Action: "target raised by"})
+
+	mockRepository.AssertExpectations(t)
+}
@@ -0,0 +1,177 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/rubenpad/srs/internal/domain/entity"
+)
+
+type CompanyService struct {
+	isLoading             atomic.Bool
+	companySource         entity.ICompanySource
+	companyRepository     entity.ICompanyRepository
+	stockRatingRepository entity.IStockRatingRepository
+	marketDataProvider    entity.MarketDataProvider
+}
+
+// NewCompanyService creates the service. companySource is optional; without
+// it companies are only loaded from the market data provider.
+func NewCompanyService(companyRepository entity.ICompanyRepository, stockRatingRepository entity.IStockRatingRepository, marketDataProvider entity.MarketDataProvider, companySource entity.ICompanySource) *CompanyService {
+	return &CompanyService{
+		companySource:         companySource,
+		companyRepository:     companyRepository,
+		stockRatingRepository: stockRatingRepository,
+		marketDataProvider:    marketDataProvider,
+	}
+}
+
+func (s *CompanyService) GetCompany(ctx context.Context, ticker string) (*entity.Company, error) {
+	return s.companyRepository.GetCompany(ctx, strings.ToUpper(ticker))
+}
+
+// LoadCompaniesData saves the companies of the company source and then asks
+// the market data provider for the rated tickers that are still unknown.
+func (s *CompanyService) LoadCompaniesData(ctx context.Context) {
+	if !s.isLoading.CompareAndSwap(false, true) {
+		slog.InfoContext(ctx, "load companies process already running")
+		return
+	}
+
+	defer s.isLoading.Store(false)
+
+	slog.InfoContext(ctx, "process to load companies started")
+	start := time.Now()
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Minute)
+	defer cancel()
+
+	known := make(map[string]bool)
+
+	if s.companySource != nil {
+		companies, err := s.companySource.GetCompanies(timeoutCtx)
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to get companies from source", "error", err)
+		}
+
+		for _, company := range companies {
+			if err := s.companyRepository.Save(timeoutCtx, company); err == nil {
+				known[company.Ticker] = true
+			}
+		}
+	}
+
+	stored, err := s.companyRepository.GetCompanies(timeoutCtx)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to get stored companies", "error", err)
+		return
+	}
+
+	index := newCompanyIndex(stored)
+	for _, company := range stored {
+		known[company.Ticker] = true
+	}
+
+	tickers, err := s.stockRatingRepository.GetTickers(timeoutCtx)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to get tickers to load companies", "error", err)
+		return
+	}
+
+	for _, ticker := range tickers {
+		if known[index.currentTicker(ticker)] {
+			continue
+		}
+
+		select {
+		case <-timeoutCtx.Done():
+			slog.WarnContext(ctx, "load companies process timed out")
+			return
+		default:
+		}
+
+		company, err := s.marketDataProvider.GetCompanyProfile(timeoutCtx, ticker)
+		if errors.Is(err, entity.ErrStockNotFound) {
+			continue
+		}
+
+		if err != nil {
+			slog.ErrorContext(ctx, "error getting company profile", "error", err, "ticker", ticker)
+			continue
+		}
+
+		if err := s.companyRepository.Save(timeoutCtx, *company); err == nil {
+			known[company.Ticker] = true
+		}
+	}
+
+	elapsed := time.Since(start)
+	minutes := int(elapsed.Minutes())
+	seconds := int(elapsed.Seconds()) % 60
+	milliseconds := int(elapsed.Milliseconds()) % 1000
+	duration := fmt.Sprintf("%dm %ds %dms", minutes, seconds, milliseconds)
+	slog.InfoContext(ctx, "process to load companies finished", "duration", duration, "companies", len(known))
+}
+
+// companyIndex resolves the canonical ticker and name of the companies in the
+// incoming stock ratings.
+type companyIndex struct {
+	byTicker       map[string]entity.Company
+	byName         map[string]entity.Company
+	renamedTickers map[string]string
+}
+
+func newCompanyIndex(companies []entity.Company) *companyIndex {
+	index := &companyIndex{
+		byTicker:       make(map[string]entity.Company, len(companies)),
+		byName:         make(map[string]entity.Company, len(companies)),
+		renamedTickers: make(map[string]string),
+	}
+
+	for _, company := range companies {
+		index.byTicker[company.Ticker] = company
+		index.byName[normalizeCompanyName(company.Name)] = company
+
+		for _, alias := range company.Aliases {
+			index.byName[normalizeCompanyName(alias)] = company
+		}
+
+		for _, change := range company.SymbolHistory {
+			index.renamedTickers[change.FromTicker] = company.Ticker
+		}
+	}
+
+	return index
+}
+
+func (ci *companyIndex) currentTicker(ticker string) string {
+	if current, ok := ci.renamedTickers[ticker]; ok {
+		return current
+	}
+
+	return ticker
+}
+
+func (ci *companyIndex) normalize(rating entity.StockRating) entity.StockRating {
+	rating.Ticker = ci.currentTicker(rating.Ticker)
+
+	if company, ok := ci.byTicker[rating.Ticker]; ok {
+		rating.Company = company.Name
+		return rating
+	}
+
+	if company, ok := ci.byName[normalizeCompanyName(rating.Company)]; ok {
+		rating.Company = company.Name
+	}
+
+	return rating
+}
+
+func normalizeCompanyName(name string) string {
+	return strings.ToLower(strings.TrimRight(strings.Join(strings.Fields(name), " "), "."))
+}
@@ -0,0 +1,48 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/rubenpad/srs/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompanyIndexNormalize(t *testing.T) {
+	index := newCompanyIndex([]entity.Company{
+		{Ticker: "RYN", Name: "Rayonier Inc.", Aliases: []string{"Rayonier"}},
+		{Ticker: "META", Name: "Meta Platforms, Inc.", SymbolHistory: []entity.SymbolChange{{FromTicker: "FB", ToTicker: "META"}}},
+	})
+
+	testCases := []struct {
+		name     string
+		rating   entity.StockRating
+		expected entity.StockRating
+	}{
+		{
+			name:     "Known Ticker",
+			rating:   entity.StockRating{Ticker: "RYN", Company: "Rayonier"},
+			expected: entity.StockRating{Ticker: "RYN", Company: "Rayonier Inc."},
+		},
+		{
+			name:     "Renamed Ticker",
+			rating:   entity.StockRating{Ticker: "FB", Company: "Facebook"},
+			expected: entity.StockRating{Ticker: "META", Company: "Meta Platforms, Inc."},
+		},
+		{
+			name:     "Alias On Other Listing",
+			rating:   entity.StockRating{Ticker: "RYN.X", Company: "rayonier "},
+			expected: entity.StockRating{Ticker: "RYN.X", Company: "Rayonier Inc."},
+		},
+		{
+			name:     "Unknown Company",
+			rating:   entity.StockRating{Ticker: "MOMO", Company: "Hello Group"},
+			expected: entity.StockRating{Ticker: "MOMO", Company: "Hello Group"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, index.normalize(tc.rating))
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+
+	"github.com/rubenpad/srs/internal/domain/entity"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// instrumentationName is the scope of the service meters and tracers.
+const instrumentationName = "github.com/rubenpad/srs"
+
+var tracer = otel.Tracer(instrumentationName)
+
+type ingestionMetrics struct {
+	meter      metric.Meter
+	pages      metric.Int64Counter
+	saved      metric.Int64Counter
+	duplicates metric.Int64Counter
+	queueDepth metric.Int64ObservableGauge
+}
+
+// newIngestionMetrics creates the ingestion instruments. The queue depth is
+// only observed while a load runs, see observeQueueDepth.
+func newIngestionMetrics() ingestionMetrics {
+	meter := otel.Meter(instrumentationName)
+
+	pages, err := meter.Int64Counter("srs.ingestion.pages",
+		metric.WithDescription("Pages read from the stock ratings API."))
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	saved, err := meter.Int64Counter("srs.ingestion.ratings.saved",
+		metric.WithDescription("Stock ratings saved by the ingestion."))
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	duplicates, err := meter.Int64Counter("srs.ingestion.ratings.duplicates",
+		metric.WithDescription("Stock ratings skipped by the ingestion because they were already saved."))
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	queueDepth, err := meter.Int64ObservableGauge("srs.ingestion.queue.depth",
+		metric.WithDescription("Stock ratings waiting for a worker to save them."))
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	return ingestionMetrics{meter: meter, pages: pages, saved: saved, duplicates: duplicates, queueDepth: queueDepth}
+}
+
+// observeQueueDepth observes the length of queue as the queue depth until the
+// returned function is called. The callback is registered per load, not per
+// service, so it does not outlive the queue.
+func (m ingestionMetrics) observeQueueDepth(queue chan entity.StockRating) func() {
+	registration, err := m.meter.RegisterCallback(func(_ context.Context, observer metric.Observer) error {
+		observer.ObserveInt64(m.queueDepth, int64(len(queue)))
+		return nil
+	}, m.queueDepth)
+	if err != nil {
+		otel.Handle(err)
+		return func() {}
+	}
+
+	return func() {
+		if err := registration.Unregister(); err != nil {
+			otel.Handle(err)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rubenpad/srs/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestObserveQueueDepth(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	otel.SetMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))
+
+	queueDepth := func() []int64 {
+		var metrics metricdata.ResourceMetrics
+		require.NoError(t, reader.Collect(context.Background(), &metrics))
+
+		depths := []int64{}
+		for _, scope := range metrics.ScopeMetrics {
+			for _, m := range scope.Metrics {
+				if m.Name != "srs.ingestion.queue.depth" {
+					continue
+				}
+
+				for _, point := range m.Data.(metricdata.Gauge[int64]).DataPoints {
+					depths = append(depths, point.Value)
+				}
+			}
+		}
+
+		return depths
+	}
+
+	// Services created before and after a load must not leave callbacks
+	// behind observing queues that are gone.
+	NewStockRatingService(nil, nil, nil, nil)
+	metrics := NewStockRatingService(nil, nil, nil, nil).metrics
+
+	queue := make(chan entity.StockRating, 2)
+	queue <- entity.StockRating{}
+	stopObserving := metrics.observeQueueDepth(queue)
+
+	assert.Equal(t, []int64{1}, queueDepth())
+
+	stopObserving()
+	assert.Empty(t, queueDepth())
+}
@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/rubenpad/srs/internal/domain/entity"
+)
+
+const topTickersSize = 5
+
+type SectorService struct {
+	sectorRepository   entity.ISectorRepository
+	marketDataProvider entity.MarketDataProvider
+}
+
+func NewSectorService(sectorRepository entity.ISectorRepository, marketDataProvider entity.MarketDataProvider) *SectorService {
+	return &SectorService{sectorRepository, marketDataProvider}
+}
+
+// GetSectors rolls the rating activity of the last window up to sectors and
+// their industries.
+func (s *SectorService) GetSectors(ctx context.Context, window time.Duration) (*serviceResponse[entity.SectorAggregate], error) {
+	activity, err := s.sectorRepository.GetTickerActivity(ctx, time.Now().Add(-window).Truncate(24*time.Hour))
+	if err != nil {
+		return nil, err
+	}
+
+	return &serviceResponse[entity.SectorAggregate]{
+		Data: aggregateSectors(activity),
+	}, nil
+}
+
+// GetSectorRecommendations returns the best rated tickers of a sector over
+// the last window, with the implied upside of those without stored prices
+// taken from their quotes as GetStockRecommendations does.
+func (s *SectorService) GetSectorRecommendations(ctx context.Context, sector string, window time.Duration, pageSize int, sortBy string) (*serviceResponse[entity.StockRatingAggregate], error) {
+	recommendations, err := s.sectorRepository.GetSectorRecommendations(ctx, sector, time.Now().Add(-window).Truncate(24*time.Hour), pageSize, sortBy)
+	if err != nil {
+		return nil, err
+	}
+
+	if fillImpliedUpside(ctx, s.marketDataProvider, recommendations) && sortBy == entity.RecommendationSortUpside {
+		sortByUpside(recommendations)
+	}
+
+	return &serviceResponse[entity.StockRatingAggregate]{
+		Data: recommendations,
+	}, nil
+}
+
+type activityGroup struct {
+	aggregate            entity.SectorAggregate
+	scoreSum             float64
+	targetPriceChangeSum float64
+	tickers              []entity.TickerActivity
+}
+
+func (g *activityGroup) add(activity entity.TickerActivity) {
+	g.aggregate.Ratings += activity.Ratings
+	g.aggregate.Upgrades += activity.Upgrades
+	g.aggregate.Downgrades += activity.Downgrades
+	g.scoreSum += activity.ScoreSum
+	g.targetPriceChangeSum += activity.TargetPriceChangeSum
+	g.tickers = append(g.tickers, activity)
+}
+
+func (g *activityGroup) build() entity.SectorAggregate {
+	aggregate := g.aggregate
+
+	if aggregate.Ratings > 0 {
+		aggregate.AvgScore = round2(g.scoreSum / float64(aggregate.Ratings))
+		aggregate.AvgTargetPriceChange = round2(g.targetPriceChangeSum / float64(aggregate.Ratings) * 100)
+	}
+
+	sort.SliceStable(g.tickers, func(i, j int) bool {
+		return g.tickers[i].ScoreSum/float64(g.tickers[i].Ratings) > g.tickers[j].ScoreSum/float64(g.tickers[j].Ratings)
+	})
+
+	aggregate.TopTickers = make([]string, 0, topTickersSize)
+	for _, ticker := range g.tickers[:min(topTickersSize, len(g.tickers))] {
+		aggregate.TopTickers = append(aggregate.TopTickers, ticker.Ticker)
+	}
+
+	return aggregate
+}
+
+// aggregateSectors expects the activity ordered by sector and industry.
+func aggregateSectors(activity []entity.TickerActivity) []entity.SectorAggregate {
+	var sectors []entity.SectorAggregate
+	var sector, industry *activityGroup
+	var industries []entity.SectorAggregate
+
+	flushIndustry := func() {
+		if industry != nil {
+			industries = append(industries, industry.build())
+			industry = nil
+		}
+	}
+
+	flushSector := func() {
+		flushIndustry()
+		if sector != nil {
+			aggregate := sector.build()
+			aggregate.Industries = industries
+			sectors = append(sectors, aggregate)
+			sector, industries = nil, nil
+		}
+	}
+
+	for _, ticker := range activity {
+		if sector == nil || sector.aggregate.Sector != ticker.Sector {
+			flushSector()
+			sector = &activityGroup{aggregate: entity.SectorAggregate{Sector: ticker.Sector}}
+		}
+
+		if industry == nil || industry.aggregate.Industry != ticker.Industry {
+			flushIndustry()
+			industry = &activityGroup{aggregate: entity.SectorAggregate{Sector: ticker.Sector, Industry: ticker.Industry}}
+		}
+
+		sector.add(ticker)
+		industry.add(ticker)
+	}
+
+	flushSector()
+
+	sort.SliceStable(sectors, func(i, j int) bool {
+		return sectors[i].AvgScore > sectors[j].AvgScore
+	})
+
+	if sectors == nil {
+		return []entity.SectorAggregate{}
+	}
+
+	return sectors
+}
+
+func round2(value float64) float64 {
+	return math.Round(value*100) / 100
+}
@@ -0,0 +1,37 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/rubenpad/srs/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAggregateSectors(t *testing.T) {
+	activity := []entity.TickerActivity{
+		{Sector: "Energy", Industry: "Oil & Gas", Ticker: "XOM", Ratings: 2, Upgrades: 1, ScoreSum: 6, TargetPriceChangeSum: 0.2},
+		{Sector: "Technology", Industry: "Semiconductors", Ticker: "NVDA", Ratings: 2, Upgrades: 2, ScoreSum: 9, TargetPriceChangeSum: 0.5},
+		{Sector: "Technology", Industry: "Software", Ticker: "MSFT", Ratings: 1, Downgrades: 1, ScoreSum: 2, TargetPriceChangeSum: -0.1},
+		{Sector: "Technology", Industry: "Software", Ticker: "ORCL", Ratings: 1, ScoreSum: 3, TargetPriceChangeSum: 0},
+	}
+
+	sectors := aggregateSectors(activity)
+
+	assert.Len(t, sectors, 2)
+
+	technology := sectors[0]
+	assert.Equal(t, "Technology", technology.Sector)
+	assert.Equal(t, 4, technology.Ratings)
+	assert.Equal(t, 2, technology.Upgrades)
+	assert.Equal(t, 1, technology.Downgrades)
+	assert.Equal(t, 3.5, technology.AvgScore)
+	assert.Equal(t, 10.0, technology.AvgTargetPriceChange)
+	assert.Equal(t, []string{"NVDA", "ORCL", "MSFT"}, technology.TopTickers)
+	assert.Len(t, technology.Industries, 2)
+	assert.Equal(t, "Software", technology.Industries[1].Industry)
+	assert.Equal(t, []string{"ORCL", "MSFT"}, technology.Industries[1].TopTickers)
+
+	assert.Equal(t, "Energy", sectors[1].Sector)
+	assert.Equal(t, 3.0, sectors[1].AvgScore)
+	assert.Empty(t, aggregateSectors(nil))
+}
@@ -0,0 +1,197 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/rubenpad/srs/internal/domain/entity"
+)
+
+const (
+	priceWorkers = 2
+
+	// ingestedPriceDays is how much history is loaded for the tickers of a
+	// stock ratings load.
+	ingestedPriceDays = 365
+)
+
+type StockPriceService struct {
+	isLoading             atomic.Bool
+	mu                    sync.Mutex
+	ingestedTickers       map[string]struct{}
+	marketDataProvider    entity.MarketDataProvider
+	stockPriceRepository  entity.IStockPriceRepository
+	stockRatingRepository entity.IStockRatingRepository
+}
+
+func NewStockPriceService(stockPriceRepository entity.IStockPriceRepository, stockRatingRepository entity.IStockRatingRepository, marketDataProvider entity.MarketDataProvider) *StockPriceService {
+	return &StockPriceService{
+		marketDataProvider:    marketDataProvider,
+		stockPriceRepository:  stockPriceRepository,
+		stockRatingRepository: stockRatingRepository,
+	}
+}
+
+// GetCandles returns the stored daily candles of a ticker between from and to,
+// aggregated to the requested resolution.
+func (s *StockPriceService) GetCandles(ctx context.Context, ticker string, from, to time.Time, resolution string) ([]entity.Candle, error) {
+	candles, err := s.stockPriceRepository.GetCandles(ctx, ticker, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return aggregateCandles(candles, resolution), nil
+}
+
+// LoadStockPricesData fetches the daily candles between from and to for every
+// ticker with stored ratings and saves them.
+func (s *StockPriceService) LoadStockPricesData(ctx context.Context, from, to time.Time) {
+	s.loadStockPrices(ctx, nil, from, to)
+}
+
+// StockRatingSaved implements entity.StockRatingListener, remembering the
+// tickers of the load in progress.
+func (s *StockPriceService) StockRatingSaved(ctx context.Context, rating entity.StockRating) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.ingestedTickers == nil {
+		s.ingestedTickers = make(map[string]struct{})
+	}
+	s.ingestedTickers[rating.Ticker] = struct{}{}
+}
+
+// IngestionCompleted implements entity.IngestionListener, loading the prices
+// of the tickers saved by the load in the background.
+func (s *StockPriceService) IngestionCompleted(ctx context.Context, summary entity.IngestionSummary) {
+	s.mu.Lock()
+	ingestedTickers := s.ingestedTickers
+	s.ingestedTickers = nil
+	s.mu.Unlock()
+
+	if len(ingestedTickers) == 0 {
+		return
+	}
+
+	tickers := make([]string, 0, len(ingestedTickers))
+	for ticker := range ingestedTickers {
+		tickers = append(tickers, ticker)
+	}
+
+	to := summary.FinishedAt.UTC().Truncate(24 * time.Hour)
+	go s.loadStockPrices(context.WithoutCancel(ctx), tickers, to.AddDate(0, 0, -ingestedPriceDays), to)
+}
+
+// loadStockPrices fetches and saves the daily candles of tickers, or of every
+// ticker with stored ratings when tickers is nil.
+func (s *StockPriceService) loadStockPrices(ctx context.Context, tickers []string, from, to time.Time) {
+	if !s.isLoading.CompareAndSwap(false, true) {
+		slog.InfoContext(ctx, "load stock prices process already running")
+		return
+	}
+
+	defer s.isLoading.Store(false)
+
+	slog.InfoContext(ctx, "process to load stock prices started", "from", from, "to", to)
+	start := time.Now()
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Minute)
+	defer cancel()
+
+	if tickers == nil {
+		var err error
+		tickers, err = s.stockRatingRepository.GetTickers(timeoutCtx)
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to get tickers to load stock prices", "error", err)
+			return
+		}
+	}
+
+	tickersChannel := make(chan string, len(tickers))
+	for _, ticker := range tickers {
+		tickersChannel <- ticker
+	}
+	close(tickersChannel)
+
+	var wg sync.WaitGroup
+	for range priceWorkers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for ticker := range tickersChannel {
+				select {
+				case <-timeoutCtx.Done():
+					return
+				default:
+					s.loadTickerPrices(timeoutCtx, ticker, from, to)
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	elapsed := time.Since(start)
+	minutes := int(elapsed.Minutes())
+	seconds := int(elapsed.Seconds()) % 60
+	milliseconds := int(elapsed.Milliseconds()) % 1000
+	duration := fmt.Sprintf("%dm %ds %dms", minutes, seconds, milliseconds)
+	slog.InfoContext(ctx, "process to load stock prices finished", "duration", duration, "tickers", len(tickers))
+}
+
+func (s *StockPriceService) loadTickerPrices(ctx context.Context, ticker string, from, to time.Time) {
+	candles, err := s.marketDataProvider.GetDailyCandles(ctx, ticker, from, to)
+	if err != nil {
+		slog.ErrorContext(ctx, "error getting stock candles", "error", err, "ticker", ticker)
+		return
+	}
+
+	if len(candles) == 0 {
+		return
+	}
+
+	if err := s.stockPriceRepository.SaveCandles(ctx, candles); err != nil {
+		slog.ErrorContext(ctx, "error saving stock candles", "error", err, "ticker", ticker)
+	}
+}
+
+// aggregateCandles merges ascending daily candles into weekly or monthly ones.
+func aggregateCandles(candles []entity.Candle, resolution string) []entity.Candle {
+	if resolution != entity.ResolutionWeek && resolution != entity.ResolutionMonth {
+		return candles
+	}
+
+	aggregated := make([]entity.Candle, 0, len(candles))
+	for _, candle := range candles {
+		period := periodStart(candle.Time, resolution)
+
+		if n := len(aggregated); n > 0 && aggregated[n-1].Time.Equal(period) {
+			last := &aggregated[n-1]
+			last.High = max(last.High, candle.High)
+			last.Low = min(last.Low, candle.Low)
+			last.Close = candle.Close
+			last.Volume += candle.Volume
+			continue
+		}
+
+		candle.Time = period
+		aggregated = append(aggregated, candle)
+	}
+
+	return aggregated
+}
+
+func periodStart(t time.Time, resolution string) time.Time {
+	year, month, day := t.Date()
+
+	if resolution == entity.ResolutionMonth {
+		return time.Date(year, month, 1, 0, 0, 0, 0, t.Location())
+	}
+
+	daysSinceMonday := (int(t.Weekday()) + 6) % 7
+	return time.Date(year, month, day-daysSinceMonday, 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rubenpad/srs/internal/domain/entity"
+	"github.com/rubenpad/srs/internal/infrastructure/marketdata/fixture"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAggregateCandles(t *testing.T) {
+	day := func(value string) time.Time {
+		parsed, _ := time.Parse("2006-01-02", value)
+		return parsed
+	}
+
+	// 2025-03-31 is a Monday, 2025-04-07 the following one.
+	daily := []entity.Candle{
+		{Ticker: "TEST", Time: day("2025-03-31"), Open: 10, High: 12, Low: 9, Close: 11, Volume: 100},
+		{Ticker: "TEST", Time: day("2025-04-01"), Open: 11, High: 15, Low: 10, Close: 14, Volume: 200},
+		{Ticker: "TEST", Time: day("2025-04-04"), Open: 14, High: 14, Low: 8, Close: 9, Volume: 50},
+		{Ticker: "TEST", Time: day("2025-04-07"), Open: 9, High: 10, Low: 7, Close: 8, Volume: 10},
+	}
+
+	assert.Equal(t, daily, aggregateCandles(daily, entity.ResolutionDay))
+
+	weekly := aggregateCandles(daily, entity.ResolutionWeek)
+	assert.Equal(t, []entity.Candle{
+		{Ticker: "TEST", Time: day("2025-03-31"), Open: 10, High: 15, Low: 8, Close: 9, Volume: 350},
+		{Ticker: "TEST", Time: day("2025-04-07"), Open: 9, High: 10, Low: 7, Close: 8, Volume: 10},
+	}, weekly)
+
+	monthly := aggregateCandles(daily, entity.ResolutionMonth)
+	assert.Equal(t, []entity.Candle{
+		{Ticker: "TEST", Time: day("2025-03-01"), Open: 10, High: 12, Low: 9, Close: 11, Volume: 100},
+		{Ticker: "TEST", Time: day("2025-04-01"), Open: 11, High: 15, Low: 7, Close: 8, Volume: 260},
+	}, monthly)
+}
+
+type recordingStockPriceRepository struct {
+	mu    sync.Mutex
+	saved map[string][]entity.Candle
+}
+
+func (r *recordingStockPriceRepository) SaveCandles(ctx context.Context, candles []entity.Candle) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, candle := range candles {
+		r.saved[candle.Ticker] = append(r.saved[candle.Ticker], candle)
+	}
+	return nil
+}
+
+func (r *recordingStockPriceRepository) GetCandles(ctx context.Context, ticker string, from, to time.Time) ([]entity.Candle, error) {
+	return nil, nil
+}
+
+func (r *recordingStockPriceRepository) tickers() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tickers := make([]string, 0, len(r.saved))
+	for ticker := range r.saved {
+		tickers = append(tickers, ticker)
+	}
+	return tickers
+}
+
+func TestStockPriceServiceLoadsIngestedTickers(t *testing.T) {
+	finishedAt := time.Date(2025, 4, 10, 15, 0, 0, 0, time.UTC)
+	candle := entity.Candle{Time: time.Date(2025, 4, 9, 0, 0, 0, 0, time.UTC), Open: 10, High: 12, Low: 9, Close: 11, Volume: 100}
+	provider := fixture.NewMarketDataProviderFromData(map[string]fixture.TickerData{
+		"AAPL": {Candles: []entity.Candle{candle}},
+		"MSFT": {Candles: []entity.Candle{candle}},
+		"TSLA": {Candles: []entity.Candle{candle}},
+	})
+
+	priceRepository := &recordingStockPriceRepository{saved: map[string][]entity.Candle{}}
+	service := NewStockPriceService(priceRepository, nil, provider)
+
+	ctx := context.Background()
+	service.StockRatingSaved(ctx, entity.StockRating{Ticker: "AAPL"})
+	service.StockRatingSaved(ctx, entity.StockRating{Ticker: "MSFT"})
+	service.StockRatingSaved(ctx, entity.StockRating{Ticker: "AAPL"})
+	service.IngestionCompleted(ctx, entity.IngestionSummary{FinishedAt: finishedAt})
+
+	assert.Eventually(t, func() bool { return len(priceRepository.tickers()) == 2 }, time.Second, 10*time.Millisecond)
+	assert.ElementsMatch(t, []string{"AAPL", "MSFT"}, priceRepository.tickers())
+
+	// The tickers are forgotten once their prices are requested.
+	service.IngestionCompleted(ctx, entity.IngestionSummary{FinishedAt: finishedAt})
+	assert.Never(t, func() bool { return len(priceRepository.tickers()) > 2 }, 100*time.Millisecond, 10*time.Millisecond)
+}
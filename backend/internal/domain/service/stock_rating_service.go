@@ -1,16 +1,22 @@
 package service
 
 import (
+	"cmp"
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
-	"strconv"
-	"strings"
+	"math"
+	"slices"
 	"sync"
 	"sync/atomic"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/rubenpad/srs/internal/domain/entity"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
@@ -28,6 +34,9 @@ const (
 	workers           = 4
 	itemsBatchSize    = 10
 	channelBufferSize = itemsBatchSize * (workers / 2)
+
+	quoteWorkers = 4
+	quoteTimeout = 2 * time.Second
 )
 
 var actionsScaleMap = map[string]int{
@@ -80,22 +89,42 @@ type StockRatingService struct {
 	isLoading             atomic.Bool
 	stockRatingApi        entity.IStockRatingApi
 	stockRatingRepository entity.IStockRatingRepository
+	companyRepository     entity.ICompanyRepository
+	marketDataProvider    entity.MarketDataProvider
+	listeners             []entity.StockRatingListener
+	metrics               ingestionMetrics
 }
 
-func NewStockRatingService(stockRatingRepository entity.IStockRatingRepository, stockRatingApi entity.IStockRatingApi) *StockRatingService {
+// NewStockRatingService creates the service. marketDataProvider, when not
+// nil, supplies the current price of the recommendations whose ticker has no
+// stored prices yet.
+func NewStockRatingService(stockRatingRepository entity.IStockRatingRepository, stockRatingApi entity.IStockRatingApi, companyRepository entity.ICompanyRepository, marketDataProvider entity.MarketDataProvider) *StockRatingService {
 	return &StockRatingService{
 		stockRatingApi:        stockRatingApi,
 		stockRatingRepository: stockRatingRepository,
+		companyRepository:     companyRepository,
+		marketDataProvider:    marketDataProvider,
+		metrics:               newIngestionMetrics(),
 	}
 }
 
-func (s *StockRatingService) GetStockDetails(ctx context.Context, ticker string) *entity.StockDetails {
+// AddListener registers a listener notified of every rating saved by
+// LoadStockRatingsData. Listeners implementing entity.IngestionListener or
+// entity.IngestionProgressListener are also told about the load itself.
+// Listeners must be added before loading starts.
+func (s *StockRatingService) AddListener(listener entity.StockRatingListener) {
+	s.listeners = append(s.listeners, listener)
+}
+
+func (s *StockRatingService) GetStockDetails(ctx context.Context, ticker string) (*entity.StockDetails, error) {
 	return s.stockRatingApi.GetStockDetails(ctx, ticker)
 }
 
-func (s *StockRatingService) GetStockRatings(ctx context.Context, nextPage string, pageSize int, search string) (*serviceResponse[entity.StockRating], error) {
+// GetStockRatings returns the page of ratings after the cursor. The NextPage
+// of the response is the encoded cursor of its last rating.
+func (s *StockRatingService) GetStockRatings(ctx context.Context, after entity.StockRatingCursor, pageSize int, search string) (*serviceResponse[entity.StockRating], error) {
 	pageSizePlusOne := pageSize + 1
-	stockRatings, err := s.stockRatingRepository.GetStockRatings(ctx, nextPage, pageSizePlusOne, search)
+	stockRatings, err := s.stockRatingRepository.GetStockRatings(ctx, after, pageSizePlusOne, search)
 
 	if err != nil {
 		return nil, err
@@ -107,7 +136,7 @@ func (s *StockRatingService) GetStockRatings(ctx context.Context, nextPage strin
 
 	if responseSize > 0 && existsMoreItems {
 		lastItemCurrentPage := stockRatings[responseSize-2]
-		nNextPage = lastItemCurrentPage.Ticker
+		nNextPage = entity.NewStockRatingCursor(lastItemCurrentPage).String()
 		stockRatings = stockRatings[:responseSize-1]
 	}
 
@@ -117,33 +146,173 @@ func (s *StockRatingService) GetStockRatings(ctx context.Context, nextPage strin
 	}, nil
 }
 
-func (s *StockRatingService) GetStockRecommendations(ctx context.Context, pageSize int) (*serviceResponse[entity.StockRatingAggregate], error) {
-	recommendations, err := s.stockRatingRepository.GetStockRecommendations(ctx, pageSize)
+// GetStockRecommendations returns the best rated tickers, only those of the
+// given sector when sector is not empty.
+//
+// The repository computes the implied upside from the stored prices, loaded
+// after every stock ratings load. Recommendations without one get it from the
+// current quote instead, and a page sorted by upside is sorted again; tickers
+// that only gain an upside this way can still be missing from the page.
+func (s *StockRatingService) GetStockRecommendations(ctx context.Context, pageSize int, sortBy string, sector string) (*serviceResponse[entity.StockRatingAggregate], error) {
+	recommendations, err := s.stockRatingRepository.GetStockRecommendations(ctx, pageSize, sortBy, sector)
 
 	if err != nil {
 		return nil, err
 	}
 
+	if fillImpliedUpside(ctx, s.marketDataProvider, recommendations) && sortBy == entity.RecommendationSortUpside {
+		sortByUpside(recommendations)
+	}
+
 	return &serviceResponse[entity.StockRatingAggregate]{
 		Data: recommendations,
 	}, nil
 }
 
+// fillImpliedUpside sets the current price and implied upside of the
+// recommendations with a consensus target but no stored price from their
+// quotes. It reports whether any of them changed.
+func fillImpliedUpside(ctx context.Context, marketDataProvider entity.MarketDataProvider, recommendations []entity.StockRatingAggregate) bool {
+	if marketDataProvider == nil {
+		return false
+	}
+
+	missing := make(chan int, len(recommendations))
+	for i, recommendation := range recommendations {
+		if recommendation.CurrentPrice == nil && recommendation.ConsensusTargetMedian != nil {
+			missing <- i
+		}
+	}
+	close(missing)
+
+	if len(missing) == 0 {
+		return false
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, quoteTimeout)
+	defer cancel()
+
+	var filled atomic.Bool
+	var wg sync.WaitGroup
+	for range quoteWorkers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range missing {
+				recommendation := &recommendations[i]
+
+				quote, err := marketDataProvider.GetQuote(timeoutCtx, recommendation.Ticker)
+				if err != nil {
+					if !errors.Is(err, entity.ErrStockNotFound) {
+						slog.WarnContext(ctx, "error getting the quote of a recommendation", "error", err, "ticker", recommendation.Ticker)
+					}
+					continue
+				}
+
+				upside := impliedUpside(*recommendation.ConsensusTargetMedian, quote.Current)
+				if upside == nil {
+					continue
+				}
+
+				recommendation.CurrentPrice = &quote.Current
+				recommendation.ImpliedUpside = upside
+				filled.Store(true)
+			}
+		}()
+	}
+
+	wg.Wait()
+	return filled.Load()
+}
+
+// impliedUpside returns how much target is above price, as a percentage with
+// 2 decimals, or nil when there is no price.
+func impliedUpside(target, price float64) *float64 {
+	if price <= 0 {
+		return nil
+	}
+
+	upside := math.Round((target/price-1)*100*100) / 100
+	return &upside
+}
+
+// sortByUpside orders the recommendations by descending implied upside, those
+// without one last, keeping the order of the repository between ties.
+func sortByUpside(recommendations []entity.StockRatingAggregate) {
+	slices.SortStableFunc(recommendations, func(a, b entity.StockRatingAggregate) int {
+		switch {
+		case a.ImpliedUpside == nil && b.ImpliedUpside == nil:
+			return 0
+		case a.ImpliedUpside == nil:
+			return 1
+		case b.ImpliedUpside == nil:
+			return -1
+		}
+
+		return cmp.Compare(*b.ImpliedUpside, *a.ImpliedUpside)
+	})
+}
+
+// GetLatestStockRatings returns the limit most recent ratings of each ticker,
+// keyed by ticker.
+func (s *StockRatingService) GetLatestStockRatings(ctx context.Context, tickers []string, limit int) (map[string][]entity.StockRating, error) {
+	stockRatings, err := s.stockRatingRepository.GetLatestStockRatings(ctx, tickers, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	byTicker := make(map[string][]entity.StockRating, len(tickers))
+	for _, rating := range stockRatings {
+		byTicker[rating.Ticker] = append(byTicker[rating.Ticker], rating)
+	}
+
+	return byTicker, nil
+}
+
 func (s *StockRatingService) LoadStockRatingsData(ctx context.Context, useCustomFormat bool) {
 	if !s.isLoading.CompareAndSwap(false, true) {
-		slog.Info("load stock ratings process already running")
+		slog.InfoContext(ctx, "load stock ratings process already running")
 		return
 	}
 
 	defer s.isLoading.Store(false)
 
-	slog.Info("process to load stock ratings started", "useCustomFormat", useCustomFormat)
+	// Every run is its own trace, linked to the request that triggered it, and
+	// its logs carry the job ID.
+	jobID := uuid.NewString()
+	ctx = entity.WithJobID(ctx, jobID)
+	ctx, span := tracer.Start(ctx, "StockRatingService.LoadStockRatingsData",
+		trace.WithNewRoot(),
+		trace.WithLinks(trace.LinkFromContext(ctx)),
+		trace.WithAttributes(
+			attribute.String("ingestion.job_id", jobID),
+			attribute.Bool("ingestion.custom_format", useCustomFormat),
+		))
+	defer span.End()
+
+	slog.InfoContext(ctx, "process to load stock ratings started", "useCustomFormat", useCustomFormat)
 	start := time.Now()
 
 	timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Minute)
 	defer cancel()
 
+	// The storage backends without the database keep no companies.
+	var companies []entity.Company
+	if s.companyRepository != nil {
+		var err error
+		if companies, err = s.companyRepository.GetCompanies(ctx); err != nil {
+			slog.WarnContext(ctx, "company reference data unavailable - ratings will not be normalised", "error", err)
+		}
+	}
+
+	index := newCompanyIndex(companies)
+
+	var saved atomic.Int64
+	progress := entity.IngestionProgress{JobID: jobID, Status: entity.IngestionStatusStarted, StartedAt: start}
+	s.notifyProgress(ctx, progress)
+
 	ratingsChannel := make(chan entity.StockRating, channelBufferSize)
+	defer s.metrics.observeQueueDepth(ratingsChannel)()
 
 	var wg sync.WaitGroup
 	for range workers {
@@ -151,11 +320,13 @@ func (s *StockRatingService) LoadStockRatingsData(ctx context.Context, useCustom
 		go func() {
 			defer wg.Done()
 			for rating := range ratingsChannel {
+				batch := receiveBatch(rating, ratingsChannel)
+
 				select {
 				case <-timeoutCtx.Done():
 					return
 				default:
-					s.stockRatingRepository.Save(ctx, s.formatStockRating(rating))
+					saved.Add(s.saveBatch(ctx, index, batch))
 				}
 			}
 		}()
@@ -166,7 +337,9 @@ func (s *StockRatingService) LoadStockRatingsData(ctx context.Context, useCustom
 		stockRatings, nNextPage, err := s.stockRatingApi.GetStockRatings(ctx, nextPage, useCustomFormat)
 		if err != nil {
 			errorMessage := "failed to get stock ratings from API"
-			slog.Error(errorMessage, "error", err)
+			slog.ErrorContext(ctx, errorMessage, "error", err)
+			span.RecordError(err)
+			span.SetStatus(codes.Error, errorMessage)
 			break
 		}
 
@@ -178,6 +351,13 @@ func (s *StockRatingService) LoadStockRatingsData(ctx context.Context, useCustom
 			}
 		}
 
+		s.metrics.pages.Add(ctx, 1)
+		progress.Status = entity.IngestionStatusRunning
+		progress.Pages++
+		progress.Fetched += int64(len(stockRatings))
+		progress.Saved = saved.Load()
+		s.notifyProgress(ctx, progress)
+
 		nextPage = nNextPage
 		if nNextPage == "" {
 			break
@@ -196,7 +376,7 @@ Cleanup:
 	select {
 	case <-done:
 	case <-time.After(1 * time.Minute):
-		slog.Warn("worker timeout exceeded during cleanup")
+		slog.WarnContext(ctx, "worker timeout exceeded during cleanup")
 	}
 
 	elapsed := time.Since(start)
@@ -204,7 +384,86 @@ Cleanup:
 	seconds := int(elapsed.Seconds()) % 60
 	milliseconds := int(elapsed.Milliseconds()) % 1000
 	duration := fmt.Sprintf("%dm %ds %dms", minutes, seconds, milliseconds)
-	slog.Info("process to load stock ratings finished", "duration", duration)
+	slog.InfoContext(ctx, "process to load stock ratings finished", "duration", duration, "saved", saved.Load())
+
+	progress.Status = entity.IngestionStatusCompleted
+	progress.Saved = saved.Load()
+	s.notifyProgress(ctx, progress)
+	span.SetAttributes(
+		attribute.Int("ingestion.pages", progress.Pages),
+		attribute.Int64("ingestion.fetched", progress.Fetched),
+		attribute.Int64("ingestion.saved", progress.Saved),
+	)
+
+	summary := entity.IngestionSummary{StartedAt: start, FinishedAt: time.Now(), Saved: saved.Load()}
+	for _, listener := range s.listeners {
+		if ingestionListener, ok := listener.(entity.IngestionListener); ok {
+			ingestionListener.IngestionCompleted(ctx, summary)
+		}
+	}
+}
+
+func (s *StockRatingService) notifyProgress(ctx context.Context, progress entity.IngestionProgress) {
+	for _, listener := range s.listeners {
+		if progressListener, ok := listener.(entity.IngestionProgressListener); ok {
+			progressListener.IngestionProgressed(ctx, progress)
+		}
+	}
+}
+
+// receiveBatch returns first along with the ratings already waiting in
+// ratings, up to itemsBatchSize, without blocking for more.
+func receiveBatch(first entity.StockRating, ratings <-chan entity.StockRating) []entity.StockRating {
+	batch := []entity.StockRating{first}
+	for len(batch) < itemsBatchSize {
+		select {
+		case rating, open := <-ratings:
+			if !open {
+				return batch
+			}
+
+			batch = append(batch, rating)
+		default:
+			return batch
+		}
+	}
+
+	return batch
+}
+
+// saveBatch saves the batch of a worker and returns how many ratings were
+// saved.
+func (s *StockRatingService) saveBatch(ctx context.Context, index *companyIndex, batch []entity.StockRating) int64 {
+	ctx, span := tracer.Start(ctx, "StockRatingService.saveBatch", trace.WithAttributes(attribute.Int("ingestion.batch.size", len(batch))))
+	defer span.End()
+
+	var saved int64
+	for _, rating := range batch {
+		if s.save(ctx, s.formatStockRating(index.normalize(rating))) {
+			saved++
+		}
+	}
+
+	span.SetAttributes(attribute.Int64("ingestion.batch.saved", saved))
+	return saved
+}
+
+func (s *StockRatingService) save(ctx context.Context, rating entity.StockRating) bool {
+	if err := s.stockRatingRepository.Save(ctx, rating); err != nil {
+		if errors.Is(err, entity.ErrDuplicateStockRating) {
+			s.metrics.duplicates.Add(ctx, 1)
+		}
+
+		return false
+	}
+
+	s.metrics.saved.Add(ctx, 1)
+
+	for _, listener := range s.listeners {
+		listener.StockRatingSaved(ctx, rating)
+	}
+
+	return true
 }
 
 func (s *StockRatingService) formatStockRating(rating entity.StockRating) entity.StockRating {
@@ -212,6 +471,7 @@ func (s *StockRatingService) formatStockRating(rating entity.StockRating) entity
 	currentRatingScore := ratingScaleMap[rating.RatingTo]
 	ratingChangeScore := calculateRatingChangeScore(rating)
 	brokerageActionScore := calculateBrokerageActionScore(rating)
+	targetFromValue, targetToValue, currency := parseTargets(rating)
 	targetPriceChange := calculateTargetPriceChange(rating)
 	targetPriceChangeScore := calculateTargetPriceChangeScore(targetPriceChange)
 	score := calculateScore(ratingChangeScore, currentRatingScore, brokerageActionScore, reportDateScore, targetPriceChangeScore)
@@ -225,6 +485,9 @@ func (s *StockRatingService) formatStockRating(rating entity.StockRating) entity
 		RatingTo:          rating.RatingTo,
 		TargetFrom:        rating.TargetFrom,
 		TargetTo:          rating.TargetTo,
+		TargetFromValue:   targetFromValue,
+		TargetToValue:     targetToValue,
+		Currency:          currency,
 		Time:              rating.Time.Truncate(24 * time.Hour),
 		TargetPriceChange: targetPriceChange,
 		Score:             score,
@@ -242,11 +505,47 @@ func calculateScore(ratingChangeScore, currentRatingScore, brokerageActionScore,
 	return float32(score) / 100
 }
 
+// parseTargets returns the numeric targets and their currency, leaving the
+// values nil when they cannot be parsed. Targets in different currencies
+// cannot be compared, so neither is kept.
+func parseTargets(rating entity.StockRating) (*float64, *float64, *string) {
+	var targetFromValue, targetToValue *float64
+	var fromCurrency, toCurrency string
+
+	if value, currency, err := entity.ParsePrice(rating.TargetFrom); err == nil {
+		targetFromValue = &value
+		fromCurrency = currency
+	}
+
+	if value, currency, err := entity.ParsePrice(rating.TargetTo); err == nil {
+		targetToValue = &value
+		toCurrency = currency
+	}
+
+	switch {
+	case targetFromValue != nil && targetToValue != nil && fromCurrency != toCurrency:
+		slog.Warn(
+			"target currencies do not match",
+			"targetFrom", rating.TargetFrom,
+			"targetTo", rating.TargetTo,
+			"ticker", rating.Ticker,
+			"brokerage", rating.Brokerage,
+			"time", rating.Time)
+		return nil, nil, nil
+	case targetToValue != nil:
+		return targetFromValue, targetToValue, &toCurrency
+	case targetFromValue != nil:
+		return targetFromValue, nil, &fromCurrency
+	default:
+		return nil, nil, nil
+	}
+}
+
 func calculateTargetPriceChange(rating entity.StockRating) float64 {
-	targetFrom, fromErr := strconv.ParseFloat(strings.TrimPrefix(strings.ReplaceAll(rating.TargetFrom, ",", ""), "$"), 64)
-	targetTo, toErr := strconv.ParseFloat(strings.TrimPrefix(strings.ReplaceAll(rating.TargetTo, ",", ""), "$"), 64)
+	targetFrom, fromCurrency, fromErr := entity.ParsePrice(rating.TargetFrom)
+	targetTo, toCurrency, toErr := entity.ParsePrice(rating.TargetTo)
 
-	if fromErr != nil || toErr != nil || targetFrom == 0 {
+	if fromErr != nil || toErr != nil || targetFrom == 0 || fromCurrency != toCurrency {
 		slog.Warn(
 			"target price change calculation error",
 			"fromError",
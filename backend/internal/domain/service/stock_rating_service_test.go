@@ -2,53 +2,51 @@ package service
 
 import (
 	"context"
-	"sync"
 	"testing"
 	"time"
 
 	"github.com/rubenpad/srs/internal/domain/entity"
+	"github.com/rubenpad/srs/internal/infrastructure/marketdata/fixture"
+	"github.com/rubenpad/srs/internal/infrastructure/storage/memory"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 type MockStockRatingApi struct {
 	mock.Mock
 }
 
-type MockStockRatingRepository struct {
+type MockCompanyRepository struct {
 	mock.Mock
 }
 
-func (m *MockStockRatingRepository) Save(ctx context.Context, stock entity.StockRating) {
-	m.Called(ctx, stock)
+func (m *MockCompanyRepository) Save(ctx context.Context, company entity.Company) error {
+	return m.Called(ctx, company).Error(0)
 }
 
-func (m *MockStockRatingRepository) BatchSave(ctx context.Context, stockRatings []entity.StockRating) {
-	m.Called(ctx, stockRatings)
-}
-
-func (m *MockStockRatingRepository) GetStockRatings(ctx context.Context, nextPage string, pageSize int, search string) ([]entity.StockRating, error) {
-	args := m.Called(ctx, nextPage, pageSize, search)
+func (m *MockCompanyRepository) GetCompany(ctx context.Context, ticker string) (*entity.Company, error) {
+	args := m.Called(ctx, ticker)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).([]entity.StockRating), args.Error(1)
+	return args.Get(0).(*entity.Company), args.Error(1)
 }
 
-func (m *MockStockRatingRepository) GetStockRecommendations(ctx context.Context, pageSize int) ([]entity.StockRatingAggregate, error) {
-	args := m.Called(ctx, pageSize)
+func (m *MockCompanyRepository) GetCompanies(ctx context.Context) ([]entity.Company, error) {
+	args := m.Called(ctx)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).([]entity.StockRatingAggregate), args.Error(1)
+	return args.Get(0).([]entity.Company), args.Error(1)
 }
 
-func (m *MockStockRatingApi) GetStockDetails(ctx context.Context, ticker string) *entity.StockDetails {
+func (m *MockStockRatingApi) GetStockDetails(ctx context.Context, ticker string) (*entity.StockDetails, error) {
 	args := m.Called(ctx, ticker)
 	if args.Get(0) == nil {
-		return nil
+		return nil, args.Error(1)
 	}
-	return args.Get(0).(*entity.StockDetails)
+	return args.Get(0).(*entity.StockDetails), args.Error(1)
 }
 
 func (m *MockStockRatingApi) GetStockRatings(ctx context.Context, nextPage string, useCustomFormat bool) ([]entity.StockRating, string, error) {
@@ -59,7 +57,8 @@ func (m *MockStockRatingApi) GetStockRatings(ctx context.Context, nextPage strin
 func TestLoadStockRatingsData(t *testing.T) {
 	ctx := context.Background()
 	mockApi := new(MockStockRatingApi)
-	mockRepository := new(MockStockRatingRepository)
+	repository := memory.NewStockRatingRepository()
+	mockCompanyRepository := new(MockCompanyRepository)
 
 	testTime := time.Now()
 
@@ -91,28 +90,22 @@ func TestLoadStockRatingsData(t *testing.T) {
 		},
 	}
 
-	var mu sync.Mutex
-	var processedRatings []entity.StockRating
-
-	mockApi.On("GetStockRatings", ctx, "", false).
+	mockApi.On("GetStockRatings", mock.Anything, "", false).
 		Return(testBatch1, "next_page", nil).Once()
-	mockApi.On("GetStockRatings", ctx, "next_page", false).
+	mockApi.On("GetStockRatings", mock.Anything, "next_page", false).
 		Return(testBatch2, "", nil).Once()
 
-	// Capture processed ratings in thread-safe way
-	mockRepository.On("Save", ctx, mock.AnythingOfType("entity.StockRating")).
-		Run(func(args mock.Arguments) {
-			mu.Lock()
-			processedRatings = append(processedRatings, args.Get(1).(entity.StockRating))
-			mu.Unlock()
-		}).Return(nil)
+	mockCompanyRepository.On("GetCompanies", mock.Anything).
+		Return([]entity.Company{{Ticker: "TEST1", Name: "Test Company One Inc."}}, nil).Once()
 
-	service := NewStockRatingService(mockRepository, mockApi)
+	service := NewStockRatingService(repository, mockApi, mockCompanyRepository, nil)
 
 	service.LoadStockRatingsData(ctx, false)
 
 	mockApi.AssertExpectations(t)
-	mockRepository.AssertExpectations(t)
+
+	processedRatings, err := repository.GetStockRatings(ctx, entity.StockRatingCursor{}, 10, "")
+	require.NoError(t, err)
 
 	assert.Equal(t, len(testBatch1)+len(testBatch2), len(processedRatings))
 
@@ -125,6 +118,7 @@ func TestLoadStockRatingsData(t *testing.T) {
 	}
 
 	assert.NotEmpty(t, upgradedRating)
+	assert.Equal(t, "Test Company One Inc.", upgradedRating.Company)
 	assert.Equal(t, 5, calculateDateScore(upgradedRating.Time))
 	assert.Equal(t, 5, calculateTargetPriceChangeScore(calculateTargetPriceChange(upgradedRating)))
 	assert.Equal(t, 5, ratingScaleMap[upgradedRating.RatingTo])
@@ -140,9 +134,169 @@ func TestLoadStockRatingsData(t *testing.T) {
 	}
 
 	assert.NotEmpty(t, downgradedRating)
+	assert.Equal(t, "TestCompany2", downgradedRating.Company)
 	assert.Equal(t, 5, calculateDateScore(downgradedRating.Time))
 	assert.Equal(t, 0, calculateTargetPriceChangeScore(calculateTargetPriceChange(downgradedRating)))
 	assert.Equal(t, 1, ratingScaleMap[downgradedRating.RatingTo])
 	assert.Equal(t, 1, calculateRatingChangeScore(downgradedRating))
 	assert.Equal(t, 1, calculateBrokerageActionScore(downgradedRating))
 }
+
+func TestGetStockRatings(t *testing.T) {
+	ctx := context.Background()
+	testTime := time.Date(2025, 3, 14, 0, 0, 0, 0, time.UTC)
+	ratings := []entity.StockRating{
+		{Ticker: "AAPL", Brokerage: "Citigroup", Time: testTime},
+		{Ticker: "AAPL", Brokerage: "Citigroup", Time: testTime.AddDate(0, 0, -1)},
+		{Ticker: "AAPL", Brokerage: "JPMorgan Chase & Co.", Time: testTime},
+	}
+	after := entity.StockRatingCursor{Ticker: "AAL"}
+
+	tests := []struct {
+		name     string
+		saved    []entity.StockRating
+		data     []entity.StockRating
+		nextPage string
+	}{
+		{"more pages", ratings, ratings[:2], entity.NewStockRatingCursor(ratings[1]).String()},
+		{"last page", ratings[:2], ratings[:2], ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repository := memory.NewStockRatingRepository()
+			for _, rating := range tt.saved {
+				require.NoError(t, repository.Save(ctx, rating))
+			}
+
+			service := NewStockRatingService(repository, new(MockStockRatingApi), new(MockCompanyRepository), nil)
+			page, err := service.GetStockRatings(ctx, after, 2, "A")
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.data, page.Data)
+			assert.Equal(t, tt.nextPage, page.NextPage)
+		})
+	}
+}
+
+func TestParseTargets(t *testing.T) {
+	tests := []struct {
+		name       string
+		targetFrom string
+		targetTo   string
+		from       *float64
+		to         *float64
+		currency   *string
+	}{
+		{"same currency", "$180.00", "$200.00", pointer(180.0), pointer(200.0), pointer("USD")},
+		{"code after the amount", "150.00 GBP", "£160.00", pointer(150.0), pointer(160.0), pointer("GBP")},
+		{"only the new target", "", "€12.50", nil, pointer(12.5), pointer("EUR")},
+		{"only the prior target", "$180.00", "n/a", pointer(180.0), nil, pointer("USD")},
+		{"different currencies", "$180.00", "€200.00", nil, nil, nil},
+		{"no targets", "", "", nil, nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from, to, currency := parseTargets(entity.StockRating{Ticker: "AAPL", TargetFrom: tt.targetFrom, TargetTo: tt.targetTo})
+
+			assert.Equal(t, tt.from, from)
+			assert.Equal(t, tt.to, to)
+			assert.Equal(t, tt.currency, currency)
+		})
+	}
+}
+
+func TestImpliedUpside(t *testing.T) {
+	tests := []struct {
+		name   string
+		target float64
+		price  float64
+		upside *float64
+	}{
+		{"target above the price", 250, 200, pointer(25.0)},
+		{"target below the price", 150, 200, pointer(-25.0)},
+		{"rounded to 2 decimals", 100, 30, pointer(233.33)},
+		{"no price", 250, 0, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.upside, impliedUpside(tt.target, tt.price))
+		})
+	}
+}
+
+func TestSortByUpside(t *testing.T) {
+	tests := []struct {
+		name    string
+		upsides []*float64
+		tickers []string
+	}{
+		{"descending upside", []*float64{pointer(5.0), pointer(20.0), pointer(-3.0)}, []string{"B", "A", "C"}},
+		{"no upside last", []*float64{nil, pointer(-3.0), pointer(10.0)}, []string{"C", "B", "A"}},
+		{"ties keep their order", []*float64{nil, pointer(10.0), nil, pointer(10.0)}, []string{"B", "D", "A", "C"}},
+		{"no upsides", []*float64{nil, nil}, []string{"A", "B"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recommendations := make([]entity.StockRatingAggregate, len(tt.upsides))
+			for i, upside := range tt.upsides {
+				recommendations[i] = entity.StockRatingAggregate{Ticker: string(rune('A' + i)), ImpliedUpside: upside}
+			}
+
+			sortByUpside(recommendations)
+
+			tickers := make([]string, len(recommendations))
+			for i, recommendation := range recommendations {
+				tickers[i] = recommendation.Ticker
+			}
+			assert.Equal(t, tt.tickers, tickers)
+		})
+	}
+}
+
+func TestGetStockRecommendationsQuoteFallback(t *testing.T) {
+	ctx := context.Background()
+	testTime := time.Now()
+	rating := func(ticker, brokerage string, target float64) entity.StockRating {
+		return entity.StockRating{Ticker: ticker, Brokerage: brokerage, Time: testTime, RatingTo: "Buy", TargetToValue: &target, TargetPriceChange: 0.1, Score: 10}
+	}
+
+	repository := memory.NewStockRatingRepository()
+	for _, stockRating := range []entity.StockRating{
+		rating("AAPL", "Citigroup", 200),
+		rating("AAPL", "JPMorgan Chase & Co.", 240),
+		rating("MSFT", "Citigroup", 600),
+		rating("TSLA", "Citigroup", 300),
+	} {
+		require.NoError(t, repository.Save(ctx, stockRating))
+	}
+
+	provider := fixture.NewMarketDataProviderFromData(map[string]fixture.TickerData{
+		"AAPL": {Quote: &entity.Quote{Current: 200}},
+		"MSFT": {Quote: &entity.Quote{Current: 400}},
+	})
+
+	service := NewStockRatingService(repository, new(MockStockRatingApi), nil, provider)
+	page, err := service.GetStockRecommendations(ctx, 10, entity.RecommendationSortUpside, "")
+	require.NoError(t, err)
+	require.Len(t, page.Data, 3)
+
+	assert.Equal(t, "MSFT", page.Data[0].Ticker)
+	assert.Equal(t, pointer(400.0), page.Data[0].CurrentPrice)
+	assert.Equal(t, pointer(50.0), page.Data[0].ImpliedUpside)
+
+	assert.Equal(t, "AAPL", page.Data[1].Ticker)
+	assert.Equal(t, pointer(220.0), page.Data[1].ConsensusTargetMedian)
+	assert.Equal(t, pointer(10.0), page.Data[1].ImpliedUpside)
+
+	assert.Equal(t, "TSLA", page.Data[2].Ticker, "without a quote the upside stays empty")
+	assert.Nil(t, page.Data[2].CurrentPrice)
+	assert.Nil(t, page.Data[2].ImpliedUpside)
+}
+
+func pointer[T any](value T) *T {
+	return &value
+}
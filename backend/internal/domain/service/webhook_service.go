@@ -0,0 +1,243 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/cenkalti/backoff/v5"
+	"github.com/rubenpad/srs/internal/domain/entity"
+)
+
+const (
+	webhookPollInterval    = 5 * time.Second
+	webhookClaimSize       = 20
+	webhookDeliveryTimeout = 15 * time.Second
+	// The claimed deliveries are sent one after another, so the lease outlasts
+	// all of them timing out; otherwise another replica could claim and send
+	// them again while they are still being sent.
+	webhookLease               = webhookClaimSize*webhookDeliveryTimeout + time.Minute
+	webhookMaxAttempts         = 8
+	webhookSecretSize          = 32
+	webhookRecommendationsSize = 10
+)
+
+type webhookPayload struct {
+	Event     string    `json:"event"`
+	CreatedAt time.Time `json:"created_at"`
+	Data      any       `json:"data"`
+}
+
+// WebhookService manages webhook subscriptions, queues events for them and
+// delivers the queued events in the background.
+type WebhookService struct {
+	webhookRepository     entity.IWebhookRepository
+	stockRatingRepository entity.IStockRatingRepository
+	sender                entity.IWebhookSender
+}
+
+func NewWebhookService(webhookRepository entity.IWebhookRepository, stockRatingRepository entity.IStockRatingRepository, sender entity.IWebhookSender) *WebhookService {
+	return &WebhookService{
+		webhookRepository:     webhookRepository,
+		stockRatingRepository: stockRatingRepository,
+		sender:                sender,
+	}
+}
+
+func (s *WebhookService) CreateWebhook(ctx context.Context, webhook entity.Webhook) (*entity.Webhook, error) {
+	parsed, err := url.Parse(webhook.Url)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return nil, fmt.Errorf("%w: url must be an absolute http(s) url", entity.ErrInvalidWebhook)
+	}
+
+	if len(webhook.Events) == 0 {
+		return nil, fmt.Errorf("%w: at least one event is required", entity.ErrInvalidWebhook)
+	}
+
+	events := make([]string, 0, len(webhook.Events))
+	for _, event := range webhook.Events {
+		if !slices.Contains(entity.WebhookEvents, event) {
+			return nil, fmt.Errorf("%w: unknown event %q", entity.ErrInvalidWebhook, event)
+		}
+
+		if !slices.Contains(events, event) {
+			events = append(events, event)
+		}
+	}
+
+	webhook.Events = events
+
+	if webhook.Secret == "" {
+		secret := make([]byte, webhookSecretSize)
+		if _, err := rand.Read(secret); err != nil {
+			return nil, err
+		}
+
+		webhook.Secret = hex.EncodeToString(secret)
+	}
+
+	return s.webhookRepository.SaveWebhook(ctx, webhook)
+}
+
+func (s *WebhookService) GetWebhooks(ctx context.Context) (*serviceResponse[entity.Webhook], error) {
+	webhooks, err := s.webhookRepository.GetWebhooks(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range webhooks {
+		webhooks[i].Secret = ""
+	}
+
+	return &serviceResponse[entity.Webhook]{Data: webhooks}, nil
+}
+
+func (s *WebhookService) DeleteWebhook(ctx context.Context, id int64) error {
+	return s.webhookRepository.DeleteWebhook(ctx, id)
+}
+
+func (s *WebhookService) GetDeliveries(ctx context.Context, webhookID int64, nextPage string, pageSize int) (*serviceResponse[entity.WebhookDelivery], error) {
+	pageSizePlusOne := pageSize + 1
+	deliveries, err := s.webhookRepository.GetDeliveries(ctx, webhookID, nextPage, pageSizePlusOne)
+	if err != nil {
+		return nil, err
+	}
+
+	nNextPage := ""
+	if len(deliveries) == pageSizePlusOne {
+		deliveries = deliveries[:pageSize]
+		nNextPage = fmt.Sprint(deliveries[pageSize-1].ID)
+	}
+
+	return &serviceResponse[entity.WebhookDelivery]{
+		Data:     deliveries,
+		NextPage: nNextPage,
+	}, nil
+}
+
+// Publish queues event for every webhook subscribed to it.
+func (s *WebhookService) Publish(ctx context.Context, event string, data any) error {
+	payload, err := json.Marshal(webhookPayload{Event: event, CreatedAt: time.Now().UTC(), Data: data})
+	if err != nil {
+		return err
+	}
+
+	_, err = s.webhookRepository.EnqueueDeliveries(ctx, event, payload)
+	return err
+}
+
+// StockRatingSaved implements entity.StockRatingListener.
+func (s *WebhookService) StockRatingSaved(ctx context.Context, rating entity.StockRating) {
+	if err := s.Publish(ctx, entity.EventRatingCreated, rating); err != nil {
+		slog.ErrorContext(ctx, "error publishing webhook event", "error", err, "event", entity.EventRatingCreated)
+	}
+}
+
+// IngestionCompleted implements entity.IngestionListener.
+func (s *WebhookService) IngestionCompleted(ctx context.Context, summary entity.IngestionSummary) {
+	if err := s.Publish(ctx, entity.EventIngestionCompleted, summary); err != nil {
+		slog.ErrorContext(ctx, "error publishing webhook event", "error", err, "event", entity.EventIngestionCompleted)
+	}
+
+	if summary.Saved == 0 {
+		return
+	}
+
+	recommendations, err := s.stockRatingRepository.GetStockRecommendations(ctx, webhookRecommendationsSize, entity.RecommendationSortDefault, "")
+	if err != nil {
+		return
+	}
+
+	if err := s.Publish(ctx, entity.EventRecommendationsUpdated, recommendations); err != nil {
+		slog.ErrorContext(ctx, "error publishing webhook event", "error", err, "event", entity.EventRecommendationsUpdated)
+	}
+}
+
+// Run delivers the queued events until ctx is done.
+func (s *WebhookService) Run(ctx context.Context) {
+	ticker := time.NewTicker(webhookPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for s.dispatchDue(ctx) == webhookClaimSize {
+			}
+		}
+	}
+}
+
+// dispatchDue delivers one batch of due deliveries and returns its size.
+func (s *WebhookService) dispatchDue(ctx context.Context) int {
+	dispatches, err := s.webhookRepository.ClaimDeliveries(ctx, webhookClaimSize, time.Now().UTC().Add(webhookLease))
+	if err != nil {
+		return 0
+	}
+
+	for _, dispatch := range dispatches {
+		s.deliver(ctx, dispatch)
+	}
+
+	return len(dispatches)
+}
+
+func (s *WebhookService) deliver(ctx context.Context, dispatch entity.WebhookDispatch) {
+	sendCtx, cancel := context.WithTimeout(ctx, webhookDeliveryTimeout)
+	defer cancel()
+
+	delivery := dispatch.Delivery
+	statusCode, err := s.sender.Send(sendCtx, dispatch.Webhook, delivery)
+	now := time.Now().UTC()
+
+	delivery.Attempts++
+	delivery.LastStatusCode = nil
+	if statusCode != 0 {
+		delivery.LastStatusCode = &statusCode
+	}
+
+	switch {
+	case err == nil:
+		delivery.Status = entity.DeliveryStatusDelivered
+		delivery.LastError = nil
+		delivery.DeliveredAt = &now
+	case delivery.Attempts >= webhookMaxAttempts:
+		message := err.Error()
+		delivery.Status = entity.DeliveryStatusFailed
+		delivery.LastError = &message
+		slog.WarnContext(ctx, "webhook delivery failed", "error", err, "webhookId", dispatch.Webhook.ID, "deliveryId", delivery.ID)
+	default:
+		message := err.Error()
+		delivery.LastError = &message
+		delivery.NextAttemptAt = now.Add(nextDeliveryDelay(delivery.Attempts))
+	}
+
+	// The delivery must be recorded even when ctx was cancelled mid-send.
+	if err := s.webhookRepository.UpdateDelivery(context.WithoutCancel(ctx), delivery); err != nil {
+		slog.ErrorContext(ctx, "error recording webhook delivery", "error", err, "deliveryId", delivery.ID)
+	}
+}
+
+// nextDeliveryDelay returns how long to wait before retrying a delivery that
+// failed attempts times: 30s, 1m, 2m, ... up to 1h with some jitter.
+func nextDeliveryDelay(attempts int) time.Duration {
+	exponentialBackOff := backoff.NewExponentialBackOff()
+	exponentialBackOff.InitialInterval = 30 * time.Second
+	exponentialBackOff.Multiplier = 2
+	exponentialBackOff.MaxInterval = 1 * time.Hour
+	exponentialBackOff.RandomizationFactor = 0.1
+
+	delay := exponentialBackOff.NextBackOff()
+	for range attempts - 1 {
+		delay = exponentialBackOff.NextBackOff()
+	}
+
+	return delay
+}
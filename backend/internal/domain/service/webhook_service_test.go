@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/rubenpad/srs/internal/domain/entity"
+	"github.com/rubenpad/srs/internal/infrastructure/storage/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockWebhookRepository struct {
+	mock.Mock
+}
+
+func (m *MockWebhookRepository) SaveWebhook(ctx context.Context, webhook entity.Webhook) (*entity.Webhook, error) {
+	args := m.Called(ctx, webhook)
+	return args.Get(0).(*entity.Webhook), args.Error(1)
+}
+
+func (m *MockWebhookRepository) GetWebhooks(ctx context.Context) ([]entity.Webhook, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]entity.Webhook), args.Error(1)
+}
+
+func (m *MockWebhookRepository) DeleteWebhook(ctx context.Context, id int64) error {
+	return m.Called(ctx, id).Error(0)
+}
+
+func (m *MockWebhookRepository) EnqueueDeliveries(ctx context.Context, event string, payload []byte) (int64, error) {
+	args := m.Called(ctx, event, payload)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockWebhookRepository) ClaimDeliveries(ctx context.Context, limit int, leaseUntil time.Time) ([]entity.WebhookDispatch, error) {
+	args := m.Called(ctx, limit, leaseUntil)
+	return args.Get(0).([]entity.WebhookDispatch), args.Error(1)
+}
+
+func (m *MockWebhookRepository) UpdateDelivery(ctx context.Context, delivery entity.WebhookDelivery) error {
+	return m.Called(ctx, delivery).Error(0)
+}
+
+func (m *MockWebhookRepository) GetDeliveries(ctx context.Context, webhookID int64, nextPage string, pageSize int) ([]entity.WebhookDelivery, error) {
+	args := m.Called(ctx, webhookID, nextPage, pageSize)
+	return args.Get(0).([]entity.WebhookDelivery), args.Error(1)
+}
+
+type MockWebhookSender struct {
+	mock.Mock
+}
+
+func (m *MockWebhookSender) Send(ctx context.Context, webhook entity.Webhook, delivery entity.WebhookDelivery) (int, error) {
+	args := m.Called(ctx, webhook, delivery)
+	return args.Int(0), args.Error(1)
+}
+
+func TestWebhookServiceCreateWebhookValidation(t *testing.T) {
+	service := NewWebhookService(new(MockWebhookRepository), memory.NewStockRatingRepository(), new(MockWebhookSender))
+
+	testCases := []struct {
+		name    string
+		webhook entity.Webhook
+	}{
+		{name: "Relative Url", webhook: entity.Webhook{Url: "/hooks", Events: []string{entity.EventRatingCreated}}},
+		{name: "Unsupported Scheme", webhook: entity.Webhook{Url: "ftp://example.com", Events: []string{entity.EventRatingCreated}}},
+		{name: "No Events", webhook: entity.Webhook{Url: "https://example.com/hooks"}},
+		{name: "Unknown Event", webhook: entity.Webhook{Url: "https://example.com/hooks", Events: []string{"rating.deleted"}}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := service.CreateWebhook(context.Background(), tc.webhook)
+			assert.ErrorIs(t, err, entity.ErrInvalidWebhook)
+		})
+	}
+}
+
+func TestWebhookServiceCreateWebhookGeneratesSecret(t *testing.T) {
+	ctx := context.Background()
+	mockRepository := new(MockWebhookRepository)
+	service := NewWebhookService(mockRepository, memory.NewStockRatingRepository(), new(MockWebhookSender))
+
+	mockRepository.On("SaveWebhook", ctx, mock.MatchedBy(func(webhook entity.Webhook) bool {
+		return len(webhook.Secret) == 2*webhookSecretSize && len(webhook.Events) == 1
+	})).Return(&entity.Webhook{ID: 1}, nil).Once()
+
+	_, err := service.CreateWebhook(ctx, entity.Webhook{
+		Url:    "https://example.com/hooks",
+		Events: []string{entity.EventRatingCreated, entity.EventRatingCreated},
+	})
+
+	assert.NoError(t, err)
+	mockRepository.AssertExpectations(t)
+}
+
+func TestWebhookServiceDeliver(t *testing.T) {
+	testCases := []struct {
+		name           string
+		attempts       int
+		statusCode     int
+		sendError      error
+		expectedStatus string
+	}{
+		{name: "Delivered", statusCode: http.StatusOK, expectedStatus: entity.DeliveryStatusDelivered},
+		{name: "Retried", statusCode: http.StatusBadGateway, sendError: errors.New("bad gateway"), expectedStatus: entity.DeliveryStatusPending},
+		{name: "Failed", attempts: webhookMaxAttempts - 1, sendError: errors.New("connection refused"), expectedStatus: entity.DeliveryStatusFailed},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			mockRepository := new(MockWebhookRepository)
+			mockSender := new(MockWebhookSender)
+			service := NewWebhookService(mockRepository, memory.NewStockRatingRepository(), mockSender)
+
+			dispatch := entity.WebhookDispatch{
+				Webhook:  entity.Webhook{ID: 1, Url: "https://example.com/hooks", Secret: "secret"},
+				Delivery: entity.WebhookDelivery{ID: 2, WebhookID: 1, Status: entity.DeliveryStatusPending, Attempts: tc.attempts},
+			}
+
+			mockSender.On("Send", mock.Anything, dispatch.Webhook, dispatch.Delivery).Return(tc.statusCode, tc.sendError).Once()
+			mockRepository.On("UpdateDelivery", mock.Anything, mock.MatchedBy(func(delivery entity.WebhookDelivery) bool {
+				return delivery.Status == tc.expectedStatus && delivery.Attempts == tc.attempts+1
+			})).Return(nil).Once()
+
+			service.deliver(ctx, dispatch)
+
+			mockSender.AssertExpectations(t)
+			mockRepository.AssertExpectations(t)
+		})
+	}
+}
+
+func TestNextDeliveryDelay(t *testing.T) {
+	assert.InDelta(t, 30*time.Second, nextDeliveryDelay(1), float64(3*time.Second))
+	assert.InDelta(t, 60*time.Second, nextDeliveryDelay(2), float64(6*time.Second))
+	assert.InDelta(t, 1*time.Hour, nextDeliveryDelay(20), float64(6*time.Minute))
+}
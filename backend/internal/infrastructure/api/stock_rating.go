@@ -9,7 +9,6 @@ import (
 	"io"
 	"log/slog"
 	"net/http"
-	"os"
 	"regexp"
 	"strings"
 	"sync"
@@ -18,14 +17,21 @@ import (
 
 	"github.com/gocolly/colly/v2"
 	"github.com/rubenpad/srs/internal/domain/entity"
+	"github.com/rubenpad/srs/internal/infrastructure/config"
+	"github.com/rubenpad/srs/internal/infrastructure/otel"
 
 	"github.com/cenkalti/backoff/v5"
-
-	finnhub "github.com/Finnhub-Stock-API/finnhub-go/v2"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const errorMessage = "there was an error while processing the stock ratings from external API"
 
+// currencySymbols are the symbols that can prefix the targets in the custom format.
+const currencySymbols = "$€£¥₹"
+
 var actions = []string{
 	"target lowered by",
 	"target raised by",
@@ -69,32 +75,76 @@ type stockRatingsDto struct {
 }
 
 type StockRatingApi struct {
-	baseURL       string
-	authToken     string
-	format        string
-	httpClient    *http.Client
-	collector     colly.Collector
-	finnhubClient *finnhub.DefaultApiService
+	baseURL            string
+	authToken          string
+	format             string
+	tickerDataURL      string
+	httpClient         *http.Client
+	collector          colly.Collector
+	marketDataProvider entity.MarketDataProvider
+	brokerages         *entity.BrokerageRegistry
+	metrics            ingestionMetrics
+	scraper            *otel.Upstream
 }
 
-func NewStockRatingApi() *StockRatingApi {
-	configuration := finnhub.NewConfiguration()
-	configuration.AddDefaultHeader("X-Finnhub-Token", os.Getenv("FINNHUB_API_KEY"))
+func NewStockRatingApi(configuration config.StockRatingApi, marketDataProvider entity.MarketDataProvider, brokerages *entity.BrokerageRegistry) *StockRatingApi {
+	collector := colly.NewCollector()
+	collector.WithTransport(otelhttp.NewTransport(http.DefaultTransport))
 
 	return &StockRatingApi{
-		httpClient:    &http.Client{},
-		baseURL:       os.Getenv("STOCK_RATING_API_URL"),
-		authToken:     os.Getenv("STOCK_RATING_API_AUTH_TOKEN"),
-		format:        os.Getenv("STOCK_RATING_API_FORMAT"),
-		finnhubClient: finnhub.NewAPIClient(configuration).DefaultApi,
-		collector:     *colly.NewCollector(),
+		httpClient:         &http.Client{Transport: otelhttp.NewTransport(http.DefaultTransport)},
+		baseURL:            configuration.StockRatingApiUrl,
+		authToken:          configuration.StockRatingApiAuthToken.Value(),
+		format:             configuration.StockRatingApiFormat,
+		tickerDataURL:      configuration.WebTickerDataUrl,
+		collector:          *collector,
+		marketDataProvider: marketDataProvider,
+		brokerages:         brokerages,
+		metrics:            newIngestionMetrics(),
+		scraper:            otel.NewUpstream("scraper"),
+	}
+}
+
+// HealthCheck requests the first page of ratings, without retries, to verify
+// that the rating API is reachable and accepts the auth token.
+func (s *StockRatingApi) HealthCheck(ctx context.Context) error {
+	if s.baseURL == "" {
+		return errors.New("stock rating API URL is not set")
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, s.baseURL+"/swechallenge/list", nil)
+	if err != nil {
+		return err
 	}
+
+	request.Header.Add("Authorization", fmt.Sprintf("Bearer %s", s.authToken))
+	response, err := s.httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+
+	defer response.Body.Close()
+
+	switch {
+	case response.StatusCode == http.StatusUnauthorized || response.StatusCode == http.StatusForbidden:
+		return errors.New("stock rating API rejected the auth token")
+	case response.StatusCode >= 400:
+		return fmt.Errorf("stock rating API answered %d", response.StatusCode)
+	}
+
+	return nil
 }
 
 func (s *StockRatingApi) GetStockRatings(ctx context.Context, nextPage string, useCustomFormat bool) ([]entity.StockRating, string, error) {
 	url := s.baseURL + "/swechallenge/list"
 	withCustomFormat := useCustomFormat && s.format != ""
 
+	ctx, span := tracer.Start(ctx, "StockRatingApi.GetStockRatings", trace.WithAttributes(
+		attribute.String("ingestion.page", nextPage),
+		attribute.Bool("ingestion.custom_format", withCustomFormat),
+	))
+	defer span.End()
+
 	operation := func() ([]entity.StockRating, error) {
 		request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 		q := request.URL.Query()
@@ -110,7 +160,7 @@ func (s *StockRatingApi) GetStockRatings(ctx context.Context, nextPage string, u
 		request.URL.RawQuery = q.Encode()
 
 		if err != nil {
-			slog.Error("http error", "error", err)
+			slog.ErrorContext(ctx, "http error", "error", err)
 			return nil, errors.New(errorMessage)
 		}
 
@@ -128,13 +178,13 @@ func (s *StockRatingApi) GetStockRatings(ctx context.Context, nextPage string, u
 				firstLine, readErr := reader.ReadString('\n')
 
 				if readErr != nil && readErr != io.EOF {
-					slog.Error("error reading first line for next page", "error", readErr)
+					slog.ErrorContext(ctx, "error reading first line for next page", "error", readErr)
 					return nil, backoff.Permanent(errors.New(errorMessage))
 				}
 
 				nextPage = strings.TrimSpace(firstLine)
 
-				ratings, parseErr := s.parseStockRatingsResponse(reader)
+				ratings, parseErr := s.parseStockRatingsResponse(ctx, reader)
 				if parseErr != nil {
 					return nil, backoff.Permanent(parseErr)
 				}
@@ -144,7 +194,7 @@ func (s *StockRatingApi) GetStockRatings(ctx context.Context, nextPage string, u
 
 			var stockRatings stockRatingsDto
 			if err := json.NewDecoder(response.Body).Decode(&stockRatings); err != nil {
-				slog.Error("error decoding stock ratings", "error", err)
+				slog.ErrorContext(ctx, "error decoding stock ratings", "error", err)
 				return nil, backoff.Permanent(errors.New(errorMessage))
 			}
 
@@ -153,11 +203,11 @@ func (s *StockRatingApi) GetStockRatings(ctx context.Context, nextPage string, u
 		}
 
 		if response.StatusCode >= 400 && response.StatusCode <= 499 {
-			slog.Error("client error from external API", "status", response.StatusCode)
+			slog.ErrorContext(ctx, "client error from external API", "status", response.StatusCode)
 			return nil, backoff.Permanent(errors.New(errorMessage))
 		}
 
-		slog.Info("error getting stock ratings - will retry", "status", response.StatusCode)
+		slog.InfoContext(ctx, "error getting stock ratings - will retry", "status", response.StatusCode)
 		return nil, errors.New(errorMessage)
 	}
 
@@ -166,24 +216,43 @@ func (s *StockRatingApi) GetStockRatings(ctx context.Context, nextPage string, u
 		operation,
 		backoff.WithMaxTries(3),
 		backoff.WithMaxElapsedTime(1*time.Minute),
+		backoff.WithNotify(func(err error, delay time.Duration) {
+			s.metrics.retries.Add(ctx, 1)
+			span.AddEvent("retry", trace.WithAttributes(
+				attribute.String("error", err.Error()),
+				attribute.String("delay", delay.String()),
+			))
+		}),
 		backoff.WithBackOff(backoff.NewExponentialBackOff()))
 
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	for i := range result {
+		result[i].Brokerage = s.brokerages.Canonical(result[i].Brokerage)
+	}
+
+	span.SetAttributes(attribute.Int("ingestion.page.ratings", len(result)))
+
 	return result, nextPage, err
 }
 
-func (s *StockRatingApi) parseStockRatingsResponse(body io.Reader) ([]entity.StockRating, error) {
+func (s *StockRatingApi) parseStockRatingsResponse(ctx context.Context, body io.Reader) ([]entity.StockRating, error) {
 	scanner := bufio.NewScanner(body)
 
 	var stockRatings []entity.StockRating
 	for scanner.Scan() {
 		line := scanner.Text()
-		if !strings.Contains(line, "$") {
+		if !strings.ContainsAny(line, currencySymbols) {
 			continue
 		}
 
 		rating, err := parseStockRatingLine(line)
 		if err != nil {
-			slog.Error("error parsing stock rating line", "error", err, "line", line)
+			slog.ErrorContext(ctx, "error parsing stock rating line", "error", err, "line", line)
+			s.metrics.parseFailures.Add(ctx, 1)
 			continue
 		}
 
@@ -191,7 +260,7 @@ func (s *StockRatingApi) parseStockRatingsResponse(body io.Reader) ([]entity.Sto
 	}
 
 	if err := scanner.Err(); err != nil {
-		slog.Error("error scanning response body", "error", err)
+		slog.ErrorContext(ctx, "error scanning response body", "error", err)
 		return nil, fmt.Errorf("%s: %w", errorMessage, err)
 	}
 
@@ -211,7 +280,7 @@ func parseStockRatingLine(line string) (entity.StockRating, error) {
 	}
 
 	dataPart := line[:dateIndex]
-	partsRegex := regexp.MustCompile(`^([A-Z]+)(\$\d+(?:,\d{3})*\.\d{2})(\$\d+(?:,\d{3})*\.\d{2})(.*)$`)
+	partsRegex := regexp.MustCompile(`^([A-Z]+)([$€£¥₹]\d+(?:,\d{3})*\.\d{2})([$€£¥₹]\d+(?:,\d{3})*\.\d{2})(.*)$`)
 	parts := partsRegex.FindStringSubmatch(dataPart)
 
 	if len(parts) != 5 {
@@ -319,62 +388,122 @@ func formatConcatenatedString(name string) string {
 	return builder.String()
 }
 
-func (s *StockRatingApi) GetStockDetails(ctx context.Context, ticker string) *entity.StockDetails {
+func (s *StockRatingApi) GetStockDetails(ctx context.Context, ticker string) (*entity.StockDetails, error) {
 	var (
-		quoteErr, recErr error
-		wg               sync.WaitGroup
-
-		keyFacts        string
-		quote           finnhub.Quote
-		recommendations []finnhub.RecommendationTrend
+		mu     sync.Mutex
+		wg     sync.WaitGroup
+		closed bool
+
+		details = &entity.StockDetails{}
+		pending = map[string]*entity.SectionStatus{
+			"quote":           &details.Status.Quote,
+			"recommendations": &details.Status.Recommendations,
+			"keyFacts":        &details.Status.KeyFacts,
+		}
 	)
 
 	ctx, cancel := context.WithTimeout(ctx, 6*time.Second)
 	defer cancel()
 
-	tickerUrl := fmt.Sprintf("%s/%s", os.Getenv("WEB_TICKER_DATA_URL"), ticker)
+	// complete stores the result of a section unless the request already
+	// timed out, in which case the late result is discarded.
+	complete := func(section string, status entity.SectionStatus, apply func()) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if closed {
+			return
+		}
+
+		if apply != nil {
+			apply()
+		}
+
+		*pending[section] = status
+		delete(pending, section)
+	}
+
+	tickerUrl := fmt.Sprintf("%s/%s", s.tickerDataURL, ticker)
 
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
 
-		s.collector.OnError(func(r *colly.Response, err error) {
-			slog.Error("error collecting information from web", "error", err)
+		var (
+			keyFacts   string
+			collectErr error
+		)
+
+		ctx, span := tracer.Start(ctx, "StockRatingApi.scrapeKeyFacts", trace.WithAttributes(attribute.String("ticker", ticker)))
+		defer span.End()
+
+		collector := s.collector.Clone()
+		collector.Context = ctx
+		collector.AllowURLRevisit = true
+
+		collector.OnError(func(r *colly.Response, err error) {
+			collectErr = err
+			slog.ErrorContext(ctx, "error collecting information from web", "error", err, "ticker", ticker)
 		})
 
-		s.collector.OnHTML("html > body > div:nth-of-type(1) > section:nth-of-type(3) > section:nth-of-type(1) > div > section > div > div:nth-of-type(2)", func(e *colly.HTMLElement) {
+		collector.OnHTML("html > body > div:nth-of-type(1) > section:nth-of-type(3) > section:nth-of-type(1) > div > section > div > div:nth-of-type(2)", func(e *colly.HTMLElement) {
 			keyFacts = e.ChildText("p")
 		})
 
-		s.collector.OnRequest(func(r *colly.Request) {
-			slog.Info("visiting web", "url", tickerUrl)
+		collector.OnRequest(func(r *colly.Request) {
+			slog.InfoContext(ctx, "visiting web", "url", tickerUrl)
 		})
 
-		s.collector.Visit(tickerUrl)
+		start := time.Now()
+		if err := collector.Visit(tickerUrl); err != nil && collectErr == nil {
+			collectErr = err
+			slog.ErrorContext(ctx, "error visiting web", "error", err, "ticker", ticker)
+		}
+		s.scraper.Record(ctx, "key_facts", start, collectErr)
+		if collectErr != nil {
+			span.RecordError(collectErr)
+			span.SetStatus(codes.Error, collectErr.Error())
+		}
+
+		switch {
+		case collectErr != nil:
+			complete("keyFacts", sectionError(ctx, collectErr), nil)
+		case keyFacts == "":
+			complete("keyFacts", entity.NewSectionError(entity.SectionErrorNotFound, "key facts not found"), nil)
+		default:
+			complete("keyFacts", entity.NewSectionOk(), func() { details.KeyFacts = keyFacts })
+		}
 	}()
 
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		data, _, err := s.finnhubClient.Quote(ctx).Symbol(ticker).Execute()
+		quote, err := s.marketDataProvider.GetQuote(ctx, ticker)
+		if errors.Is(err, entity.ErrStockNotFound) {
+			complete("quote", entity.NewSectionError(entity.SectionErrorNotFound, "quote not found"), nil)
+			return
+		}
+
 		if err != nil {
-			quoteErr = err
-			slog.Error("error getting stock quote", "error", err, "ticker", ticker)
+			slog.ErrorContext(ctx, "error getting stock quote", "error", err, "ticker", ticker)
+			complete("quote", sectionError(ctx, err), nil)
 			return
 		}
-		quote = data
+
+		complete("quote", entity.NewSectionOk(), func() { details.Quote = quote })
 	}()
 
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		data, _, err := s.finnhubClient.RecommendationTrends(ctx).Symbol(ticker).Execute()
+		recommendations, err := s.marketDataProvider.GetRecommendationTrends(ctx, ticker)
 		if err != nil {
-			recErr = err
-			slog.Error("error getting stock recommendation trends", "error", err, "ticker", ticker)
+			slog.ErrorContext(ctx, "error getting stock recommendation trends", "error", err, "ticker", ticker)
+			complete("recommendations", sectionError(ctx, err), nil)
 			return
 		}
-		recommendations = data
+
+		complete("recommendations", entity.NewSectionOk(), func() { details.Recommendations = recommendations })
 	}()
 
 	done := make(chan struct{})
@@ -386,18 +515,48 @@ func (s *StockRatingApi) GetStockDetails(ctx context.Context, ticker string) *en
 	select {
 	case <-ctx.Done():
 		if ctx.Err() == context.DeadlineExceeded {
-			slog.Error("request timeout exceeded", "ticker", ticker)
+			slog.ErrorContext(ctx, "request timeout exceeded", "ticker", ticker)
 		}
-		return nil
 	case <-done:
-		if quoteErr != nil && recErr != nil {
-			return nil
-		}
+	}
 
-		return &entity.StockDetails{
-			KeyFacts:        keyFacts,
-			Quote:           &quote,
-			Recommendations: &recommendations,
-		}
+	mu.Lock()
+	defer mu.Unlock()
+
+	closed = true
+	for _, status := range pending {
+		*status = entity.NewSectionError(entity.SectionErrorTimeout, "request timeout exceeded")
+	}
+
+	return details, stockDetailsError(details)
+}
+
+func sectionError(ctx context.Context, err error) entity.SectionStatus {
+	if errors.Is(err, context.DeadlineExceeded) || ctx.Err() == context.DeadlineExceeded {
+		return entity.NewSectionError(entity.SectionErrorTimeout, "request timeout exceeded")
+	}
+
+	return entity.NewSectionError(entity.SectionErrorUpstreamError, err.Error())
+}
+
+// stockDetailsError summarises the section statuses: a ticker is unknown when
+// the quote says so and no recommendations exist, and the request only fails
+// when neither the quote nor the recommendations could be loaded.
+func stockDetailsError(details *entity.StockDetails) error {
+	quote := details.Status.Quote
+	recommendations := details.Status.Recommendations
+
+	if quote.Code == entity.SectionErrorNotFound && len(details.Recommendations) == 0 {
+		return entity.ErrStockNotFound
+	}
+
+	if quote.IsOk() || recommendations.IsOk() {
+		return nil
+	}
+
+	if quote.Code == entity.SectionErrorTimeout || recommendations.Code == entity.SectionErrorTimeout {
+		return entity.ErrUpstreamTimeout
 	}
+
+	return entity.ErrUpstreamUnavailable
 }
@@ -1,7 +1,10 @@
 package api
 
 import (
+	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"strings"
 	"testing"
@@ -97,6 +100,22 @@ var testCases = []struct {
 		},
 		expectError: false,
 	},
+	{
+		name: "Target Raised - Euro Targets",
+		line: "ASML€620.00€700.00ASMLHoldingtargetraisedbyBarclaysOverweightOverweightMonMar31202500:30UTC",
+		expected: entity.StockRating{
+			Ticker:     "ASML",
+			TargetFrom: "€620.00",
+			TargetTo:   "€700.00",
+			Company:    "ASML Holding",
+			Action:     "target raised by",
+			Brokerage:  "Barclays",
+			RatingFrom: "Overweight",
+			RatingTo:   "Overweight",
+			Time:       mustParseTime("MonMar31202500:30UTC"),
+		},
+		expectError: false,
+	},
 	{
 		name:        "Error Case - Invalid Date",
 		line:        "MOMO$13.00$13.00HelloGroupreiteratedbyBenchmarkBuyBuyInvalidDate",
@@ -193,3 +212,116 @@ func mustParseTime(value string) time.Time {
 	}
 	return t.Truncate(24 * time.Hour)
 }
+
+func TestStockDetailsError(t *testing.T) {
+	timeout := entity.NewSectionError(entity.SectionErrorTimeout, "")
+	upstreamError := entity.NewSectionError(entity.SectionErrorUpstreamError, "")
+	notFound := entity.NewSectionError(entity.SectionErrorNotFound, "")
+
+	cases := []struct {
+		name     string
+		details  entity.StockDetails
+		expected error
+	}{
+		{
+			name:     "Partial - Key Facts Missing",
+			details:  entity.StockDetails{Status: entity.StockDetailsStatus{Quote: entity.NewSectionOk(), Recommendations: entity.NewSectionOk(), KeyFacts: notFound}},
+			expected: nil,
+		},
+		{
+			name:     "Partial - Quote Failed",
+			details:  entity.StockDetails{Status: entity.StockDetailsStatus{Quote: upstreamError, Recommendations: entity.NewSectionOk(), KeyFacts: entity.NewSectionOk()}},
+			expected: nil,
+		},
+		{
+			name:     "Unknown Ticker",
+			details:  entity.StockDetails{Status: entity.StockDetailsStatus{Quote: notFound, Recommendations: entity.NewSectionOk(), KeyFacts: notFound}},
+			expected: entity.ErrStockNotFound,
+		},
+		{
+			name:     "Upstream Timeout",
+			details:  entity.StockDetails{Status: entity.StockDetailsStatus{Quote: timeout, Recommendations: upstreamError, KeyFacts: entity.NewSectionOk()}},
+			expected: entity.ErrUpstreamTimeout,
+		},
+		{
+			name:     "Upstream Error",
+			details:  entity.StockDetails{Status: entity.StockDetailsStatus{Quote: upstreamError, Recommendations: upstreamError, KeyFacts: timeout}},
+			expected: entity.ErrUpstreamUnavailable,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := stockDetailsError(&tc.details); actual != tc.expected {
+				t.Errorf("Expected %v, but got %v", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestGetStockRatingsCanonicalBrokerages(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("format") != "" {
+			fmt.Fprintln(w, "")
+			fmt.Fprintln(w, "JPM$200.00$210.00JPMorganChase&Co.targetraisedbyJPMorganOverweightOverweightMonMar31202500:30UTC")
+			return
+		}
+
+		fmt.Fprint(w, `{"next_page":"","items":[{"ticker":"JPM","brokerage":"J.P. Morgan","action":"target raised by","company":"JPMorgan Chase & Co.","rating_from":"Overweight","rating_to":"Overweight","target_from":"$200.00","target_to":"$210.00","time":"2025-03-31T00:30:00Z"}]}`)
+	}))
+	defer server.Close()
+
+	stockRatingApi := &StockRatingApi{
+		baseURL:    server.URL,
+		format:     "custom",
+		httpClient: server.Client(),
+		brokerages: entity.NewBrokerageRegistry(entity.DefaultBrokerages),
+	}
+
+	for _, useCustomFormat := range []bool{false, true} {
+		ratings, _, err := stockRatingApi.GetStockRatings(context.Background(), "", useCustomFormat)
+		if err != nil {
+			t.Fatalf("Expected no error, but got: %v", err)
+		}
+
+		if len(ratings) != 1 || ratings[0].Brokerage != "JPMorgan Chase & Co." {
+			t.Errorf("Expected canonical brokerage with custom format %v, but got: %+v", useCustomFormat, ratings)
+		}
+	}
+}
+
+func TestHealthCheck(t *testing.T) {
+	testCases := []struct {
+		name     string
+		status   int
+		errorMsg string
+	}{
+		{name: "Reachable", status: http.StatusOK},
+		{name: "Rejected token", status: http.StatusUnauthorized, errorMsg: "stock rating API rejected the auth token"},
+		{name: "Server error", status: http.StatusBadGateway, errorMsg: "stock rating API answered 502"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Header.Get("Authorization") != "Bearer token" {
+					t.Errorf("Expected the auth token, but got: %q", r.Header.Get("Authorization"))
+				}
+
+				w.WriteHeader(tc.status)
+			}))
+			defer server.Close()
+
+			stockRatingApi := &StockRatingApi{baseURL: server.URL, authToken: "token", httpClient: server.Client()}
+
+			err := stockRatingApi.HealthCheck(context.Background())
+			if tc.errorMsg == "" && err != nil {
+				t.Errorf("Expected no error, but got: %v", err)
+			}
+
+			if tc.errorMsg != "" && (err == nil || err.Error() != tc.errorMsg) {
+				t.Errorf("Expected error %q, but got: %v", tc.errorMsg, err)
+			}
+		})
+	}
+}
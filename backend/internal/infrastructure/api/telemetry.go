@@ -0,0 +1,33 @@
+package api
+
+import (
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+
+	srsotel "github.com/rubenpad/srs/internal/infrastructure/otel"
+)
+
+var tracer = otel.Tracer(srsotel.ScopeName)
+
+type ingestionMetrics struct {
+	retries       metric.Int64Counter
+	parseFailures metric.Int64Counter
+}
+
+func newIngestionMetrics() ingestionMetrics {
+	meter := otel.Meter(srsotel.ScopeName)
+
+	retries, err := meter.Int64Counter("srs.ingestion.retries",
+		metric.WithDescription("Retried requests for a page of the stock ratings API."))
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	parseFailures, err := meter.Int64Counter("srs.ingestion.parse_failures",
+		metric.WithDescription("Stock ratings of the custom format that could not be parsed."))
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	return ingestionMetrics{retries: retries, parseFailures: parseFailures}
+}
@@ -0,0 +1,212 @@
+// Package config loads the configuration shared by the binaries from an
+// optional YAML or TOML file and the environment.
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/kelseyhightower/envconfig"
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// FileEnv names the variable pointing to the configuration file when no path
+// is given to Load.
+const FileEnv = "SRS_CONFIG_FILE"
+
+// Config is the whole configuration. The sections are embedded so every
+// setting keeps its flat SRS_ variable, while the file nests them under the
+// section key.
+type Config struct {
+	Server         `yaml:"server"`
+	Database       `yaml:"database"`
+	Storage        `yaml:"storage"`
+	Logging        `yaml:"logging"`
+	Telemetry      `yaml:"telemetry"`
+	StockRatingApi `yaml:"stock_rating_api"`
+	MarketData     `yaml:"market_data"`
+	Reference      `yaml:"reference"`
+	Alerts         `yaml:"alerts"`
+}
+
+type Server struct {
+	Host            string        `yaml:"host"`
+	Port            uint          `yaml:"port"`
+	GrpcPort        uint          `yaml:"grpc_port" split_words:"true"`
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout" split_words:"true"`
+	// AdminToken authenticates the /api/admin requests, which are not served
+	// without it.
+	AdminToken Secret `yaml:"admin_token" split_words:"true"`
+}
+
+// Database is either a URL or the discrete connection fields. The other
+// settings apply to both and override the URL parameters; when zero, the URL
+// parameters or the defaults of cockroach.ConnectionString are used. The
+// migrations are read from DatabaseMigrationsPath when set, instead of the
+// ones embedded in the binaries.
+type Database struct {
+	DatabaseUrl              Secret        `yaml:"url" split_words:"true"`
+	Database                 string        `yaml:"name"`
+	DatabaseHost             string        `yaml:"host" split_words:"true"`
+	DatabasePort             uint          `yaml:"port" split_words:"true"`
+	DatabaseUser             string        `yaml:"user" split_words:"true"`
+	DatabasePassword         Secret        `yaml:"password" split_words:"true"`
+	DatabaseSslMode          string        `yaml:"sslmode" split_words:"true"`
+	DatabaseSslRootCert      string        `yaml:"sslrootcert" split_words:"true"`
+	DatabaseSslCert          string        `yaml:"sslcert" split_words:"true"`
+	DatabaseSslKey           string        `yaml:"sslkey" split_words:"true"`
+	DatabaseMaxConns         int32         `yaml:"max_conns" split_words:"true"`
+	DatabaseMinConns         int32         `yaml:"min_conns" split_words:"true"`
+	DatabaseMaxConnLifetime  time.Duration `yaml:"max_conn_lifetime" split_words:"true"`
+	DatabaseMaxConnIdleTime  time.Duration `yaml:"max_conn_idle_time" split_words:"true"`
+	DatabaseStatementTimeout time.Duration `yaml:"statement_timeout" split_words:"true"`
+	DatabaseApplicationName  string        `yaml:"application_name" split_words:"true"`
+	DatabaseMigrationsPath   string        `yaml:"migrations_path" split_words:"true"`
+}
+
+// Storage selects where the stock ratings are kept. Only the cockroach backend
+// uses the database, where the prices, companies, alerts and webhooks live
+// too, so those are unavailable with the others.
+type Storage struct {
+	StorageBackend    string `yaml:"backend" split_words:"true"`
+	StorageSqlitePath string `yaml:"sqlite_path" split_words:"true"`
+}
+
+type Logging struct {
+	LogLevel  string `yaml:"level" split_words:"true"`
+	LogFormat string `yaml:"format" split_words:"true"`
+}
+
+type Telemetry struct {
+	ServiceName            string  `yaml:"service_name" split_words:"true"`
+	ServiceVersion         string  `yaml:"service_version" split_words:"true"`
+	Environment            string  `yaml:"environment"`
+	TraceExporter          string  `yaml:"trace_exporter" split_words:"true"`
+	TraceSampleRatio       float64 `yaml:"trace_sample_ratio" split_words:"true"`
+	TraceSampleParentBased bool    `yaml:"trace_sample_parent_based" split_words:"true"`
+}
+
+// StockRatingApi also reads the unprefixed variables it was first configured
+// with, such as STOCK_RATING_API_URL.
+type StockRatingApi struct {
+	StockRatingApiUrl       string `yaml:"url" envconfig:"STOCK_RATING_API_URL"`
+	StockRatingApiAuthToken Secret `yaml:"auth_token" envconfig:"STOCK_RATING_API_AUTH_TOKEN"`
+	StockRatingApiFormat    string `yaml:"format" envconfig:"STOCK_RATING_API_FORMAT"`
+	WebTickerDataUrl        string `yaml:"web_ticker_data_url" envconfig:"WEB_TICKER_DATA_URL"`
+}
+
+type MarketData struct {
+	MarketDataProvider    string `yaml:"provider" split_words:"true"`
+	MarketDataFixturePath string `yaml:"fixture_path" split_words:"true"`
+	FinnhubApiKey         Secret `yaml:"finnhub_api_key" envconfig:"FINNHUB_API_KEY"`
+}
+
+type Reference struct {
+	CompanyDataPath string `yaml:"company_data_path" split_words:"true"`
+}
+
+type Alerts struct {
+	AlertWebhookUrl  string   `yaml:"webhook_url" split_words:"true"`
+	AlertSmtpAddress string   `yaml:"smtp_address" split_words:"true"`
+	AlertSmtpFrom    string   `yaml:"smtp_from" split_words:"true"`
+	AlertSmtpTo      []string `yaml:"smtp_to" split_words:"true"`
+}
+
+// Default returns the settings used when neither the file nor the environment
+// set them.
+func Default() Config {
+	return Config{
+		Server: Server{
+			Host:            "0.0.0.0",
+			Port:            8080,
+			GrpcPort:        9090,
+			ShutdownTimeout: 10 * time.Second,
+		},
+		Database: Database{
+			DatabasePort: 26257,
+		},
+		Storage: Storage{
+			StorageBackend: "cockroach",
+		},
+		Logging: Logging{
+			LogLevel:  "info",
+			LogFormat: "json",
+		},
+		Telemetry: Telemetry{
+			ServiceName:            "srs",
+			ServiceVersion:         "dev",
+			Environment:            "development",
+			TraceExporter:          "otlphttp",
+			TraceSampleRatio:       1,
+			TraceSampleParentBased: true,
+		},
+		MarketData: MarketData{
+			MarketDataProvider: "finnhub",
+		},
+	}
+}
+
+// Load reads the defaults, the file at path and the environment, each one
+// overriding the previous, and validates the result. When path is empty the
+// file is taken from SRS_CONFIG_FILE, if set.
+func Load(path string) (Config, error) {
+	configuration := Default()
+
+	if path == "" {
+		path = os.Getenv(FileEnv)
+	}
+
+	if path != "" {
+		if err := readFile(path, &configuration); err != nil {
+			return Config{}, err
+		}
+	}
+
+	if err := envconfig.Process("SRS", &configuration); err != nil {
+		return Config{}, err
+	}
+
+	if err := configuration.Validate(); err != nil {
+		return Config{}, fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	return configuration, nil
+}
+
+func readFile(path string, configuration *Config) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("error reading configuration file: %w", err)
+	}
+
+	switch filepath.Ext(path) {
+	case ".yaml", ".yml":
+	case ".toml":
+		// TOML is decoded through YAML so both formats share the keys and
+		// the duration syntax.
+		var document map[string]any
+		if err := toml.Unmarshal(content, &document); err != nil {
+			return fmt.Errorf("error parsing %s: %w", path, err)
+		}
+
+		if content, err = yaml.Marshal(document); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("configuration file %s must be .yaml, .yml or .toml", path)
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(configuration); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("error parsing %s: %w", path, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,198 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setDatabaseEnv(t *testing.T) {
+	t.Setenv("SRS_DATABASE", "srs")
+	t.Setenv("SRS_DATABASE_HOST", "localhost")
+	t.Setenv("SRS_DATABASE_PORT", "26257")
+	t.Setenv("SRS_DATABASE_USER", "srs")
+	t.Setenv("SRS_DATABASE_PASSWORD", "s3cret")
+}
+
+func writeFile(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoadDefaults(t *testing.T) {
+	setDatabaseEnv(t)
+
+	configuration, err := Load("")
+	require.NoError(t, err)
+
+	assert.Equal(t, "0.0.0.0", configuration.Host)
+	assert.Equal(t, uint(8080), configuration.Port)
+	assert.Equal(t, "srs", configuration.Database.Database)
+	assert.Equal(t, "s3cret", configuration.DatabasePassword.Value())
+}
+
+func TestLoadFile(t *testing.T) {
+	files := map[string]string{
+		"config.yaml": `
+server:
+  port: 9000
+  shutdown_timeout: 30s
+logging:
+  level: debug
+stock_rating_api:
+  url: https://ratings.example.com
+alerts:
+  smtp_to: [a@example.com, b@example.com]
+`,
+		"config.toml": `
+[server]
+port = 9000
+shutdown_timeout = "30s"
+
+[logging]
+level = "debug"
+
+[stock_rating_api]
+url = "https://ratings.example.com"
+
+[alerts]
+smtp_to = ["a@example.com", "b@example.com"]
+`,
+	}
+
+	for name, content := range files {
+		t.Run(name, func(t *testing.T) {
+			setDatabaseEnv(t)
+			t.Setenv("SRS_LOG_LEVEL", "warn")
+
+			configuration, err := Load(writeFile(t, name, content))
+			require.NoError(t, err)
+
+			assert.Equal(t, uint(9000), configuration.Port)
+			assert.Equal(t, 30*time.Second, configuration.ShutdownTimeout)
+			assert.Equal(t, "warn", configuration.LogLevel, "the environment overrides the file")
+			assert.Equal(t, "https://ratings.example.com", configuration.StockRatingApiUrl)
+			assert.Equal(t, []string{"a@example.com", "b@example.com"}, configuration.AlertSmtpTo)
+			assert.Equal(t, uint(9090), configuration.GrpcPort, "unset keys keep their default")
+		})
+	}
+}
+
+func TestLoadFileFromEnv(t *testing.T) {
+	setDatabaseEnv(t)
+	t.Setenv(FileEnv, writeFile(t, "config.yml", "server:\n  port: 9000\n"))
+
+	configuration, err := Load("")
+	require.NoError(t, err)
+	assert.Equal(t, uint(9000), configuration.Port)
+}
+
+func TestLoadLegacyVariables(t *testing.T) {
+	setDatabaseEnv(t)
+	t.Setenv("STOCK_RATING_API_URL", "https://ratings.example.com")
+	t.Setenv("FINNHUB_API_KEY", "key")
+
+	configuration, err := Load("")
+	require.NoError(t, err)
+	assert.Equal(t, "https://ratings.example.com", configuration.StockRatingApiUrl)
+	assert.Equal(t, "key", configuration.FinnhubApiKey.Value())
+}
+
+func TestLoadErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		err     string
+	}{
+		{name: "unknown key", file: "config.yaml", content: "server:\n  prot: 9000\n", err: "field prot not found"},
+		{name: "unsupported format", file: "config.json", content: "{}", err: "must be .yaml, .yml or .toml"},
+		{name: "invalid toml", file: "config.toml", content: "[server", err: "error parsing"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setDatabaseEnv(t)
+
+			_, err := Load(writeFile(t, tt.file, tt.content))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.err)
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	configuration := Default()
+	configuration.Port = 0
+	configuration.LogFormat = "xml"
+	configuration.TraceSampleRatio = 2
+	configuration.StockRatingApiUrl = "ratings.example.com"
+	configuration.MarketDataProvider = "fixture"
+	configuration.AlertSmtpAddress = "smtp.example.com:25"
+
+	err := configuration.Validate()
+	require.Error(t, err)
+
+	for _, message := range []string{
+		"server.port (SRS_PORT) must be between 1 and 65535",
+		"database.name (SRS_DATABASE) is required",
+		"database.host (SRS_DATABASE_HOST) is required",
+		"logging.format (SRS_LOG_FORMAT) must be json or text",
+		"telemetry.trace_sample_ratio (SRS_TRACE_SAMPLE_RATIO) must be between 0 and 1",
+		"stock_rating_api.url (STOCK_RATING_API_URL) must be an absolute http or https URL",
+		"market_data.fixture_path (SRS_MARKET_DATA_FIXTURE_PATH) is required by the fixture provider",
+		"alerts.smtp_to (SRS_ALERT_SMTP_TO) is required by the smtp notifier",
+	} {
+		assert.Contains(t, err.Error(), message)
+	}
+}
+
+func TestValidateStorageBackends(t *testing.T) {
+	configuration := Default()
+	configuration.StorageBackend = "memory"
+	assert.NoError(t, configuration.Validate(), "the memory backend needs no database")
+
+	configuration.StorageBackend = "sqlite"
+	err := configuration.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "storage.sqlite_path (SRS_STORAGE_SQLITE_PATH) is required by the sqlite backend")
+	assert.NotContains(t, err.Error(), "database.", "the sqlite backend needs no database")
+
+	configuration.StorageBackend = "cockroach"
+	err = configuration.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "database.host (SRS_DATABASE_HOST) is required")
+}
+
+func TestSecretsAreRedacted(t *testing.T) {
+	configuration := Default()
+	configuration.DatabasePassword = "s3cret"
+	configuration.FinnhubApiKey = "finnhub-key"
+
+	var logs bytes.Buffer
+	slog.New(slog.NewJSONHandler(&logs, nil)).Info("configuration", "config", configuration)
+	encoded, err := json.Marshal(configuration)
+	require.NoError(t, err)
+
+	for _, output := range []string{logs.String(), string(encoded), fmt.Sprintf("%+v", configuration)} {
+		assert.NotContains(t, output, "s3cret")
+		assert.NotContains(t, output, "finnhub-key")
+		assert.Contains(t, output, redacted)
+	}
+}
+
+func TestExampleFileIsValid(t *testing.T) {
+	t.Setenv("SRS_DATABASE_PASSWORD", "s3cret")
+
+	_, err := Load("../../../config.example.yaml")
+	assert.NoError(t, err)
+}
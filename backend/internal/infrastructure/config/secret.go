@@ -0,0 +1,29 @@
+package config
+
+import "log/slog"
+
+const redacted = "[REDACTED]"
+
+// Secret is a configuration value that is never printed, logged or encoded.
+// Use Value to read it.
+type Secret string
+
+func (s Secret) Value() string {
+	return string(s)
+}
+
+func (s Secret) String() string {
+	if s == "" {
+		return ""
+	}
+
+	return redacted
+}
+
+func (s Secret) LogValue() slog.Value {
+	return slog.StringValue(s.String())
+}
+
+func (s Secret) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
@@ -0,0 +1,167 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"slices"
+
+	"github.com/rubenpad/srs/internal/infrastructure/logging"
+	"github.com/rubenpad/srs/internal/infrastructure/otel"
+)
+
+var (
+	traceExporters      = []string{otel.ExporterOtlpHttp, otel.ExporterOtlpGrpc, otel.ExporterStdout, otel.ExporterNone}
+	marketDataProviders = []string{"finnhub", "fixture"}
+	databaseSchemes     = []string{"postgres", "postgresql", "cockroachdb"}
+	sslModes            = []string{"disable", "allow", "prefer", "require", "verify-ca", "verify-full"}
+	storageBackends     = []string{"cockroach", "memory", "sqlite"}
+)
+
+// Validate reports every invalid setting at once, naming both its file key and
+// its variable.
+func (c Config) Validate() error {
+	var errs []error
+	invalid := func(key, variable, format string, args ...any) {
+		errs = append(errs, fmt.Errorf("%s (%s) %s", key, variable, fmt.Sprintf(format, args...)))
+	}
+
+	if c.Port == 0 || c.Port > 65535 {
+		invalid("server.port", "SRS_PORT", "must be between 1 and 65535")
+	}
+
+	if c.GrpcPort > 65535 {
+		invalid("server.grpc_port", "SRS_GRPC_PORT", "must be between 0 and 65535")
+	}
+
+	if c.ShutdownTimeout <= 0 {
+		invalid("server.shutdown_timeout", "SRS_SHUTDOWN_TIMEOUT", "must be positive")
+	}
+
+	// Only the cockroach backend connects to the database.
+	if c.StorageBackend == "cockroach" {
+		if err := c.Database.Validate(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if !slices.Contains(storageBackends, c.StorageBackend) {
+		invalid("storage.backend", "SRS_STORAGE_BACKEND", "must be one of %v", storageBackends)
+	}
+
+	if c.StorageBackend == "sqlite" && c.StorageSqlitePath == "" {
+		invalid("storage.sqlite_path", "SRS_STORAGE_SQLITE_PATH", "is required by the sqlite backend")
+	}
+
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(c.LogLevel)); err != nil {
+		invalid("logging.level", "SRS_LOG_LEVEL", "must be debug, info, warn or error")
+	}
+
+	if c.LogFormat != logging.FormatJson && c.LogFormat != logging.FormatText {
+		invalid("logging.format", "SRS_LOG_FORMAT", "must be %s or %s", logging.FormatJson, logging.FormatText)
+	}
+
+	if !slices.Contains(traceExporters, c.TraceExporter) {
+		invalid("telemetry.trace_exporter", "SRS_TRACE_EXPORTER", "must be one of %v", traceExporters)
+	}
+
+	if c.TraceSampleRatio < 0 || c.TraceSampleRatio > 1 {
+		invalid("telemetry.trace_sample_ratio", "SRS_TRACE_SAMPLE_RATIO", "must be between 0 and 1")
+	}
+
+	if err := validateUrl(c.StockRatingApiUrl); err != nil {
+		invalid("stock_rating_api.url", "STOCK_RATING_API_URL", "%v", err)
+	}
+
+	if err := validateUrl(c.WebTickerDataUrl); err != nil {
+		invalid("stock_rating_api.web_ticker_data_url", "WEB_TICKER_DATA_URL", "%v", err)
+	}
+
+	if !slices.Contains(marketDataProviders, c.MarketDataProvider) {
+		invalid("market_data.provider", "SRS_MARKET_DATA_PROVIDER", "must be one of %v", marketDataProviders)
+	}
+
+	if c.MarketDataProvider == "fixture" && c.MarketDataFixturePath == "" {
+		invalid("market_data.fixture_path", "SRS_MARKET_DATA_FIXTURE_PATH", "is required by the fixture provider")
+	}
+
+	if err := validateUrl(c.AlertWebhookUrl); err != nil {
+		invalid("alerts.webhook_url", "SRS_ALERT_WEBHOOK_URL", "%v", err)
+	}
+
+	if c.AlertSmtpAddress != "" && c.AlertSmtpFrom == "" {
+		invalid("alerts.smtp_from", "SRS_ALERT_SMTP_FROM", "is required by the smtp notifier")
+	}
+
+	if c.AlertSmtpAddress != "" && len(c.AlertSmtpTo) == 0 {
+		invalid("alerts.smtp_to", "SRS_ALERT_SMTP_TO", "is required by the smtp notifier")
+	}
+
+	return errors.Join(errs...)
+}
+
+// Validate reports every invalid database setting at once. The commands that
+// always work on the database check it whatever the storage backend.
+func (d Database) Validate() error {
+	var errs []error
+	invalid := func(key, variable, format string, args ...any) {
+		errs = append(errs, fmt.Errorf("%s (%s) %s", key, variable, fmt.Sprintf(format, args...)))
+	}
+
+	if d.DatabaseUrl != "" {
+		if parsed, err := url.Parse(d.DatabaseUrl.Value()); err != nil || !slices.Contains(databaseSchemes, parsed.Scheme) {
+			invalid("database.url", "SRS_DATABASE_URL", "must be a postgresql:// or cockroachdb:// URL")
+		}
+	} else {
+		if d.Database == "" {
+			invalid("database.name", "SRS_DATABASE", "is required")
+		}
+
+		if d.DatabaseHost == "" {
+			invalid("database.host", "SRS_DATABASE_HOST", "is required")
+		}
+
+		if d.DatabasePort == 0 || d.DatabasePort > 65535 {
+			invalid("database.port", "SRS_DATABASE_PORT", "must be between 1 and 65535")
+		}
+
+		if d.DatabaseUser == "" {
+			invalid("database.user", "SRS_DATABASE_USER", "is required")
+		}
+	}
+
+	if d.DatabaseSslMode != "" && !slices.Contains(sslModes, d.DatabaseSslMode) {
+		invalid("database.sslmode", "SRS_DATABASE_SSL_MODE", "must be one of %v", sslModes)
+	}
+
+	if (d.DatabaseSslCert == "") != (d.DatabaseSslKey == "") {
+		invalid("database.sslcert", "SRS_DATABASE_SSL_CERT", "and database.sslkey (SRS_DATABASE_SSL_KEY) must be set together")
+	}
+
+	if d.DatabaseMaxConns < 0 || d.DatabaseMinConns < 0 || (d.DatabaseMaxConns > 0 && d.DatabaseMinConns > d.DatabaseMaxConns) {
+		invalid("database.min_conns", "SRS_DATABASE_MIN_CONNS", "must be between 0 and database.max_conns (SRS_DATABASE_MAX_CONNS)")
+	}
+
+	if d.DatabaseMaxConnLifetime < 0 || d.DatabaseMaxConnIdleTime < 0 || d.DatabaseStatementTimeout < 0 {
+		invalid("database.statement_timeout", "SRS_DATABASE_STATEMENT_TIMEOUT", "and the connection durations must not be negative")
+	}
+
+	return errors.Join(errs...)
+}
+
+// validateUrl accepts an empty value, for the optional services, or an
+// absolute http(s) URL.
+func validateUrl(value string) error {
+	if value == "" {
+		return nil
+	}
+
+	parsed, err := url.Parse(value)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return errors.New("must be an absolute http or https URL")
+	}
+
+	return nil
+}
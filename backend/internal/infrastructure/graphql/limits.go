@@ -0,0 +1,178 @@
+package graphql
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+)
+
+const (
+	MaxDepth      = 6
+	MaxComplexity = 1000
+	// MaxStockDetails bounds the stock details, fetched from the upstream
+	// providers, a query can ask for however they are aliased or nested.
+	MaxStockDetails = 20
+
+	// stockDetailsCost reflects the three upstream calls made per ticker.
+	stockDetailsCost = 20
+)
+
+// listSizeArguments are the arguments bounding the size of a list field, with
+// the size assumed when they are not given and the largest size accepted.
+var listSizeArguments = map[string]struct {
+	argument    string
+	defaultSize int
+	maxSize     int
+}{
+	"stockRatings":         {"pageSize", defaultPageSize, maxPageSize},
+	"stockRecommendations": {"pageSize", defaultPageSize, maxPageSize},
+	"ratings":              {"limit", defaultRatingsLimit, maxRatingsLimit},
+}
+
+var fieldCosts = map[string]int{
+	"stockDetails": stockDetailsCost,
+	"details":      stockDetailsCost,
+}
+
+// stockDetailsFields are the fields resolved with the stock details loader.
+var stockDetailsFields = map[string]bool{
+	"stockDetails": true,
+	"details":      true,
+}
+
+// CheckLimits rejects queries nested deeper than MaxDepth, whose estimated
+// cost exceeds MaxComplexity or that ask for more than MaxStockDetails stock
+// details. Every field costs one, or its fieldCosts entry, and the cost of the
+// fields under a list is multiplied by the list size. Syntax errors are left
+// for the executor to report.
+func CheckLimits(query string, operationName string, variables map[string]any) error {
+	document, err := parser.Parse(parser.ParseParams{Source: query})
+	if err != nil {
+		return nil
+	}
+
+	analyzer := limitsAnalyzer{fragments: map[string]*ast.FragmentDefinition{}, variables: variables}
+	var operations []*ast.OperationDefinition
+	for _, definition := range document.Definitions {
+		switch definition := definition.(type) {
+		case *ast.FragmentDefinition:
+			analyzer.fragments[definition.Name.Value] = definition
+		case *ast.OperationDefinition:
+			if operationName == "" || (definition.Name != nil && definition.Name.Value == operationName) {
+				operations = append(operations, definition)
+			}
+		}
+	}
+
+	for _, operation := range operations {
+		cost := analyzer.selectionSet(operation.SelectionSet, map[string]bool{})
+		if cost.depth > MaxDepth {
+			return fmt.Errorf("query depth %d exceeds the maximum of %d", cost.depth, MaxDepth)
+		}
+
+		if cost.complexity > MaxComplexity {
+			return fmt.Errorf("query complexity %d exceeds the maximum of %d", cost.complexity, MaxComplexity)
+		}
+
+		if cost.stockDetails > MaxStockDetails {
+			return fmt.Errorf("query asks for %d stock details, more than the maximum of %d", cost.stockDetails, MaxStockDetails)
+		}
+	}
+
+	return nil
+}
+
+// queryCost is the depth, complexity and number of stock details of a
+// selection set.
+type queryCost struct {
+	depth        int
+	complexity   int
+	stockDetails int
+}
+
+type limitsAnalyzer struct {
+	fragments map[string]*ast.FragmentDefinition
+	variables map[string]any
+}
+
+// selectionSet returns the cost of a selection set. visiting holds the
+// fragments being expanded so cyclic fragments do not recurse forever; the
+// validator rejects them anyway.
+func (a limitsAnalyzer) selectionSet(selectionSet *ast.SelectionSet, visiting map[string]bool) queryCost {
+	if selectionSet == nil {
+		return queryCost{}
+	}
+
+	var total queryCost
+	for _, selection := range selectionSet.Selections {
+		var cost queryCost
+
+		switch selection := selection.(type) {
+		case *ast.Field:
+			children := a.selectionSet(selection.SelectionSet, visiting)
+			fieldCost, ok := fieldCosts[selection.Name.Value]
+			if !ok {
+				fieldCost = 1
+			}
+
+			size := a.listSize(selection)
+			cost = queryCost{
+				depth:        children.depth + 1,
+				complexity:   fieldCost + size*children.complexity,
+				stockDetails: size * children.stockDetails,
+			}
+			if stockDetailsFields[selection.Name.Value] {
+				cost.stockDetails++
+			}
+		case *ast.InlineFragment:
+			cost = a.selectionSet(selection.SelectionSet, visiting)
+		case *ast.FragmentSpread:
+			name := selection.Name.Value
+			fragment, ok := a.fragments[name]
+			if !ok || visiting[name] {
+				continue
+			}
+
+			visiting[name] = true
+			cost = a.selectionSet(fragment.SelectionSet, visiting)
+			delete(visiting, name)
+		}
+
+		total.depth = max(total.depth, cost.depth)
+		total.complexity += cost.complexity
+		total.stockDetails += cost.stockDetails
+	}
+
+	return total
+}
+
+// listSize returns the size of a list field clamped to the sizes the resolvers
+// accept, so an out of range size cannot lower the estimated cost; the
+// resolvers reject it anyway.
+func (a limitsAnalyzer) listSize(field *ast.Field) int {
+	sizeArgument, ok := listSizeArguments[field.Name.Value]
+	if !ok {
+		return 1
+	}
+
+	for _, argument := range field.Arguments {
+		if argument.Name.Value != sizeArgument.argument {
+			continue
+		}
+
+		switch value := argument.Value.(type) {
+		case *ast.IntValue:
+			if size, err := strconv.Atoi(value.Value); err == nil {
+				return min(max(size, 1), sizeArgument.maxSize)
+			}
+		case *ast.Variable:
+			if size, ok := a.variables[value.Name.Value].(float64); ok {
+				return min(max(int(size), 1), sizeArgument.maxSize)
+			}
+		}
+	}
+
+	return sizeArgument.defaultSize
+}
@@ -0,0 +1,81 @@
+package graphql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckLimits(t *testing.T) {
+	testCases := []struct {
+		name        string
+		query       string
+		variables   map[string]any
+		expectError bool
+	}{
+		{
+			name:  "Home Screen",
+			query: `{ stockRecommendations(pageSize: 5) { ticker ratings(limit: 3) { brokerage } details { keyFacts } } }`,
+		},
+		{
+			name:        "Too Complex",
+			query:       `{ stockRecommendations(pageSize: 100) { ticker details { keyFacts } } }`,
+			expectError: true,
+		},
+		{
+			name:        "Too Complex Through Variables",
+			query:       `query Home($size: Int) { stockRecommendations(pageSize: $size) { ratings(limit: 50) { ticker brokerage } } }`,
+			variables:   map[string]any{"size": float64(100)},
+			expectError: true,
+		},
+		{
+			name:        "Negative Size Does Not Offset The Cost",
+			query:       `{ a: stockRecommendations(pageSize: -1000) { ticker } b: stockRecommendations(pageSize: 30) { ratings(limit: 50) { ticker } } }`,
+			expectError: true,
+		},
+		{
+			name:        "Negative Size Through Variables Does Not Offset The Cost",
+			query:       `query Home($size: Int) { a: stockRecommendations(pageSize: $size) { ticker } b: stockRecommendations(pageSize: 30) { ratings(limit: 50) { ticker } } }`,
+			variables:   map[string]any{"size": float64(-1000)},
+			expectError: true,
+		},
+		{
+			name: "Too Many Aliased Stock Details",
+			query: `{ a: stockDetails(ticker: "A") { keyFacts } b: stockDetails(ticker: "B") { keyFacts }
+				c: stockDetails(ticker: "C") { keyFacts } d: stockDetails(ticker: "D") { keyFacts }
+				e: stockDetails(ticker: "E") { keyFacts } f: stockDetails(ticker: "F") { keyFacts }
+				g: stockDetails(ticker: "G") { keyFacts } h: stockDetails(ticker: "H") { keyFacts }
+				i: stockDetails(ticker: "I") { keyFacts } j: stockDetails(ticker: "J") { keyFacts }
+				k: stockDetails(ticker: "K") { keyFacts } l: stockDetails(ticker: "L") { keyFacts }
+				m: stockDetails(ticker: "M") { keyFacts } n: stockDetails(ticker: "N") { keyFacts }
+				o: stockDetails(ticker: "O") { keyFacts } p: stockDetails(ticker: "P") { keyFacts }
+				q: stockDetails(ticker: "Q") { keyFacts } r: stockDetails(ticker: "R") { keyFacts }
+				s: stockDetails(ticker: "S") { keyFacts } t: stockDetails(ticker: "T") { keyFacts }
+				u: stockDetails(ticker: "U") { keyFacts } }`,
+			expectError: true,
+		},
+		{
+			name:        "Too Many Stock Details Under A List",
+			query:       `{ stockRecommendations(pageSize: 25) { ticker details { keyFacts } } }`,
+			expectError: true,
+		},
+		{
+			name: "Too Deep Through Fragments",
+			query: `{ stockRecommendations { details { ...Status } } }
+				fragment Status on StockDetails { status { quote { ...Section } } }
+				fragment Section on SectionStatus { status { a { b } } }`,
+			expectError: true,
+		},
+		{
+			name:  "Syntax Error Left To Executor",
+			query: `{ stockRatings {`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := CheckLimits(tc.query, "", tc.variables)
+			assert.Equal(t, tc.expectError, err != nil, err)
+		})
+	}
+}
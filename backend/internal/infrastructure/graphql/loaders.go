@@ -0,0 +1,118 @@
+package graphql
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/graph-gophers/dataloader/v7"
+	"github.com/rubenpad/srs/internal/domain/entity"
+	"github.com/rubenpad/srs/internal/domain/service"
+)
+
+const (
+	loaderWait = 2 * time.Millisecond
+	// detailsWorkers bounds the stock details fetched at once, each making
+	// three upstream calls.
+	detailsWorkers = 4
+)
+
+type loadersKey struct{}
+
+// ratingsKey asks for the limit most recent ratings of a ticker.
+type ratingsKey struct {
+	Ticker string
+	Limit  int
+}
+
+// loaders batch and deduplicate the lookups made while resolving one query.
+// They cache for the lifetime of the request only.
+type loaders struct {
+	ratings *dataloader.Loader[ratingsKey, []entity.StockRating]
+	details *dataloader.Loader[string, *entity.StockDetails]
+}
+
+// WithLoaders returns a context carrying fresh loaders for one request.
+func WithLoaders(ctx context.Context, stockRatingService *service.StockRatingService) context.Context {
+	return context.WithValue(ctx, loadersKey{}, newLoaders(stockRatingService))
+}
+
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
+
+func newLoaders(stockRatingService *service.StockRatingService) *loaders {
+	return &loaders{
+		ratings: dataloader.NewBatchedLoader(latestRatingsBatch(stockRatingService), dataloader.WithWait[ratingsKey, []entity.StockRating](loaderWait)),
+		details: dataloader.NewBatchedLoader(stockDetailsBatch(stockRatingService), dataloader.WithWait[string, *entity.StockDetails](loaderWait)),
+	}
+}
+
+// latestRatingsBatch loads the ratings of every requested ticker with a single
+// repository call, using the largest requested limit and trimming per key.
+func latestRatingsBatch(stockRatingService *service.StockRatingService) dataloader.BatchFunc[ratingsKey, []entity.StockRating] {
+	return func(ctx context.Context, keys []ratingsKey) []*dataloader.Result[[]entity.StockRating] {
+		tickers := make([]string, 0, len(keys))
+		limit := 0
+		for _, key := range keys {
+			if !slices.Contains(tickers, key.Ticker) {
+				tickers = append(tickers, key.Ticker)
+			}
+			limit = max(limit, key.Limit)
+		}
+
+		byTicker, err := stockRatingService.GetLatestStockRatings(ctx, tickers, limit)
+
+		results := make([]*dataloader.Result[[]entity.StockRating], len(keys))
+		for i, key := range keys {
+			if err != nil {
+				results[i] = &dataloader.Result[[]entity.StockRating]{Error: err}
+				continue
+			}
+
+			ratings := byTicker[key.Ticker]
+			if len(ratings) > key.Limit {
+				ratings = ratings[:key.Limit]
+			}
+
+			if ratings == nil {
+				ratings = []entity.StockRating{}
+			}
+
+			results[i] = &dataloader.Result[[]entity.StockRating]{Data: ratings}
+		}
+
+		return results
+	}
+}
+
+// stockDetailsBatch fetches the details of every requested ticker with
+// detailsWorkers workers. The upstream providers have no batch API, so the
+// loader's value is deduplication and bounded fan-out rather than fewer calls.
+func stockDetailsBatch(stockRatingService *service.StockRatingService) dataloader.BatchFunc[string, *entity.StockDetails] {
+	return func(ctx context.Context, tickers []string) []*dataloader.Result[*entity.StockDetails] {
+		results := make([]*dataloader.Result[*entity.StockDetails], len(tickers))
+
+		pending := make(chan int, len(tickers))
+		for i := range tickers {
+			pending <- i
+		}
+		close(pending)
+
+		var wg sync.WaitGroup
+		for range min(detailsWorkers, len(tickers)) {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := range pending {
+					details, err := stockRatingService.GetStockDetails(ctx, tickers[i])
+					results[i] = &dataloader.Result[*entity.StockDetails]{Data: details, Error: err}
+				}
+			}()
+		}
+
+		wg.Wait()
+		return results
+	}
+}
@@ -0,0 +1,245 @@
+package graphql
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/graphql-go/graphql"
+	"github.com/rubenpad/srs/internal/domain/entity"
+	"github.com/rubenpad/srs/internal/domain/service"
+)
+
+const (
+	defaultPageSize     = 10
+	maxPageSize         = 100
+	defaultRatingsLimit = 5
+	maxRatingsLimit     = 50
+)
+
+// Object fields are named after the JSON fields of the REST API so clients can
+// share types between both.
+
+var quoteType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "Quote",
+	Fields: graphql.Fields{
+		"current":       &graphql.Field{Type: graphql.NewNonNull(graphql.Float)},
+		"open":          &graphql.Field{Type: graphql.NewNonNull(graphql.Float)},
+		"high":          &graphql.Field{Type: graphql.NewNonNull(graphql.Float)},
+		"low":           &graphql.Field{Type: graphql.NewNonNull(graphql.Float)},
+		"previousClose": &graphql.Field{Type: graphql.NewNonNull(graphql.Float)},
+		"change":        &graphql.Field{Type: graphql.NewNonNull(graphql.Float)},
+		"percentChange": &graphql.Field{Type: graphql.NewNonNull(graphql.Float)},
+	},
+})
+
+var recommendationTrendType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "RecommendationTrend",
+	Fields: graphql.Fields{
+		"period":     &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		"strongBuy":  &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+		"buy":        &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+		"hold":       &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+		"sell":       &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+		"strongSell": &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+	},
+})
+
+var sectionStatusType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "SectionStatus",
+	Fields: graphql.Fields{
+		"status":  &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		"code":    &graphql.Field{Type: graphql.String},
+		"message": &graphql.Field{Type: graphql.String},
+	},
+})
+
+var stockDetailsStatusType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "StockDetailsStatus",
+	Fields: graphql.Fields{
+		"quote":           &graphql.Field{Type: graphql.NewNonNull(sectionStatusType)},
+		"recommendations": &graphql.Field{Type: graphql.NewNonNull(sectionStatusType)},
+		"keyFacts":        &graphql.Field{Type: graphql.NewNonNull(sectionStatusType)},
+	},
+})
+
+var stockDetailsType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "StockDetails",
+	Fields: graphql.Fields{
+		"keyFacts":        &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		"quote":           &graphql.Field{Type: quoteType},
+		"recommendations": &graphql.Field{Type: graphql.NewList(graphql.NewNonNull(recommendationTrendType))},
+		"status":          &graphql.Field{Type: graphql.NewNonNull(stockDetailsStatusType)},
+	},
+})
+
+var stockRatingType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "StockRating",
+	Fields: graphql.Fields{
+		"brokerage":           &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		"action":              &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		"company":             &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		"ticker":              &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		"rating_from":         &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		"rating_to":           &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		"target_from":         &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		"target_to":           &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		"target_from_value":   &graphql.Field{Type: graphql.Float},
+		"target_to_value":     &graphql.Field{Type: graphql.Float},
+		"currency":            &graphql.Field{Type: graphql.String},
+		"time":                &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+		"target_price_change": &graphql.Field{Type: graphql.NewNonNull(graphql.Float)},
+		"score":               &graphql.Field{Type: graphql.NewNonNull(graphql.Float)},
+		"implied_upside":      &graphql.Field{Type: graphql.Float},
+	},
+})
+
+var stockRatingPageType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "StockRatingPage",
+	Fields: graphql.Fields{
+		"data":     &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(stockRatingType)))},
+		"nextPage": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+	},
+})
+
+// NewSchema builds the schema over the stock rating service. Nested ratings
+// and details are resolved through the request loaders set by WithLoaders.
+func NewSchema(stockRatingService *service.StockRatingService) (graphql.Schema, error) {
+	stockRatingAggregateType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "StockRatingAggregate",
+		Fields: graphql.Fields{
+			"ticker":                  &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"time":                    &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+			"strong_buy_ratings":      &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"buy_ratings":             &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"hold_ratings":            &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"sell_ratings":            &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"rating":                  &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"target_price_change":     &graphql.Field{Type: graphql.NewNonNull(graphql.Float)},
+			"score":                   &graphql.Field{Type: graphql.NewNonNull(graphql.Float)},
+			"current_price":           &graphql.Field{Type: graphql.Float},
+			"consensus_target_median": &graphql.Field{Type: graphql.Float},
+			"consensus_target_mean":   &graphql.Field{Type: graphql.Float},
+			"consensus_target_high":   &graphql.Field{Type: graphql.Float},
+			"consensus_target_low":    &graphql.Field{Type: graphql.Float},
+			"implied_upside":          &graphql.Field{Type: graphql.Float},
+			"ratings": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(stockRatingType))),
+				Description: "The most recent ratings of the ticker, newest first.",
+				Args: graphql.FieldConfigArgument{
+					"limit": &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: defaultRatingsLimit},
+				},
+				Resolve: resolveAggregateRatings,
+			},
+			"details": &graphql.Field{
+				Type:    stockDetailsType,
+				Resolve: resolveAggregateDetails,
+			},
+		},
+	})
+
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"stockRatings": &graphql.Field{
+				Type: graphql.NewNonNull(stockRatingPageType),
+				Args: graphql.FieldConfigArgument{
+					"search":   &graphql.ArgumentConfig{Type: graphql.String, DefaultValue: ""},
+					"nextPage": &graphql.ArgumentConfig{Type: graphql.String, DefaultValue: ""},
+					"pageSize": &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: defaultPageSize},
+				},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					pageSize, err := intArgument(p.Args, "pageSize", maxPageSize)
+					if err != nil {
+						return nil, err
+					}
+
+					after, err := entity.ParseStockRatingCursor(p.Args["nextPage"].(string))
+					if err != nil {
+						return nil, fmt.Errorf("nextPage: %w", err)
+					}
+
+					return stockRatingService.GetStockRatings(p.Context, after, pageSize, p.Args["search"].(string))
+				},
+			},
+			"stockRecommendations": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(stockRatingAggregateType))),
+				Args: graphql.FieldConfigArgument{
+					"pageSize": &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: defaultPageSize},
+					"sort":     &graphql.ArgumentConfig{Type: graphql.String, DefaultValue: entity.RecommendationSortDefault},
+					"sector":   &graphql.ArgumentConfig{Type: graphql.String, DefaultValue: ""},
+				},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					pageSize, err := intArgument(p.Args, "pageSize", maxPageSize)
+					if err != nil {
+						return nil, err
+					}
+
+					sortBy := p.Args["sort"].(string)
+					if sortBy != entity.RecommendationSortDefault && sortBy != entity.RecommendationSortUpside {
+						return nil, fmt.Errorf("sort must be empty or %q", entity.RecommendationSortUpside)
+					}
+
+					recommendations, err := stockRatingService.GetStockRecommendations(p.Context, pageSize, sortBy, p.Args["sector"].(string))
+					if err != nil {
+						return nil, err
+					}
+
+					return recommendations.Data, nil
+				},
+			},
+			"stockDetails": &graphql.Field{
+				Type: stockDetailsType,
+				Args: graphql.FieldConfigArgument{
+					"ticker": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+				},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return loadStockDetails(p, p.Args["ticker"].(string))
+				},
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{Query: query})
+}
+
+func resolveAggregateRatings(p graphql.ResolveParams) (any, error) {
+	limit, err := intArgument(p.Args, "limit", maxRatingsLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	aggregate := p.Source.(entity.StockRatingAggregate)
+	thunk := loadersFrom(p.Context).ratings.Load(p.Context, ratingsKey{Ticker: aggregate.Ticker, Limit: limit})
+
+	return func() (any, error) {
+		return thunk()
+	}, nil
+}
+
+func resolveAggregateDetails(p graphql.ResolveParams) (any, error) {
+	return loadStockDetails(p, p.Source.(entity.StockRatingAggregate).Ticker)
+}
+
+// loadStockDetails resolves to the details, possibly partial, unless the
+// stock does not exist or no section could be loaded.
+func loadStockDetails(p graphql.ResolveParams, ticker string) (any, error) {
+	thunk := loadersFrom(p.Context).details.Load(p.Context, ticker)
+
+	return func() (any, error) {
+		details, err := thunk()
+		if errors.Is(err, entity.ErrStockNotFound) || details == nil {
+			return nil, err
+		}
+
+		return details, nil
+	}, nil
+}
+
+func intArgument(args map[string]any, name string, maxValue int) (int, error) {
+	value := args[name].(int)
+	if value < 1 || value > maxValue {
+		return 0, fmt.Errorf("%s must be between 1 and %d", name, maxValue)
+	}
+
+	return value, nil
+}
@@ -0,0 +1,85 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"sync/atomic"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/rubenpad/srs/internal/domain/entity"
+	"github.com/rubenpad/srs/internal/domain/service"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeStockRatingRepository struct {
+	entity.IStockRatingRepository
+	latestCalls atomic.Int32
+}
+
+func (f *fakeStockRatingRepository) GetStockRecommendations(ctx context.Context, pageSize int, sortBy string, sector string) ([]entity.StockRatingAggregate, error) {
+	return []entity.StockRatingAggregate{{Ticker: "AAPL"}, {Ticker: "MSFT"}, {Ticker: "AAPL"}}, nil
+}
+
+func (f *fakeStockRatingRepository) GetLatestStockRatings(ctx context.Context, tickers []string, limit int) ([]entity.StockRating, error) {
+	f.latestCalls.Add(1)
+
+	ratings := []entity.StockRating{}
+	for _, ticker := range tickers {
+		for _, brokerage := range []string{"Barclays", "Citigroup", "Mizuho"}[:limit] {
+			ratings = append(ratings, entity.StockRating{Ticker: ticker, Brokerage: brokerage})
+		}
+	}
+
+	return ratings, nil
+}
+
+type fakeStockRatingApi struct {
+	entity.IStockRatingApi
+	detailsCalls atomic.Int32
+}
+
+func (f *fakeStockRatingApi) GetStockDetails(ctx context.Context, ticker string) (*entity.StockDetails, error) {
+	f.detailsCalls.Add(1)
+	return &entity.StockDetails{KeyFacts: ticker + " facts"}, nil
+}
+
+func TestSchemaBatchesNestedLookups(t *testing.T) {
+	repository := &fakeStockRatingRepository{}
+	stockRatingApi := &fakeStockRatingApi{}
+	stockRatingService := service.NewStockRatingService(repository, stockRatingApi, nil, nil)
+
+	schema, err := NewSchema(stockRatingService)
+	assert.NoError(t, err)
+
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ stockRecommendations { ticker ratings(limit: 2) { brokerage } details { keyFacts } } }`,
+		Context:       WithLoaders(context.Background(), stockRatingService),
+	})
+
+	assert.Empty(t, result.Errors)
+	assert.Equal(t, int32(1), repository.latestCalls.Load())
+	assert.Equal(t, int32(2), stockRatingApi.detailsCalls.Load())
+
+	body, _ := json.Marshal(result.Data)
+	assert.JSONEq(t, `{"stockRecommendations": [
+		{"ticker": "AAPL", "ratings": [{"brokerage": "Barclays"}, {"brokerage": "Citigroup"}], "details": {"keyFacts": "AAPL facts"}},
+		{"ticker": "MSFT", "ratings": [{"brokerage": "Barclays"}, {"brokerage": "Citigroup"}], "details": {"keyFacts": "MSFT facts"}},
+		{"ticker": "AAPL", "ratings": [{"brokerage": "Barclays"}, {"brokerage": "Citigroup"}], "details": {"keyFacts": "AAPL facts"}}
+	]}`, string(body))
+}
+
+func TestSchemaValidatesPageSize(t *testing.T) {
+	stockRatingService := service.NewStockRatingService(&fakeStockRatingRepository{}, &fakeStockRatingApi{}, nil, nil)
+	schema, err := NewSchema(stockRatingService)
+	assert.NoError(t, err)
+
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ stockRecommendations(pageSize: 0) { ticker } }`,
+		Context:       WithLoaders(context.Background(), stockRatingService),
+	})
+
+	assert.Len(t, result.Errors, 1)
+}
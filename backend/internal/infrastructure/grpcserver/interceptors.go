@@ -0,0 +1,69 @@
+package grpcserver
+
+import (
+	"context"
+	"log/slog"
+	"runtime/debug"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// logUnary logs every unary call with its code and duration, as the REST
+// handlers are logged by the gin logger.
+func logUnary(ctx context.Context, request any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	start := time.Now()
+	response, err := handler(ctx, request)
+	logCall(info.FullMethod, start, err)
+
+	return response, err
+}
+
+func logStream(server any, serverStream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	err := handler(server, serverStream)
+	logCall(info.FullMethod, start, err)
+
+	return err
+}
+
+func logCall(method string, start time.Time, err error) {
+	code := status.Code(err)
+	attributes := []any{"method", method, "code", code.String(), "duration", time.Since(start)}
+
+	switch code {
+	case codes.OK, codes.Canceled, codes.InvalidArgument, codes.NotFound:
+		slog.Info("grpc call", attributes...)
+	default:
+		slog.Error("grpc call", append(attributes, "error", err)...)
+	}
+}
+
+// recoverUnary turns a panicking handler into an Internal error instead of
+// crashing the process with every other call in flight.
+func recoverUnary(ctx context.Context, request any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (response any, err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = panicError(info.FullMethod, recovered)
+		}
+	}()
+
+	return handler(ctx, request)
+}
+
+func recoverStream(server any, serverStream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = panicError(info.FullMethod, recovered)
+		}
+	}()
+
+	return handler(server, serverStream)
+}
+
+func panicError(method string, recovered any) error {
+	slog.Error("panic handling a grpc call", "method", method, "panic", recovered, "stack", string(debug.Stack()))
+	return status.Error(codes.Internal, "error processing the request")
+}
@@ -0,0 +1,91 @@
+package grpcserver
+
+import (
+	"github.com/rubenpad/srs/internal/domain/entity"
+	"github.com/rubenpad/srs/internal/infrastructure/grpcserver/srsv1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func toStockRating(rating entity.StockRating) *srsv1.StockRating {
+	return &srsv1.StockRating{
+		Brokerage:         rating.Brokerage,
+		Action:            rating.Action,
+		Company:           rating.Company,
+		Ticker:            rating.Ticker,
+		RatingFrom:        rating.RatingFrom,
+		RatingTo:          rating.RatingTo,
+		TargetFrom:        rating.TargetFrom,
+		TargetTo:          rating.TargetTo,
+		TargetFromValue:   rating.TargetFromValue,
+		TargetToValue:     rating.TargetToValue,
+		Currency:          rating.Currency,
+		Time:              timestamppb.New(rating.Time),
+		TargetPriceChange: rating.TargetPriceChange,
+		Score:             rating.Score,
+		ImpliedUpside:     rating.ImpliedUpside,
+	}
+}
+
+func toStockRatingAggregate(aggregate entity.StockRatingAggregate) *srsv1.StockRatingAggregate {
+	return &srsv1.StockRatingAggregate{
+		Ticker:                aggregate.Ticker,
+		Time:                  timestamppb.New(aggregate.Time),
+		StrongBuyRatings:      int32(aggregate.StrongBuyRatings),
+		BuyRatings:            int32(aggregate.BuyRatings),
+		HoldRatings:           int32(aggregate.HoldRatings),
+		SellRatings:           int32(aggregate.SellRatings),
+		Rating:                aggregate.Rating,
+		TargetPriceChange:     aggregate.TargetPriceChange,
+		Score:                 aggregate.Score,
+		CurrentPrice:          aggregate.CurrentPrice,
+		ConsensusTargetMedian: aggregate.ConsensusTargetMedian,
+		ConsensusTargetMean:   aggregate.ConsensusTargetMean,
+		ConsensusTargetHigh:   aggregate.ConsensusTargetHigh,
+		ConsensusTargetLow:    aggregate.ConsensusTargetLow,
+		ImpliedUpside:         aggregate.ImpliedUpside,
+	}
+}
+
+func toStockDetails(details *entity.StockDetails) *srsv1.StockDetails {
+	response := &srsv1.StockDetails{
+		KeyFacts: details.KeyFacts,
+		Status: &srsv1.StockDetailsStatus{
+			Quote:           toSectionStatus(details.Status.Quote),
+			Recommendations: toSectionStatus(details.Status.Recommendations),
+			KeyFacts:        toSectionStatus(details.Status.KeyFacts),
+		},
+	}
+
+	if details.Quote != nil {
+		response.Quote = &srsv1.Quote{
+			Current:       details.Quote.Current,
+			Open:          details.Quote.Open,
+			High:          details.Quote.High,
+			Low:           details.Quote.Low,
+			PreviousClose: details.Quote.PreviousClose,
+			Change:        details.Quote.Change,
+			PercentChange: details.Quote.PercentChange,
+		}
+	}
+
+	for _, trend := range details.Recommendations {
+		response.Recommendations = append(response.Recommendations, &srsv1.RecommendationTrend{
+			Period:     trend.Period,
+			StrongBuy:  trend.StrongBuy,
+			Buy:        trend.Buy,
+			Hold:       trend.Hold,
+			Sell:       trend.Sell,
+			StrongSell: trend.StrongSell,
+		})
+	}
+
+	return response
+}
+
+func toSectionStatus(section entity.SectionStatus) *srsv1.SectionStatus {
+	return &srsv1.SectionStatus{
+		Status:  section.Status,
+		Code:    section.Code,
+		Message: section.Message,
+	}
+}
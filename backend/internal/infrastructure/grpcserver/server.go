@@ -0,0 +1,164 @@
+package grpcserver
+
+//go:generate protoc -I ../../../proto --go_out=. --go_opt=module=github.com/rubenpad/srs/internal/infrastructure/grpcserver --go-grpc_out=. --go-grpc_opt=module=github.com/rubenpad/srs/internal/infrastructure/grpcserver srs/v1/stock_rating.proto
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/rubenpad/srs/internal/domain/entity"
+	"github.com/rubenpad/srs/internal/domain/service"
+	"github.com/rubenpad/srs/internal/infrastructure/grpcserver/srsv1"
+	"github.com/rubenpad/srs/internal/infrastructure/stream"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultPageSize = 10
+	maxPageSize     = 100
+)
+
+type StockRatingServer struct {
+	srsv1.UnimplementedStockRatingServiceServer
+	stockRatingService *service.StockRatingService
+	hub                *stream.Hub
+}
+
+// New returns a gRPC server exposing the stock rating service. New ratings are
+// streamed from hub. Calls are traced, logged and recovered from panics.
+func New(stockRatingService *service.StockRatingService, hub *stream.Hub) *grpc.Server {
+	server := grpc.NewServer(
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.ChainUnaryInterceptor(logUnary, recoverUnary),
+		grpc.ChainStreamInterceptor(logStream, recoverStream),
+	)
+	srsv1.RegisterStockRatingServiceServer(server, &StockRatingServer{
+		stockRatingService: stockRatingService,
+		hub:                hub,
+	})
+
+	return server
+}
+
+func (s *StockRatingServer) ListStockRatings(ctx context.Context, request *srsv1.ListStockRatingsRequest) (*srsv1.ListStockRatingsResponse, error) {
+	pageSize, err := pageSize(request.GetPageSize())
+	if err != nil {
+		return nil, err
+	}
+
+	after, err := entity.ParseStockRatingCursor(request.GetNextPage())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "next_page is not a valid cursor")
+	}
+
+	stockRatings, err := s.stockRatingService.GetStockRatings(ctx, after, pageSize, request.GetSearch())
+	if err != nil {
+		return nil, internalError(err)
+	}
+
+	response := &srsv1.ListStockRatingsResponse{NextPage: stockRatings.NextPage}
+	for _, rating := range stockRatings.Data {
+		response.Data = append(response.Data, toStockRating(rating))
+	}
+
+	return response, nil
+}
+
+func (s *StockRatingServer) GetStockRecommendations(ctx context.Context, request *srsv1.GetStockRecommendationsRequest) (*srsv1.GetStockRecommendationsResponse, error) {
+	pageSize, err := pageSize(request.GetPageSize())
+	if err != nil {
+		return nil, err
+	}
+
+	sortBy := request.GetSort()
+	if sortBy != entity.RecommendationSortDefault && sortBy != entity.RecommendationSortUpside {
+		return nil, status.Errorf(codes.InvalidArgument, "sort must be empty or %q", entity.RecommendationSortUpside)
+	}
+
+	recommendations, err := s.stockRatingService.GetStockRecommendations(ctx, pageSize, sortBy, request.GetSector())
+	if err != nil {
+		return nil, internalError(err)
+	}
+
+	response := &srsv1.GetStockRecommendationsResponse{}
+	for _, aggregate := range recommendations.Data {
+		response.Data = append(response.Data, toStockRatingAggregate(aggregate))
+	}
+
+	return response, nil
+}
+
+// GetStockDetails answers with the partial details and an OK status when only
+// some sections failed, like the REST handler does with its status field.
+func (s *StockRatingServer) GetStockDetails(ctx context.Context, request *srsv1.GetStockDetailsRequest) (*srsv1.StockDetails, error) {
+	if request.GetTicker() == "" {
+		return nil, status.Error(codes.InvalidArgument, "ticker is required")
+	}
+
+	stockDetails, err := s.stockRatingService.GetStockDetails(ctx, request.GetTicker())
+
+	switch {
+	case errors.Is(err, entity.ErrStockNotFound):
+		return nil, status.Error(codes.NotFound, "stock details not found")
+	case errors.Is(err, entity.ErrUpstreamTimeout):
+		return nil, status.Error(codes.DeadlineExceeded, "stock details providers did not respond in time")
+	case err != nil:
+		slog.Error(err.Error(), "ticker", request.GetTicker())
+		return nil, status.Error(codes.Unavailable, "stock details providers failed")
+	}
+
+	return toStockDetails(stockDetails), nil
+}
+
+func (s *StockRatingServer) LoadStockRatings(ctx context.Context, request *srsv1.LoadStockRatingsRequest) (*srsv1.LoadStockRatingsResponse, error) {
+	// The load outlives the call, like the REST handler's.
+	go s.stockRatingService.LoadStockRatingsData(context.WithoutCancel(ctx), request.GetUseCustomFormat())
+
+	return &srsv1.LoadStockRatingsResponse{}, nil
+}
+
+func (s *StockRatingServer) StreamStockRatings(request *srsv1.StreamStockRatingsRequest, server grpc.ServerStreamingServer[srsv1.StockRating]) error {
+	subscription := s.hub.Subscribe(request.GetTickers())
+	defer s.hub.Unsubscribe(subscription)
+
+	for {
+		select {
+		case <-server.Context().Done():
+			return nil
+		case event, open := <-subscription.Events:
+			if !open {
+				return nil
+			}
+
+			rating, ok := event.Data.(entity.StockRating)
+			if !ok {
+				continue
+			}
+
+			if err := server.Send(toStockRating(rating)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func pageSize(requested int32) (int, error) {
+	if requested == 0 {
+		return defaultPageSize, nil
+	}
+
+	if requested < 1 || requested > maxPageSize {
+		return 0, status.Errorf(codes.InvalidArgument, "page_size must be between 1 and %d", maxPageSize)
+	}
+
+	return int(requested), nil
+}
+
+func internalError(err error) error {
+	slog.Error(err.Error())
+	return status.Error(codes.Internal, "error processing the request")
+}
@@ -0,0 +1,97 @@
+package grpcserver
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/rubenpad/srs/internal/domain/entity"
+	"github.com/rubenpad/srs/internal/infrastructure/grpcserver/srsv1"
+	"github.com/rubenpad/srs/internal/infrastructure/stream"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestPageSize(t *testing.T) {
+	testCases := []struct {
+		name     string
+		value    int32
+		expected int
+		code     codes.Code
+	}{
+		{name: "Default", value: 0, expected: defaultPageSize, code: codes.OK},
+		{name: "Valid", value: 25, expected: 25, code: codes.OK},
+		{name: "Negative", value: -1, code: codes.InvalidArgument},
+		{name: "Too Large", value: maxPageSize + 1, code: codes.InvalidArgument},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			size, err := pageSize(tc.value)
+			assert.Equal(t, tc.expected, size)
+			assert.Equal(t, tc.code, status.Code(err))
+		})
+	}
+}
+
+// dialTestServer serves New(nil, hub) over an in-memory listener and returns a
+// client connected to it.
+func dialTestServer(t *testing.T, hub *stream.Hub) *grpc.ClientConn {
+	listener := bufconn.Listen(1024 * 1024)
+	server := New(nil, hub)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	connection, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { connection.Close() })
+
+	return connection
+}
+
+func TestPanicIsRecovered(t *testing.T) {
+	// Without a stock rating service the handler panics.
+	client := srsv1.NewStockRatingServiceClient(dialTestServer(t, stream.NewHub()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	for range 2 {
+		_, err := client.ListStockRatings(ctx, &srsv1.ListStockRatingsRequest{})
+		assert.Equal(t, codes.Internal, status.Code(err))
+	}
+}
+
+func TestStreamStockRatings(t *testing.T) {
+	hub := stream.NewHub()
+	connection := dialTestServer(t, hub)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	ratings, err := srsv1.NewStockRatingServiceClient(connection).StreamStockRatings(ctx, &srsv1.StreamStockRatingsRequest{Tickers: []string{"AAPL"}})
+	require.NoError(t, err)
+
+	// Publish until the subscription is registered, the stream has no
+	// handshake to wait for.
+	go func() {
+		for ctx.Err() == nil {
+			hub.StockRatingSaved(ctx, entity.StockRating{Ticker: "MSFT", Brokerage: "Barclays"})
+			hub.StockRatingSaved(ctx, entity.StockRating{Ticker: "AAPL", Brokerage: "Mizuho"})
+			time.Sleep(10 * time.Millisecond)
+		}
+	}()
+
+	rating, err := ratings.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, "AAPL", rating.GetTicker())
+	assert.Equal(t, "Mizuho", rating.GetBrokerage())
+}
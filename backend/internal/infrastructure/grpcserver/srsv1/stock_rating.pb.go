@@ -0,0 +1,1280 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: srs/v1/stock_rating.proto
+
+package srsv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type StockRating struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Brokerage         string                 `protobuf:"bytes,1,opt,name=brokerage,proto3" json:"brokerage,omitempty"`
+	Action            string                 `protobuf:"bytes,2,opt,name=action,proto3" json:"action,omitempty"`
+	Company           string                 `protobuf:"bytes,3,opt,name=company,proto3" json:"company,omitempty"`
+	Ticker            string                 `protobuf:"bytes,4,opt,name=ticker,proto3" json:"ticker,omitempty"`
+	RatingFrom        string                 `protobuf:"bytes,5,opt,name=rating_from,json=ratingFrom,proto3" json:"rating_from,omitempty"`
+	RatingTo          string                 `protobuf:"bytes,6,opt,name=rating_to,json=ratingTo,proto3" json:"rating_to,omitempty"`
+	TargetFrom        string                 `protobuf:"bytes,7,opt,name=target_from,json=targetFrom,proto3" json:"target_from,omitempty"`
+	TargetTo          string                 `protobuf:"bytes,8,opt,name=target_to,json=targetTo,proto3" json:"target_to,omitempty"`
+	TargetFromValue   *float64               `protobuf:"fixed64,9,opt,name=target_from_value,json=targetFromValue,proto3,oneof" json:"target_from_value,omitempty"`
+	TargetToValue     *float64               `protobuf:"fixed64,10,opt,name=target_to_value,json=targetToValue,proto3,oneof" json:"target_to_value,omitempty"`
+	Currency          *string                `protobuf:"bytes,11,opt,name=currency,proto3,oneof" json:"currency,omitempty"`
+	Time              *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=time,proto3" json:"time,omitempty"`
+	TargetPriceChange float64                `protobuf:"fixed64,13,opt,name=target_price_change,json=targetPriceChange,proto3" json:"target_price_change,omitempty"`
+	Score             float32                `protobuf:"fixed32,14,opt,name=score,proto3" json:"score,omitempty"`
+	ImpliedUpside     *float64               `protobuf:"fixed64,15,opt,name=implied_upside,json=impliedUpside,proto3,oneof" json:"implied_upside,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *StockRating) Reset() {
+	*x = StockRating{}
+	mi := &file_srs_v1_stock_rating_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StockRating) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockRating) ProtoMessage() {}
+
+func (x *StockRating) ProtoReflect() protoreflect.Message {
+	mi := &file_srs_v1_stock_rating_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockRating.ProtoReflect.Descriptor instead.
+func (*StockRating) Descriptor() ([]byte, []int) {
+	return file_srs_v1_stock_rating_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *StockRating) GetBrokerage() string {
+	if x != nil {
+		return x.Brokerage
+	}
+	return ""
+}
+
+func (x *StockRating) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *StockRating) GetCompany() string {
+	if x != nil {
+		return x.Company
+	}
+	return ""
+}
+
+func (x *StockRating) GetTicker() string {
+	if x != nil {
+		return x.Ticker
+	}
+	return ""
+}
+
+func (x *StockRating) GetRatingFrom() string {
+	if x != nil {
+		return x.RatingFrom
+	}
+	return ""
+}
+
+func (x *StockRating) GetRatingTo() string {
+	if x != nil {
+		return x.RatingTo
+	}
+	return ""
+}
+
+func (x *StockRating) GetTargetFrom() string {
+	if x != nil {
+		return x.TargetFrom
+	}
+	return ""
+}
+
+func (x *StockRating) GetTargetTo() string {
+	if x != nil {
+		return x.TargetTo
+	}
+	return ""
+}
+
+func (x *StockRating) GetTargetFromValue() float64 {
+	if x != nil && x.TargetFromValue != nil {
+		return *x.TargetFromValue
+	}
+	return 0
+}
+
+func (x *StockRating) GetTargetToValue() float64 {
+	if x != nil && x.TargetToValue != nil {
+		return *x.TargetToValue
+	}
+	return 0
+}
+
+func (x *StockRating) GetCurrency() string {
+	if x != nil && x.Currency != nil {
+		return *x.Currency
+	}
+	return ""
+}
+
+func (x *StockRating) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *StockRating) GetTargetPriceChange() float64 {
+	if x != nil {
+		return x.TargetPriceChange
+	}
+	return 0
+}
+
+func (x *StockRating) GetScore() float32 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *StockRating) GetImpliedUpside() float64 {
+	if x != nil && x.ImpliedUpside != nil {
+		return *x.ImpliedUpside
+	}
+	return 0
+}
+
+type StockRatingAggregate struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	Ticker                string                 `protobuf:"bytes,1,opt,name=ticker,proto3" json:"ticker,omitempty"`
+	Time                  *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=time,proto3" json:"time,omitempty"`
+	StrongBuyRatings      int32                  `protobuf:"varint,3,opt,name=strong_buy_ratings,json=strongBuyRatings,proto3" json:"strong_buy_ratings,omitempty"`
+	BuyRatings            int32                  `protobuf:"varint,4,opt,name=buy_ratings,json=buyRatings,proto3" json:"buy_ratings,omitempty"`
+	HoldRatings           int32                  `protobuf:"varint,5,opt,name=hold_ratings,json=holdRatings,proto3" json:"hold_ratings,omitempty"`
+	SellRatings           int32                  `protobuf:"varint,6,opt,name=sell_ratings,json=sellRatings,proto3" json:"sell_ratings,omitempty"`
+	Rating                string                 `protobuf:"bytes,7,opt,name=rating,proto3" json:"rating,omitempty"`
+	TargetPriceChange     float64                `protobuf:"fixed64,8,opt,name=target_price_change,json=targetPriceChange,proto3" json:"target_price_change,omitempty"`
+	Score                 float32                `protobuf:"fixed32,9,opt,name=score,proto3" json:"score,omitempty"`
+	CurrentPrice          *float64               `protobuf:"fixed64,10,opt,name=current_price,json=currentPrice,proto3,oneof" json:"current_price,omitempty"`
+	ConsensusTargetMedian *float64               `protobuf:"fixed64,11,opt,name=consensus_target_median,json=consensusTargetMedian,proto3,oneof" json:"consensus_target_median,omitempty"`
+	ConsensusTargetMean   *float64               `protobuf:"fixed64,12,opt,name=consensus_target_mean,json=consensusTargetMean,proto3,oneof" json:"consensus_target_mean,omitempty"`
+	ConsensusTargetHigh   *float64               `protobuf:"fixed64,13,opt,name=consensus_target_high,json=consensusTargetHigh,proto3,oneof" json:"consensus_target_high,omitempty"`
+	ConsensusTargetLow    *float64               `protobuf:"fixed64,14,opt,name=consensus_target_low,json=consensusTargetLow,proto3,oneof" json:"consensus_target_low,omitempty"`
+	ImpliedUpside         *float64               `protobuf:"fixed64,15,opt,name=implied_upside,json=impliedUpside,proto3,oneof" json:"implied_upside,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *StockRatingAggregate) Reset() {
+	*x = StockRatingAggregate{}
+	mi := &file_srs_v1_stock_rating_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StockRatingAggregate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockRatingAggregate) ProtoMessage() {}
+
+func (x *StockRatingAggregate) ProtoReflect() protoreflect.Message {
+	mi := &file_srs_v1_stock_rating_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockRatingAggregate.ProtoReflect.Descriptor instead.
+func (*StockRatingAggregate) Descriptor() ([]byte, []int) {
+	return file_srs_v1_stock_rating_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *StockRatingAggregate) GetTicker() string {
+	if x != nil {
+		return x.Ticker
+	}
+	return ""
+}
+
+func (x *StockRatingAggregate) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *StockRatingAggregate) GetStrongBuyRatings() int32 {
+	if x != nil {
+		return x.StrongBuyRatings
+	}
+	return 0
+}
+
+func (x *StockRatingAggregate) GetBuyRatings() int32 {
+	if x != nil {
+		return x.BuyRatings
+	}
+	return 0
+}
+
+func (x *StockRatingAggregate) GetHoldRatings() int32 {
+	if x != nil {
+		return x.HoldRatings
+	}
+	return 0
+}
+
+func (x *StockRatingAggregate) GetSellRatings() int32 {
+	if x != nil {
+		return x.SellRatings
+	}
+	return 0
+}
+
+func (x *StockRatingAggregate) GetRating() string {
+	if x != nil {
+		return x.Rating
+	}
+	return ""
+}
+
+func (x *StockRatingAggregate) GetTargetPriceChange() float64 {
+	if x != nil {
+		return x.TargetPriceChange
+	}
+	return 0
+}
+
+func (x *StockRatingAggregate) GetScore() float32 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *StockRatingAggregate) GetCurrentPrice() float64 {
+	if x != nil && x.CurrentPrice != nil {
+		return *x.CurrentPrice
+	}
+	return 0
+}
+
+func (x *StockRatingAggregate) GetConsensusTargetMedian() float64 {
+	if x != nil && x.ConsensusTargetMedian != nil {
+		return *x.ConsensusTargetMedian
+	}
+	return 0
+}
+
+func (x *StockRatingAggregate) GetConsensusTargetMean() float64 {
+	if x != nil && x.ConsensusTargetMean != nil {
+		return *x.ConsensusTargetMean
+	}
+	return 0
+}
+
+func (x *StockRatingAggregate) GetConsensusTargetHigh() float64 {
+	if x != nil && x.ConsensusTargetHigh != nil {
+		return *x.ConsensusTargetHigh
+	}
+	return 0
+}
+
+func (x *StockRatingAggregate) GetConsensusTargetLow() float64 {
+	if x != nil && x.ConsensusTargetLow != nil {
+		return *x.ConsensusTargetLow
+	}
+	return 0
+}
+
+func (x *StockRatingAggregate) GetImpliedUpside() float64 {
+	if x != nil && x.ImpliedUpside != nil {
+		return *x.ImpliedUpside
+	}
+	return 0
+}
+
+type Quote struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Current       float64                `protobuf:"fixed64,1,opt,name=current,proto3" json:"current,omitempty"`
+	Open          float64                `protobuf:"fixed64,2,opt,name=open,proto3" json:"open,omitempty"`
+	High          float64                `protobuf:"fixed64,3,opt,name=high,proto3" json:"high,omitempty"`
+	Low           float64                `protobuf:"fixed64,4,opt,name=low,proto3" json:"low,omitempty"`
+	PreviousClose float64                `protobuf:"fixed64,5,opt,name=previous_close,json=previousClose,proto3" json:"previous_close,omitempty"`
+	Change        float64                `protobuf:"fixed64,6,opt,name=change,proto3" json:"change,omitempty"`
+	PercentChange float64                `protobuf:"fixed64,7,opt,name=percent_change,json=percentChange,proto3" json:"percent_change,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Quote) Reset() {
+	*x = Quote{}
+	mi := &file_srs_v1_stock_rating_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Quote) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Quote) ProtoMessage() {}
+
+func (x *Quote) ProtoReflect() protoreflect.Message {
+	mi := &file_srs_v1_stock_rating_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Quote.ProtoReflect.Descriptor instead.
+func (*Quote) Descriptor() ([]byte, []int) {
+	return file_srs_v1_stock_rating_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Quote) GetCurrent() float64 {
+	if x != nil {
+		return x.Current
+	}
+	return 0
+}
+
+func (x *Quote) GetOpen() float64 {
+	if x != nil {
+		return x.Open
+	}
+	return 0
+}
+
+func (x *Quote) GetHigh() float64 {
+	if x != nil {
+		return x.High
+	}
+	return 0
+}
+
+func (x *Quote) GetLow() float64 {
+	if x != nil {
+		return x.Low
+	}
+	return 0
+}
+
+func (x *Quote) GetPreviousClose() float64 {
+	if x != nil {
+		return x.PreviousClose
+	}
+	return 0
+}
+
+func (x *Quote) GetChange() float64 {
+	if x != nil {
+		return x.Change
+	}
+	return 0
+}
+
+func (x *Quote) GetPercentChange() float64 {
+	if x != nil {
+		return x.PercentChange
+	}
+	return 0
+}
+
+type RecommendationTrend struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Period        string                 `protobuf:"bytes,1,opt,name=period,proto3" json:"period,omitempty"`
+	StrongBuy     int64                  `protobuf:"varint,2,opt,name=strong_buy,json=strongBuy,proto3" json:"strong_buy,omitempty"`
+	Buy           int64                  `protobuf:"varint,3,opt,name=buy,proto3" json:"buy,omitempty"`
+	Hold          int64                  `protobuf:"varint,4,opt,name=hold,proto3" json:"hold,omitempty"`
+	Sell          int64                  `protobuf:"varint,5,opt,name=sell,proto3" json:"sell,omitempty"`
+	StrongSell    int64                  `protobuf:"varint,6,opt,name=strong_sell,json=strongSell,proto3" json:"strong_sell,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecommendationTrend) Reset() {
+	*x = RecommendationTrend{}
+	mi := &file_srs_v1_stock_rating_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecommendationTrend) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecommendationTrend) ProtoMessage() {}
+
+func (x *RecommendationTrend) ProtoReflect() protoreflect.Message {
+	mi := &file_srs_v1_stock_rating_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecommendationTrend.ProtoReflect.Descriptor instead.
+func (*RecommendationTrend) Descriptor() ([]byte, []int) {
+	return file_srs_v1_stock_rating_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *RecommendationTrend) GetPeriod() string {
+	if x != nil {
+		return x.Period
+	}
+	return ""
+}
+
+func (x *RecommendationTrend) GetStrongBuy() int64 {
+	if x != nil {
+		return x.StrongBuy
+	}
+	return 0
+}
+
+func (x *RecommendationTrend) GetBuy() int64 {
+	if x != nil {
+		return x.Buy
+	}
+	return 0
+}
+
+func (x *RecommendationTrend) GetHold() int64 {
+	if x != nil {
+		return x.Hold
+	}
+	return 0
+}
+
+func (x *RecommendationTrend) GetSell() int64 {
+	if x != nil {
+		return x.Sell
+	}
+	return 0
+}
+
+func (x *RecommendationTrend) GetStrongSell() int64 {
+	if x != nil {
+		return x.StrongSell
+	}
+	return 0
+}
+
+type SectionStatus struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SectionStatus) Reset() {
+	*x = SectionStatus{}
+	mi := &file_srs_v1_stock_rating_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SectionStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SectionStatus) ProtoMessage() {}
+
+func (x *SectionStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_srs_v1_stock_rating_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SectionStatus.ProtoReflect.Descriptor instead.
+func (*SectionStatus) Descriptor() ([]byte, []int) {
+	return file_srs_v1_stock_rating_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SectionStatus) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *SectionStatus) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *SectionStatus) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type StockDetailsStatus struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Quote           *SectionStatus         `protobuf:"bytes,1,opt,name=quote,proto3" json:"quote,omitempty"`
+	Recommendations *SectionStatus         `protobuf:"bytes,2,opt,name=recommendations,proto3" json:"recommendations,omitempty"`
+	KeyFacts        *SectionStatus         `protobuf:"bytes,3,opt,name=key_facts,json=keyFacts,proto3" json:"key_facts,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *StockDetailsStatus) Reset() {
+	*x = StockDetailsStatus{}
+	mi := &file_srs_v1_stock_rating_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StockDetailsStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockDetailsStatus) ProtoMessage() {}
+
+func (x *StockDetailsStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_srs_v1_stock_rating_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockDetailsStatus.ProtoReflect.Descriptor instead.
+func (*StockDetailsStatus) Descriptor() ([]byte, []int) {
+	return file_srs_v1_stock_rating_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *StockDetailsStatus) GetQuote() *SectionStatus {
+	if x != nil {
+		return x.Quote
+	}
+	return nil
+}
+
+func (x *StockDetailsStatus) GetRecommendations() *SectionStatus {
+	if x != nil {
+		return x.Recommendations
+	}
+	return nil
+}
+
+func (x *StockDetailsStatus) GetKeyFacts() *SectionStatus {
+	if x != nil {
+		return x.KeyFacts
+	}
+	return nil
+}
+
+type StockDetails struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	KeyFacts        string                 `protobuf:"bytes,1,opt,name=key_facts,json=keyFacts,proto3" json:"key_facts,omitempty"`
+	Quote           *Quote                 `protobuf:"bytes,2,opt,name=quote,proto3" json:"quote,omitempty"`
+	Recommendations []*RecommendationTrend `protobuf:"bytes,3,rep,name=recommendations,proto3" json:"recommendations,omitempty"`
+	Status          *StockDetailsStatus    `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *StockDetails) Reset() {
+	*x = StockDetails{}
+	mi := &file_srs_v1_stock_rating_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StockDetails) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockDetails) ProtoMessage() {}
+
+func (x *StockDetails) ProtoReflect() protoreflect.Message {
+	mi := &file_srs_v1_stock_rating_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockDetails.ProtoReflect.Descriptor instead.
+func (*StockDetails) Descriptor() ([]byte, []int) {
+	return file_srs_v1_stock_rating_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *StockDetails) GetKeyFacts() string {
+	if x != nil {
+		return x.KeyFacts
+	}
+	return ""
+}
+
+func (x *StockDetails) GetQuote() *Quote {
+	if x != nil {
+		return x.Quote
+	}
+	return nil
+}
+
+func (x *StockDetails) GetRecommendations() []*RecommendationTrend {
+	if x != nil {
+		return x.Recommendations
+	}
+	return nil
+}
+
+func (x *StockDetails) GetStatus() *StockDetailsStatus {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+type ListStockRatingsRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Search   string                 `protobuf:"bytes,1,opt,name=search,proto3" json:"search,omitempty"`
+	NextPage string                 `protobuf:"bytes,2,opt,name=next_page,json=nextPage,proto3" json:"next_page,omitempty"`
+	// Defaults to 10, at most 100.
+	PageSize      int32 `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListStockRatingsRequest) Reset() {
+	*x = ListStockRatingsRequest{}
+	mi := &file_srs_v1_stock_rating_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListStockRatingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListStockRatingsRequest) ProtoMessage() {}
+
+func (x *ListStockRatingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_srs_v1_stock_rating_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListStockRatingsRequest.ProtoReflect.Descriptor instead.
+func (*ListStockRatingsRequest) Descriptor() ([]byte, []int) {
+	return file_srs_v1_stock_rating_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListStockRatingsRequest) GetSearch() string {
+	if x != nil {
+		return x.Search
+	}
+	return ""
+}
+
+func (x *ListStockRatingsRequest) GetNextPage() string {
+	if x != nil {
+		return x.NextPage
+	}
+	return ""
+}
+
+func (x *ListStockRatingsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type ListStockRatingsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []*StockRating         `protobuf:"bytes,1,rep,name=data,proto3" json:"data,omitempty"`
+	NextPage      string                 `protobuf:"bytes,2,opt,name=next_page,json=nextPage,proto3" json:"next_page,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListStockRatingsResponse) Reset() {
+	*x = ListStockRatingsResponse{}
+	mi := &file_srs_v1_stock_rating_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListStockRatingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListStockRatingsResponse) ProtoMessage() {}
+
+func (x *ListStockRatingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_srs_v1_stock_rating_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListStockRatingsResponse.ProtoReflect.Descriptor instead.
+func (*ListStockRatingsResponse) Descriptor() ([]byte, []int) {
+	return file_srs_v1_stock_rating_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListStockRatingsResponse) GetData() []*StockRating {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *ListStockRatingsResponse) GetNextPage() string {
+	if x != nil {
+		return x.NextPage
+	}
+	return ""
+}
+
+type GetStockRecommendationsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Defaults to 10, at most 100.
+	PageSize int32 `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// Empty or "upside".
+	Sort          string `protobuf:"bytes,2,opt,name=sort,proto3" json:"sort,omitempty"`
+	Sector        string `protobuf:"bytes,3,opt,name=sector,proto3" json:"sector,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStockRecommendationsRequest) Reset() {
+	*x = GetStockRecommendationsRequest{}
+	mi := &file_srs_v1_stock_rating_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStockRecommendationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStockRecommendationsRequest) ProtoMessage() {}
+
+func (x *GetStockRecommendationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_srs_v1_stock_rating_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStockRecommendationsRequest.ProtoReflect.Descriptor instead.
+func (*GetStockRecommendationsRequest) Descriptor() ([]byte, []int) {
+	return file_srs_v1_stock_rating_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetStockRecommendationsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *GetStockRecommendationsRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *GetStockRecommendationsRequest) GetSector() string {
+	if x != nil {
+		return x.Sector
+	}
+	return ""
+}
+
+type GetStockRecommendationsResponse struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Data          []*StockRatingAggregate `protobuf:"bytes,1,rep,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStockRecommendationsResponse) Reset() {
+	*x = GetStockRecommendationsResponse{}
+	mi := &file_srs_v1_stock_rating_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStockRecommendationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStockRecommendationsResponse) ProtoMessage() {}
+
+func (x *GetStockRecommendationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_srs_v1_stock_rating_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStockRecommendationsResponse.ProtoReflect.Descriptor instead.
+func (*GetStockRecommendationsResponse) Descriptor() ([]byte, []int) {
+	return file_srs_v1_stock_rating_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetStockRecommendationsResponse) GetData() []*StockRatingAggregate {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type GetStockDetailsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ticker        string                 `protobuf:"bytes,1,opt,name=ticker,proto3" json:"ticker,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStockDetailsRequest) Reset() {
+	*x = GetStockDetailsRequest{}
+	mi := &file_srs_v1_stock_rating_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStockDetailsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStockDetailsRequest) ProtoMessage() {}
+
+func (x *GetStockDetailsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_srs_v1_stock_rating_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStockDetailsRequest.ProtoReflect.Descriptor instead.
+func (*GetStockDetailsRequest) Descriptor() ([]byte, []int) {
+	return file_srs_v1_stock_rating_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetStockDetailsRequest) GetTicker() string {
+	if x != nil {
+		return x.Ticker
+	}
+	return ""
+}
+
+type LoadStockRatingsRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	UseCustomFormat bool                   `protobuf:"varint,1,opt,name=use_custom_format,json=useCustomFormat,proto3" json:"use_custom_format,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *LoadStockRatingsRequest) Reset() {
+	*x = LoadStockRatingsRequest{}
+	mi := &file_srs_v1_stock_rating_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoadStockRatingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoadStockRatingsRequest) ProtoMessage() {}
+
+func (x *LoadStockRatingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_srs_v1_stock_rating_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoadStockRatingsRequest.ProtoReflect.Descriptor instead.
+func (*LoadStockRatingsRequest) Descriptor() ([]byte, []int) {
+	return file_srs_v1_stock_rating_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *LoadStockRatingsRequest) GetUseCustomFormat() bool {
+	if x != nil {
+		return x.UseCustomFormat
+	}
+	return false
+}
+
+type LoadStockRatingsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LoadStockRatingsResponse) Reset() {
+	*x = LoadStockRatingsResponse{}
+	mi := &file_srs_v1_stock_rating_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoadStockRatingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoadStockRatingsResponse) ProtoMessage() {}
+
+func (x *LoadStockRatingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_srs_v1_stock_rating_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoadStockRatingsResponse.ProtoReflect.Descriptor instead.
+func (*LoadStockRatingsResponse) Descriptor() ([]byte, []int) {
+	return file_srs_v1_stock_rating_proto_rawDescGZIP(), []int{13}
+}
+
+type StreamStockRatingsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tickers       []string               `protobuf:"bytes,1,rep,name=tickers,proto3" json:"tickers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamStockRatingsRequest) Reset() {
+	*x = StreamStockRatingsRequest{}
+	mi := &file_srs_v1_stock_rating_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamStockRatingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamStockRatingsRequest) ProtoMessage() {}
+
+func (x *StreamStockRatingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_srs_v1_stock_rating_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamStockRatingsRequest.ProtoReflect.Descriptor instead.
+func (*StreamStockRatingsRequest) Descriptor() ([]byte, []int) {
+	return file_srs_v1_stock_rating_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *StreamStockRatingsRequest) GetTickers() []string {
+	if x != nil {
+		return x.Tickers
+	}
+	return nil
+}
+
+var File_srs_v1_stock_rating_proto protoreflect.FileDescriptor
+
+const file_srs_v1_stock_rating_proto_rawDesc = "" +
+	"\n" +
+	"\x19srs/v1/stock_rating.proto\x12\x06srs.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xdc\x04\n" +
+	"\vStockRating\x12\x1c\n" +
+	"\tbrokerage\x18\x01 \x01(\tR\tbrokerage\x12\x16\n" +
+	"\x06action\x18\x02 \x01(\tR\x06action\x12\x18\n" +
+	"\acompany\x18\x03 \x01(\tR\acompany\x12\x16\n" +
+	"\x06ticker\x18\x04 \x01(\tR\x06ticker\x12\x1f\n" +
+	"\vrating_from\x18\x05 \x01(\tR\n" +
+	"ratingFrom\x12\x1b\n" +
+	"\trating_to\x18\x06 \x01(\tR\bratingTo\x12\x1f\n" +
+	"\vtarget_from\x18\a \x01(\tR\n" +
+	"targetFrom\x12\x1b\n" +
+	"\ttarget_to\x18\b \x01(\tR\btargetTo\x12/\n" +
+	"\x11target_from_value\x18\t \x01(\x01H\x00R\x0ftargetFromValue\x88\x01\x01\x12+\n" +
+	"\x0ftarget_to_value\x18\n" +
+	" \x01(\x01H\x01R\rtargetToValue\x88\x01\x01\x12\x1f\n" +
+	"\bcurrency\x18\v \x01(\tH\x02R\bcurrency\x88\x01\x01\x12.\n" +
+	"\x04time\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12.\n" +
+	"\x13target_price_change\x18\r \x01(\x01R\x11targetPriceChange\x12\x14\n" +
+	"\x05score\x18\x0e \x01(\x02R\x05score\x12*\n" +
+	"\x0eimplied_upside\x18\x0f \x01(\x01H\x03R\rimpliedUpside\x88\x01\x01B\x14\n" +
+	"\x12_target_from_valueB\x12\n" +
+	"\x10_target_to_valueB\v\n" +
+	"\t_currencyB\x11\n" +
+	"\x0f_implied_upside\"\x9b\x06\n" +
+	"\x14StockRatingAggregate\x12\x16\n" +
+	"\x06ticker\x18\x01 \x01(\tR\x06ticker\x12.\n" +
+	"\x04time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12,\n" +
+	"\x12strong_buy_ratings\x18\x03 \x01(\x05R\x10strongBuyRatings\x12\x1f\n" +
+	"\vbuy_ratings\x18\x04 \x01(\x05R\n" +
+	"buyRatings\x12!\n" +
+	"\fhold_ratings\x18\x05 \x01(\x05R\vholdRatings\x12!\n" +
+	"\fsell_ratings\x18\x06 \x01(\x05R\vsellRatings\x12\x16\n" +
+	"\x06rating\x18\a \x01(\tR\x06rating\x12.\n" +
+	"\x13target_price_change\x18\b \x01(\x01R\x11targetPriceChange\x12\x14\n" +
+	"\x05score\x18\t \x01(\x02R\x05score\x12(\n" +
+	"\rcurrent_price\x18\n" +
+	" \x01(\x01H\x00R\fcurrentPrice\x88\x01\x01\x12;\n" +
+	"\x17consensus_target_median\x18\v \x01(\x01H\x01R\x15consensusTargetMedian\x88\x01\x01\x127\n" +
+	"\x15consensus_target_mean\x18\f \x01(\x01H\x02R\x13consensusTargetMean\x88\x01\x01\x127\n" +
+	"\x15consensus_target_high\x18\r \x01(\x01H\x03R\x13consensusTargetHigh\x88\x01\x01\x125\n" +
+	"\x14consensus_target_low\x18\x0e \x01(\x01H\x04R\x12consensusTargetLow\x88\x01\x01\x12*\n" +
+	"\x0eimplied_upside\x18\x0f \x01(\x01H\x05R\rimpliedUpside\x88\x01\x01B\x10\n" +
+	"\x0e_current_priceB\x1a\n" +
+	"\x18_consensus_target_medianB\x18\n" +
+	"\x16_consensus_target_meanB\x18\n" +
+	"\x16_consensus_target_highB\x17\n" +
+	"\x15_consensus_target_lowB\x11\n" +
+	"\x0f_implied_upside\"\xc1\x01\n" +
+	"\x05Quote\x12\x18\n" +
+	"\acurrent\x18\x01 \x01(\x01R\acurrent\x12\x12\n" +
+	"\x04open\x18\x02 \x01(\x01R\x04open\x12\x12\n" +
+	"\x04high\x18\x03 \x01(\x01R\x04high\x12\x10\n" +
+	"\x03low\x18\x04 \x01(\x01R\x03low\x12%\n" +
+	"\x0eprevious_close\x18\x05 \x01(\x01R\rpreviousClose\x12\x16\n" +
+	"\x06change\x18\x06 \x01(\x01R\x06change\x12%\n" +
+	"\x0epercent_change\x18\a \x01(\x01R\rpercentChange\"\xa7\x01\n" +
+	"\x13RecommendationTrend\x12\x16\n" +
+	"\x06period\x18\x01 \x01(\tR\x06period\x12\x1d\n" +
+	"\n" +
+	"strong_buy\x18\x02 \x01(\x03R\tstrongBuy\x12\x10\n" +
+	"\x03buy\x18\x03 \x01(\x03R\x03buy\x12\x12\n" +
+	"\x04hold\x18\x04 \x01(\x03R\x04hold\x12\x12\n" +
+	"\x04sell\x18\x05 \x01(\x03R\x04sell\x12\x1f\n" +
+	"\vstrong_sell\x18\x06 \x01(\x03R\n" +
+	"strongSell\"U\n" +
+	"\rSectionStatus\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"\xb6\x01\n" +
+	"\x12StockDetailsStatus\x12+\n" +
+	"\x05quote\x18\x01 \x01(\v2\x15.srs.v1.SectionStatusR\x05quote\x12?\n" +
+	"\x0frecommendations\x18\x02 \x01(\v2\x15.srs.v1.SectionStatusR\x0frecommendations\x122\n" +
+	"\tkey_facts\x18\x03 \x01(\v2\x15.srs.v1.SectionStatusR\bkeyFacts\"\xcb\x01\n" +
+	"\fStockDetails\x12\x1b\n" +
+	"\tkey_facts\x18\x01 \x01(\tR\bkeyFacts\x12#\n" +
+	"\x05quote\x18\x02 \x01(\v2\r.srs.v1.QuoteR\x05quote\x12E\n" +
+	"\x0frecommendations\x18\x03 \x03(\v2\x1b.srs.v1.RecommendationTrendR\x0frecommendations\x122\n" +
+	"\x06status\x18\x04 \x01(\v2\x1a.srs.v1.StockDetailsStatusR\x06status\"k\n" +
+	"\x17ListStockRatingsRequest\x12\x16\n" +
+	"\x06search\x18\x01 \x01(\tR\x06search\x12\x1b\n" +
+	"\tnext_page\x18\x02 \x01(\tR\bnextPage\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\"`\n" +
+	"\x18ListStockRatingsResponse\x12'\n" +
+	"\x04data\x18\x01 \x03(\v2\x13.srs.v1.StockRatingR\x04data\x12\x1b\n" +
+	"\tnext_page\x18\x02 \x01(\tR\bnextPage\"i\n" +
+	"\x1eGetStockRecommendationsRequest\x12\x1b\n" +
+	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x12\n" +
+	"\x04sort\x18\x02 \x01(\tR\x04sort\x12\x16\n" +
+	"\x06sector\x18\x03 \x01(\tR\x06sector\"S\n" +
+	"\x1fGetStockRecommendationsResponse\x120\n" +
+	"\x04data\x18\x01 \x03(\v2\x1c.srs.v1.StockRatingAggregateR\x04data\"0\n" +
+	"\x16GetStockDetailsRequest\x12\x16\n" +
+	"\x06ticker\x18\x01 \x01(\tR\x06ticker\"E\n" +
+	"\x17LoadStockRatingsRequest\x12*\n" +
+	"\x11use_custom_format\x18\x01 \x01(\bR\x0fuseCustomFormat\"\x1a\n" +
+	"\x18LoadStockRatingsResponse\"5\n" +
+	"\x19StreamStockRatingsRequest\x12\x18\n" +
+	"\atickers\x18\x01 \x03(\tR\atickers2\xc7\x03\n" +
+	"\x12StockRatingService\x12U\n" +
+	"\x10ListStockRatings\x12\x1f.srs.v1.ListStockRatingsRequest\x1a .srs.v1.ListStockRatingsResponse\x12j\n" +
+	"\x17GetStockRecommendations\x12&.srs.v1.GetStockRecommendationsRequest\x1a'.srs.v1.GetStockRecommendationsResponse\x12G\n" +
+	"\x0fGetStockDetails\x12\x1e.srs.v1.GetStockDetailsRequest\x1a\x14.srs.v1.StockDetails\x12U\n" +
+	"\x10LoadStockRatings\x12\x1f.srs.v1.LoadStockRatingsRequest\x1a .srs.v1.LoadStockRatingsResponse\x12N\n" +
+	"\x12StreamStockRatings\x12!.srs.v1.StreamStockRatingsRequest\x1a\x13.srs.v1.StockRating0\x01BHZFgithub.com/rubenpad/srs/internal/infrastructure/grpcserver/srsv1;srsv1b\x06proto3"
+
+var (
+	file_srs_v1_stock_rating_proto_rawDescOnce sync.Once
+	file_srs_v1_stock_rating_proto_rawDescData []byte
+)
+
+func file_srs_v1_stock_rating_proto_rawDescGZIP() []byte {
+	file_srs_v1_stock_rating_proto_rawDescOnce.Do(func() {
+		file_srs_v1_stock_rating_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_srs_v1_stock_rating_proto_rawDesc), len(file_srs_v1_stock_rating_proto_rawDesc)))
+	})
+	return file_srs_v1_stock_rating_proto_rawDescData
+}
+
+var file_srs_v1_stock_rating_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
+var file_srs_v1_stock_rating_proto_goTypes = []any{
+	(*StockRating)(nil),                     // 0: srs.v1.StockRating
+	(*StockRatingAggregate)(nil),            // 1: srs.v1.StockRatingAggregate
+	(*Quote)(nil),                           // 2: srs.v1.Quote
+	(*RecommendationTrend)(nil),             // 3: srs.v1.RecommendationTrend
+	(*SectionStatus)(nil),                   // 4: srs.v1.SectionStatus
+	(*StockDetailsStatus)(nil),              // 5: srs.v1.StockDetailsStatus
+	(*StockDetails)(nil),                    // 6: srs.v1.StockDetails
+	(*ListStockRatingsRequest)(nil),         // 7: srs.v1.ListStockRatingsRequest
+	(*ListStockRatingsResponse)(nil),        // 8: srs.v1.ListStockRatingsResponse
+	(*GetStockRecommendationsRequest)(nil),  // 9: srs.v1.GetStockRecommendationsRequest
+	(*GetStockRecommendationsResponse)(nil), // 10: srs.v1.GetStockRecommendationsResponse
+	(*GetStockDetailsRequest)(nil),          // 11: srs.v1.GetStockDetailsRequest
+	(*LoadStockRatingsRequest)(nil),         // 12: srs.v1.LoadStockRatingsRequest
+	(*LoadStockRatingsResponse)(nil),        // 13: srs.v1.LoadStockRatingsResponse
+	(*StreamStockRatingsRequest)(nil),       // 14: srs.v1.StreamStockRatingsRequest
+	(*timestamppb.Timestamp)(nil),           // 15: google.protobuf.Timestamp
+}
+var file_srs_v1_stock_rating_proto_depIdxs = []int32{
+	15, // 0: srs.v1.StockRating.time:type_name -> google.protobuf.Timestamp
+	15, // 1: srs.v1.StockRatingAggregate.time:type_name -> google.protobuf.Timestamp
+	4,  // 2: srs.v1.StockDetailsStatus.quote:type_name -> srs.v1.SectionStatus
+	4,  // 3: srs.v1.StockDetailsStatus.recommendations:type_name -> srs.v1.SectionStatus
+	4,  // 4: srs.v1.StockDetailsStatus.key_facts:type_name -> srs.v1.SectionStatus
+	2,  // 5: srs.v1.StockDetails.quote:type_name -> srs.v1.Quote
+	3,  // 6: srs.v1.StockDetails.recommendations:type_name -> srs.v1.RecommendationTrend
+	5,  // 7: srs.v1.StockDetails.status:type_name -> srs.v1.StockDetailsStatus
+	0,  // 8: srs.v1.ListStockRatingsResponse.data:type_name -> srs.v1.StockRating
+	1,  // 9: srs.v1.GetStockRecommendationsResponse.data:type_name -> srs.v1.StockRatingAggregate
+	7,  // 10: srs.v1.StockRatingService.ListStockRatings:input_type -> srs.v1.ListStockRatingsRequest
+	9,  // 11: srs.v1.StockRatingService.GetStockRecommendations:input_type -> srs.v1.GetStockRecommendationsRequest
+	11, // 12: srs.v1.StockRatingService.GetStockDetails:input_type -> srs.v1.GetStockDetailsRequest
+	12, // 13: srs.v1.StockRatingService.LoadStockRatings:input_type -> srs.v1.LoadStockRatingsRequest
+	14, // 14: srs.v1.StockRatingService.StreamStockRatings:input_type -> srs.v1.StreamStockRatingsRequest
+	8,  // 15: srs.v1.StockRatingService.ListStockRatings:output_type -> srs.v1.ListStockRatingsResponse
+	10, // 16: srs.v1.StockRatingService.GetStockRecommendations:output_type -> srs.v1.GetStockRecommendationsResponse
+	6,  // 17: srs.v1.StockRatingService.GetStockDetails:output_type -> srs.v1.StockDetails
+	13, // 18: srs.v1.StockRatingService.LoadStockRatings:output_type -> srs.v1.LoadStockRatingsResponse
+	0,  // 19: srs.v1.StockRatingService.StreamStockRatings:output_type -> srs.v1.StockRating
+	15, // [15:20] is the sub-list for method output_type
+	10, // [10:15] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
+}
+
+func init() { file_srs_v1_stock_rating_proto_init() }
+func file_srs_v1_stock_rating_proto_init() {
+	if File_srs_v1_stock_rating_proto != nil {
+		return
+	}
+	file_srs_v1_stock_rating_proto_msgTypes[0].OneofWrappers = []any{}
+	file_srs_v1_stock_rating_proto_msgTypes[1].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_srs_v1_stock_rating_proto_rawDesc), len(file_srs_v1_stock_rating_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   15,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_srs_v1_stock_rating_proto_goTypes,
+		DependencyIndexes: file_srs_v1_stock_rating_proto_depIdxs,
+		MessageInfos:      file_srs_v1_stock_rating_proto_msgTypes,
+	}.Build()
+	File_srs_v1_stock_rating_proto = out.File
+	file_srs_v1_stock_rating_proto_goTypes = nil
+	file_srs_v1_stock_rating_proto_depIdxs = nil
+}
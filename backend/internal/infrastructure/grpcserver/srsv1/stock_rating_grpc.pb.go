@@ -0,0 +1,299 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: srs/v1/stock_rating.proto
+
+package srsv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	StockRatingService_ListStockRatings_FullMethodName        = "/srs.v1.StockRatingService/ListStockRatings"
+	StockRatingService_GetStockRecommendations_FullMethodName = "/srs.v1.StockRatingService/GetStockRecommendations"
+	StockRatingService_GetStockDetails_FullMethodName         = "/srs.v1.StockRatingService/GetStockDetails"
+	StockRatingService_LoadStockRatings_FullMethodName        = "/srs.v1.StockRatingService/LoadStockRatings"
+	StockRatingService_StreamStockRatings_FullMethodName      = "/srs.v1.StockRatingService/StreamStockRatings"
+)
+
+// StockRatingServiceClient is the client API for StockRatingService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// StockRatingService exposes the stock ratings API of the REST handlers to
+// gRPC clients.
+type StockRatingServiceClient interface {
+	// ListStockRatings pages through the ratings ordered by ticker.
+	ListStockRatings(ctx context.Context, in *ListStockRatingsRequest, opts ...grpc.CallOption) (*ListStockRatingsResponse, error)
+	// GetStockRecommendations returns the best rated tickers.
+	GetStockRecommendations(ctx context.Context, in *GetStockRecommendationsRequest, opts ...grpc.CallOption) (*GetStockRecommendationsResponse, error)
+	// GetStockDetails returns the quote, analyst trends and key facts of a
+	// ticker. Sections that could not be loaded are reported in status.
+	GetStockDetails(ctx context.Context, in *GetStockDetailsRequest, opts ...grpc.CallOption) (*StockDetails, error)
+	// LoadStockRatings starts loading the ratings from the upstream API in the
+	// background.
+	LoadStockRatings(ctx context.Context, in *LoadStockRatingsRequest, opts ...grpc.CallOption) (*LoadStockRatingsResponse, error)
+	// StreamStockRatings sends every rating saved from now on, only those of
+	// tickers when it is not empty.
+	StreamStockRatings(ctx context.Context, in *StreamStockRatingsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StockRating], error)
+}
+
+type stockRatingServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewStockRatingServiceClient(cc grpc.ClientConnInterface) StockRatingServiceClient {
+	return &stockRatingServiceClient{cc}
+}
+
+func (c *stockRatingServiceClient) ListStockRatings(ctx context.Context, in *ListStockRatingsRequest, opts ...grpc.CallOption) (*ListStockRatingsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListStockRatingsResponse)
+	err := c.cc.Invoke(ctx, StockRatingService_ListStockRatings_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockRatingServiceClient) GetStockRecommendations(ctx context.Context, in *GetStockRecommendationsRequest, opts ...grpc.CallOption) (*GetStockRecommendationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetStockRecommendationsResponse)
+	err := c.cc.Invoke(ctx, StockRatingService_GetStockRecommendations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockRatingServiceClient) GetStockDetails(ctx context.Context, in *GetStockDetailsRequest, opts ...grpc.CallOption) (*StockDetails, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StockDetails)
+	err := c.cc.Invoke(ctx, StockRatingService_GetStockDetails_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockRatingServiceClient) LoadStockRatings(ctx context.Context, in *LoadStockRatingsRequest, opts ...grpc.CallOption) (*LoadStockRatingsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LoadStockRatingsResponse)
+	err := c.cc.Invoke(ctx, StockRatingService_LoadStockRatings_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockRatingServiceClient) StreamStockRatings(ctx context.Context, in *StreamStockRatingsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StockRating], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &StockRatingService_ServiceDesc.Streams[0], StockRatingService_StreamStockRatings_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamStockRatingsRequest, StockRating]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type StockRatingService_StreamStockRatingsClient = grpc.ServerStreamingClient[StockRating]
+
+// StockRatingServiceServer is the server API for StockRatingService service.
+// All implementations must embed UnimplementedStockRatingServiceServer
+// for forward compatibility.
+//
+// StockRatingService exposes the stock ratings API of the REST handlers to
+// gRPC clients.
+type StockRatingServiceServer interface {
+	// ListStockRatings pages through the ratings ordered by ticker.
+	ListStockRatings(context.Context, *ListStockRatingsRequest) (*ListStockRatingsResponse, error)
+	// GetStockRecommendations returns the best rated tickers.
+	GetStockRecommendations(context.Context, *GetStockRecommendationsRequest) (*GetStockRecommendationsResponse, error)
+	// GetStockDetails returns the quote, analyst trends and key facts of a
+	// ticker. Sections that could not be loaded are reported in status.
+	GetStockDetails(context.Context, *GetStockDetailsRequest) (*StockDetails, error)
+	// LoadStockRatings starts loading the ratings from the upstream API in the
+	// background.
+	LoadStockRatings(context.Context, *LoadStockRatingsRequest) (*LoadStockRatingsResponse, error)
+	// StreamStockRatings sends every rating saved from now on, only those of
+	// tickers when it is not empty.
+	StreamStockRatings(*StreamStockRatingsRequest, grpc.ServerStreamingServer[StockRating]) error
+	mustEmbedUnimplementedStockRatingServiceServer()
+}
+
+// UnimplementedStockRatingServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedStockRatingServiceServer struct{}
+
+func (UnimplementedStockRatingServiceServer) ListStockRatings(context.Context, *ListStockRatingsRequest) (*ListStockRatingsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListStockRatings not implemented")
+}
+func (UnimplementedStockRatingServiceServer) GetStockRecommendations(context.Context, *GetStockRecommendationsRequest) (*GetStockRecommendationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStockRecommendations not implemented")
+}
+func (UnimplementedStockRatingServiceServer) GetStockDetails(context.Context, *GetStockDetailsRequest) (*StockDetails, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStockDetails not implemented")
+}
+func (UnimplementedStockRatingServiceServer) LoadStockRatings(context.Context, *LoadStockRatingsRequest) (*LoadStockRatingsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LoadStockRatings not implemented")
+}
+func (UnimplementedStockRatingServiceServer) StreamStockRatings(*StreamStockRatingsRequest, grpc.ServerStreamingServer[StockRating]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamStockRatings not implemented")
+}
+func (UnimplementedStockRatingServiceServer) mustEmbedUnimplementedStockRatingServiceServer() {}
+func (UnimplementedStockRatingServiceServer) testEmbeddedByValue()                            {}
+
+// UnsafeStockRatingServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to StockRatingServiceServer will
+// result in compilation errors.
+type UnsafeStockRatingServiceServer interface {
+	mustEmbedUnimplementedStockRatingServiceServer()
+}
+
+func RegisterStockRatingServiceServer(s grpc.ServiceRegistrar, srv StockRatingServiceServer) {
+	// If the following call pancis, it indicates UnimplementedStockRatingServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&StockRatingService_ServiceDesc, srv)
+}
+
+func _StockRatingService_ListStockRatings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListStockRatingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockRatingServiceServer).ListStockRatings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StockRatingService_ListStockRatings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockRatingServiceServer).ListStockRatings(ctx, req.(*ListStockRatingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StockRatingService_GetStockRecommendations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStockRecommendationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockRatingServiceServer).GetStockRecommendations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StockRatingService_GetStockRecommendations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockRatingServiceServer).GetStockRecommendations(ctx, req.(*GetStockRecommendationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StockRatingService_GetStockDetails_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStockDetailsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockRatingServiceServer).GetStockDetails(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StockRatingService_GetStockDetails_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockRatingServiceServer).GetStockDetails(ctx, req.(*GetStockDetailsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StockRatingService_LoadStockRatings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoadStockRatingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockRatingServiceServer).LoadStockRatings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StockRatingService_LoadStockRatings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockRatingServiceServer).LoadStockRatings(ctx, req.(*LoadStockRatingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StockRatingService_StreamStockRatings_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamStockRatingsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(StockRatingServiceServer).StreamStockRatings(m, &grpc.GenericServerStream[StreamStockRatingsRequest, StockRating]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type StockRatingService_StreamStockRatingsServer = grpc.ServerStreamingServer[StockRating]
+
+// StockRatingService_ServiceDesc is the grpc.ServiceDesc for StockRatingService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var StockRatingService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "srs.v1.StockRatingService",
+	HandlerType: (*StockRatingServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListStockRatings",
+			Handler:    _StockRatingService_ListStockRatings_Handler,
+		},
+		{
+			MethodName: "GetStockRecommendations",
+			Handler:    _StockRatingService_GetStockRecommendations_Handler,
+		},
+		{
+			MethodName: "GetStockDetails",
+			Handler:    _StockRatingService_GetStockDetails_Handler,
+		},
+		{
+			MethodName: "LoadStockRatings",
+			Handler:    _StockRatingService_LoadStockRatings_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamStockRatings",
+			Handler:       _StockRatingService_StreamStockRatings_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "srs/v1/stock_rating.proto",
+}
@@ -0,0 +1,68 @@
+package logging
+
+import (
+	"context"
+	"io"
+	"log/slog"
+
+	"github.com/rubenpad/srs/internal/domain/entity"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ContextHandler is a slog.Handler adding the request, trace, span and
+// ingestion job IDs found in the context to every record. It writes in the
+// current Format and drops the records below the current Level.
+type ContextHandler struct {
+	json slog.Handler
+	text slog.Handler
+}
+
+func NewContextHandler(w io.Writer) *ContextHandler {
+	options := &slog.HandlerOptions{Level: level}
+
+	return &ContextHandler{
+		json: slog.NewJSONHandler(w, options),
+		text: slog.NewTextHandler(w, options),
+	}
+}
+
+func (h *ContextHandler) Enabled(ctx context.Context, l slog.Level) bool {
+	return h.current().Enabled(ctx, l)
+}
+
+func (h *ContextHandler) Handle(ctx context.Context, record slog.Record) error {
+	record = record.Clone()
+
+	if id := RequestID(ctx); id != "" {
+		record.AddAttrs(slog.String("request_id", id))
+	}
+
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.IsValid() {
+		record.AddAttrs(
+			slog.String("trace_id", spanContext.TraceID().String()),
+			slog.String("span_id", spanContext.SpanID().String()),
+		)
+	}
+
+	if id := entity.JobID(ctx); id != "" {
+		record.AddAttrs(slog.String("job_id", id))
+	}
+
+	return h.current().Handle(ctx, record)
+}
+
+func (h *ContextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &ContextHandler{json: h.json.WithAttrs(attrs), text: h.text.WithAttrs(attrs)}
+}
+
+func (h *ContextHandler) WithGroup(name string) slog.Handler {
+	return &ContextHandler{json: h.json.WithGroup(name), text: h.text.WithGroup(name)}
+}
+
+func (h *ContextHandler) current() slog.Handler {
+	if Format() == FormatText {
+		return h.text
+	}
+
+	return h.json
+}
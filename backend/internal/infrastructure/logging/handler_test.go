@@ -0,0 +1,62 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/rubenpad/srs/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestContextHandler(t *testing.T) {
+	require.NoError(t, SetLevel("info"))
+	require.NoError(t, SetFormat(FormatJson))
+
+	var out bytes.Buffer
+	logger := slog.New(NewContextHandler(&out))
+
+	spanContext := trace.NewSpanContext(trace.SpanContextConfig{TraceID: trace.TraceID{1}, SpanID: trace.SpanID{2}})
+	ctx := trace.ContextWithSpanContext(context.Background(), spanContext)
+	ctx = entity.WithJobID(WithRequestID(ctx, "request-1"), "job-1")
+
+	logger.InfoContext(ctx, "saved")
+
+	var record map[string]any
+	require.NoError(t, json.Unmarshal(out.Bytes(), &record))
+	assert.Equal(t, "request-1", record["request_id"])
+	assert.Equal(t, spanContext.TraceID().String(), record["trace_id"])
+	assert.Equal(t, spanContext.SpanID().String(), record["span_id"])
+	assert.Equal(t, "job-1", record["job_id"])
+
+	out.Reset()
+	logger.Info("saved")
+	var withoutContext map[string]any
+	require.NoError(t, json.Unmarshal(out.Bytes(), &withoutContext))
+	assert.NotContains(t, withoutContext, "request_id")
+}
+
+func TestContextHandlerFollowsLevelAndFormat(t *testing.T) {
+	t.Cleanup(func() {
+		require.NoError(t, SetLevel("info"))
+		require.NoError(t, SetFormat(FormatJson))
+	})
+
+	var out bytes.Buffer
+	logger := slog.New(NewContextHandler(&out)).With("component", "test")
+
+	require.NoError(t, SetLevel("warn"))
+	logger.Info("dropped")
+	assert.Empty(t, out.String())
+
+	require.NoError(t, SetFormat("TEXT"))
+	logger.Warn("kept")
+	assert.Contains(t, out.String(), "level=WARN msg=kept component=test")
+
+	assert.EqualError(t, SetLevel("verbose"), `unknown log level "verbose"`)
+	assert.EqualError(t, SetFormat("xml"), `unknown log format "xml"`)
+}
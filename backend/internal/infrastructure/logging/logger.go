@@ -1,13 +1,78 @@
 package logging
 
 import (
+	"fmt"
 	"log/slog"
 	"os"
+	"strings"
+	"sync/atomic"
 )
 
-var logger *slog.Logger
+// Log formats accepted by Config.Format and SetFormat.
+const (
+	FormatJson = "json"
+	FormatText = "text"
+)
+
+// Config is the logging configuration read at startup.
+type Config struct {
+	Level  string
+	Format string
+}
+
+var (
+	level  = new(slog.LevelVar)
+	format atomic.Value
+)
+
+// Set installs the default logger. Its level and format can be changed later
+// with SetLevel and SetFormat.
+func Set(config Config) error {
+	if err := SetLevel(config.Level); err != nil {
+		return err
+	}
+
+	if err := SetFormat(config.Format); err != nil {
+		return err
+	}
+
+	slog.SetDefault(slog.New(NewContextHandler(os.Stdout)))
+	return nil
+}
+
+// Level returns the name of the current log level.
+func Level() string {
+	return level.Level().String()
+}
+
+// SetLevel changes the minimum level of the records logged, one of debug,
+// info, warn or error.
+func SetLevel(name string) error {
+	var l slog.Level
+	if err := l.UnmarshalText([]byte(name)); err != nil {
+		return fmt.Errorf("unknown log level %q", name)
+	}
+
+	level.Set(l)
+	return nil
+}
+
+// Format returns the current log format.
+func Format() string {
+	if f, ok := format.Load().(string); ok {
+		return f
+	}
+
+	return FormatJson
+}
+
+// SetFormat changes the format of the records logged, json or text.
+func SetFormat(name string) error {
+	name = strings.ToLower(name)
+	if name != FormatJson && name != FormatText {
+		return fmt.Errorf("unknown log format %q", name)
+	}
 
-func Set() {
-	logger = slog.New(slog.NewJSONHandler(os.Stdout, nil))
-	slog.SetDefault(logger)
+	format.Store(name)
+	return nil
 }
@@ -0,0 +1,177 @@
+package finnhub
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	finnhubapi "github.com/Finnhub-Stock-API/finnhub-go/v2"
+	"github.com/rubenpad/srs/internal/domain/entity"
+	"github.com/rubenpad/srs/internal/infrastructure/otel"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+)
+
+type MarketDataProvider struct {
+	client   *finnhubapi.DefaultApiService
+	upstream *otel.Upstream
+	hasKey   bool
+}
+
+func NewMarketDataProvider(apiKey string) *MarketDataProvider {
+	return newMarketDataProvider(apiKey, "")
+}
+
+// newMarketDataProvider points the client at baseURL when it is not empty,
+// which the tests use to talk to a local server.
+func newMarketDataProvider(apiKey, baseURL string) *MarketDataProvider {
+	configuration := finnhubapi.NewConfiguration()
+	configuration.AddDefaultHeader("X-Finnhub-Token", apiKey)
+	if baseURL != "" {
+		configuration.Servers = finnhubapi.ServerConfigurations{{URL: baseURL}}
+	}
+	configuration.HTTPClient = &http.Client{Transport: otelhttp.NewTransport(http.DefaultTransport)}
+
+	return &MarketDataProvider{
+		client:   finnhubapi.NewAPIClient(configuration).DefaultApi,
+		upstream: otel.NewUpstream("finnhub"),
+		hasKey:   apiKey != "",
+	}
+}
+
+// HealthCheck asks for a quote to verify that Finnhub is reachable and
+// accepts the API key.
+func (p *MarketDataProvider) HealthCheck(ctx context.Context) error {
+	if !p.hasKey {
+		return errors.New("finnhub API key is not set")
+	}
+
+	_, response, err := p.client.Quote(ctx).Symbol("AAPL").Execute()
+	if response != nil && (response.StatusCode == http.StatusUnauthorized || response.StatusCode == http.StatusForbidden) {
+		return errors.New("finnhub rejected the API key")
+	}
+
+	return err
+}
+
+func (p *MarketDataProvider) GetQuote(ctx context.Context, ticker string) (*entity.Quote, error) {
+	start := time.Now()
+	data, _, err := p.client.Quote(ctx).Symbol(ticker).Execute()
+	p.upstream.Record(ctx, "quote", start, err)
+	if err != nil {
+		return nil, upstreamError(err)
+	}
+
+	// Finnhub answers unknown symbols with an empty quote instead of an error.
+	if data.GetC() == 0 && data.GetPc() == 0 {
+		return nil, entity.ErrStockNotFound
+	}
+
+	return &entity.Quote{
+		Current:       float64(data.GetC()),
+		Open:          float64(data.GetO()),
+		High:          float64(data.GetH()),
+		Low:           float64(data.GetL()),
+		PreviousClose: float64(data.GetPc()),
+		Change:        float64(data.GetD()),
+		PercentChange: float64(data.GetDp()),
+	}, nil
+}
+
+func (p *MarketDataProvider) GetRecommendationTrends(ctx context.Context, ticker string) ([]entity.RecommendationTrend, error) {
+	start := time.Now()
+	data, _, err := p.client.RecommendationTrends(ctx).Symbol(ticker).Execute()
+	p.upstream.Record(ctx, "recommendation_trends", start, err)
+	if err != nil {
+		return nil, upstreamError(err)
+	}
+
+	trends := make([]entity.RecommendationTrend, 0, len(data))
+	for _, trend := range data {
+		trends = append(trends, entity.RecommendationTrend{
+			Period:     trend.GetPeriod(),
+			StrongBuy:  trend.GetStrongBuy(),
+			Buy:        trend.GetBuy(),
+			Hold:       trend.GetHold(),
+			Sell:       trend.GetSell(),
+			StrongSell: trend.GetStrongSell(),
+		})
+	}
+
+	return trends, nil
+}
+
+func (p *MarketDataProvider) GetDailyCandles(ctx context.Context, ticker string, from, to time.Time) ([]entity.Candle, error) {
+	start := time.Now()
+	data, _, err := p.client.StockCandles(ctx).
+		Symbol(ticker).
+		Resolution(entity.ResolutionDay).
+		From(from.Unix()).
+		To(to.Unix()).
+		Execute()
+	p.upstream.Record(ctx, "stock_candles", start, err)
+	if err != nil {
+		return nil, upstreamError(err)
+	}
+
+	if data.GetS() != "ok" {
+		return []entity.Candle{}, nil
+	}
+
+	timestamps := data.GetT()
+	open, high, low, closePrices, volume := data.GetO(), data.GetH(), data.GetL(), data.GetC(), data.GetV()
+
+	candles := make([]entity.Candle, 0, len(timestamps))
+	for i, timestamp := range timestamps {
+		if i >= len(open) || i >= len(high) || i >= len(low) || i >= len(closePrices) || i >= len(volume) {
+			break
+		}
+
+		candles = append(candles, entity.Candle{
+			Ticker: ticker,
+			Time:   time.Unix(timestamp, 0).UTC().Truncate(24 * time.Hour),
+			Open:   float64(open[i]),
+			High:   float64(high[i]),
+			Low:    float64(low[i]),
+			Close:  float64(closePrices[i]),
+			Volume: int64(volume[i]),
+		})
+	}
+
+	return candles, nil
+}
+
+func (p *MarketDataProvider) GetCompanyProfile(ctx context.Context, ticker string) (*entity.Company, error) {
+	start := time.Now()
+	data, _, err := p.client.CompanyProfile2(ctx).Symbol(ticker).Execute()
+	p.upstream.Record(ctx, "company_profile", start, err)
+	if err != nil {
+		return nil, upstreamError(err)
+	}
+
+	// Unknown symbols get an empty profile.
+	if data.GetName() == "" {
+		return nil, entity.ErrStockNotFound
+	}
+
+	// Industries missing from the table leave the sector empty, which the
+	// sectors report as unclassified.
+	return &entity.Company{
+		Ticker:   ticker,
+		Name:     data.GetName(),
+		Exchange: data.GetExchange(),
+		Sector:   industrySectors[data.GetFinnhubIndustry()],
+		Industry: data.GetFinnhubIndustry(),
+	}, nil
+}
+
+// upstreamError marks the requests cut by the context deadline as timeouts,
+// keeping the original error so callers can still match it.
+func upstreamError(err error) error {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return fmt.Errorf("%w: %w", entity.ErrUpstreamTimeout, err)
+	}
+
+	return err
+}
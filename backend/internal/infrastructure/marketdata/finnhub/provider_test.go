@@ -0,0 +1,145 @@
+package finnhub
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/rubenpad/srs/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestProvider(t *testing.T, handler http.HandlerFunc) *MarketDataProvider {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	return newMarketDataProvider("test-key", server.URL)
+}
+
+func respond(body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(body))
+	}
+}
+
+func TestGetQuote(t *testing.T) {
+	var token, symbol string
+	provider := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		token = r.Header.Get("X-Finnhub-Token")
+		symbol = r.URL.Query().Get("symbol")
+		assert.Equal(t, "/quote", r.URL.Path)
+		respond(`{"c":213.49,"o":211.56,"h":214.1,"l":210.82,"pc":211.26,"d":2.23,"dp":1.0556}`)(w, r)
+	})
+
+	quote, err := provider.GetQuote(context.Background(), "AAPL")
+	require.NoError(t, err)
+
+	assert.Equal(t, "test-key", token)
+	assert.Equal(t, "AAPL", symbol)
+	assert.InDelta(t, 213.49, quote.Current, 0.001)
+	assert.InDelta(t, 211.56, quote.Open, 0.001)
+	assert.InDelta(t, 214.1, quote.High, 0.001)
+	assert.InDelta(t, 210.82, quote.Low, 0.001)
+	assert.InDelta(t, 211.26, quote.PreviousClose, 0.001)
+	assert.InDelta(t, 2.23, quote.Change, 0.001)
+	assert.InDelta(t, 1.0556, quote.PercentChange, 0.001)
+}
+
+func TestGetQuoteNotFound(t *testing.T) {
+	provider := newTestProvider(t, respond(`{"c":0,"d":null,"dp":null,"h":0,"l":0,"o":0,"pc":0,"t":0}`))
+
+	_, err := provider.GetQuote(context.Background(), "UNKNOWN")
+	assert.ErrorIs(t, err, entity.ErrStockNotFound)
+}
+
+func TestGetRecommendationTrends(t *testing.T) {
+	provider := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/stock/recommendation", r.URL.Path)
+		respond(`[
+			{"symbol":"AAPL","period":"2025-04-01","strongBuy":14,"buy":22,"hold":16,"sell":2,"strongSell":0},
+			{"symbol":"AAPL","period":"2025-03-01","strongBuy":14,"buy":23,"hold":15,"sell":2,"strongSell":1}
+		]`)(w, r)
+	})
+
+	trends, err := provider.GetRecommendationTrends(context.Background(), "AAPL")
+	require.NoError(t, err)
+
+	assert.Equal(t, []entity.RecommendationTrend{
+		{Period: "2025-04-01", StrongBuy: 14, Buy: 22, Hold: 16, Sell: 2, StrongSell: 0},
+		{Period: "2025-03-01", StrongBuy: 14, Buy: 23, Hold: 15, Sell: 2, StrongSell: 1},
+	}, trends)
+}
+
+func TestGetCompanyProfileNotFound(t *testing.T) {
+	provider := newTestProvider(t, respond(`{}`))
+
+	_, err := provider.GetCompanyProfile(context.Background(), "UNKNOWN")
+	assert.ErrorIs(t, err, entity.ErrStockNotFound)
+}
+
+func TestUpstreamTimeout(t *testing.T) {
+	provider := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err := provider.GetQuote(ctx, "AAPL")
+	assert.ErrorIs(t, err, entity.ErrUpstreamTimeout)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	_, err = provider.GetRecommendationTrends(ctx, "AAPL")
+	assert.ErrorIs(t, err, entity.ErrUpstreamTimeout)
+}
+
+func TestUpstreamError(t *testing.T) {
+	provider := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "rate limited", http.StatusTooManyRequests)
+	})
+
+	_, err := provider.GetQuote(context.Background(), "AAPL")
+	require.Error(t, err)
+	assert.False(t, errors.Is(err, entity.ErrUpstreamTimeout))
+	assert.False(t, errors.Is(err, entity.ErrStockNotFound))
+}
+
+func TestGetCompanyProfile(t *testing.T) {
+	tests := []struct {
+		industry string
+		sector   string
+	}{
+		{"Technology", "Information Technology"},
+		{"Banking", "Financials"},
+		{"Media", "Communication Services"},
+		{"N/A", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.industry, func(t *testing.T) {
+			provider := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/stock/profile2", r.URL.Path)
+				respond(`{"name":"Apple Inc","exchange":"NASDAQ NMS - GLOBAL MARKET","finnhubIndustry":"`+tt.industry+`","ticker":"AAPL"}`)(w, r)
+			})
+
+			company, err := provider.GetCompanyProfile(context.Background(), "AAPL")
+			require.NoError(t, err)
+
+			assert.Equal(t, &entity.Company{
+				Ticker:   "AAPL",
+				Name:     "Apple Inc",
+				Exchange: "NASDAQ NMS - GLOBAL MARKET",
+				Sector:   tt.sector,
+				Industry: tt.industry,
+			}, company)
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package finnhub
+
+// industrySectors maps the finnhubIndustry of the company profiles, a flat
+// list of industries, to the GICS sector they belong to, the same sectors the
+// curated company data uses.
+var industrySectors = map[string]string{
+	"Aerospace & Defense":              "Industrials",
+	"Airlines":                         "Industrials",
+	"Auto Components":                  "Consumer Discretionary",
+	"Automobiles":                      "Consumer Discretionary",
+	"Banking":                          "Financials",
+	"Beverages":                        "Consumer Staples",
+	"Biotechnology":                    "Health Care",
+	"Building":                         "Industrials",
+	"Chemicals":                        "Materials",
+	"Commercial Services & Supplies":   "Industrials",
+	"Communications":                   "Information Technology",
+	"Construction":                     "Industrials",
+	"Consumer products":                "Consumer Staples",
+	"Distributors":                     "Consumer Discretionary",
+	"Diversified Consumer Services":    "Consumer Discretionary",
+	"Electrical Equipment":             "Industrials",
+	"Energy":                           "Energy",
+	"Financial Services":               "Financials",
+	"Food Products":                    "Consumer Staples",
+	"Health Care":                      "Health Care",
+	"Hotels, Restaurants & Leisure":    "Consumer Discretionary",
+	"Industrial Conglomerates":         "Industrials",
+	"Insurance":                        "Financials",
+	"Leisure Products":                 "Consumer Discretionary",
+	"Life Sciences Tools & Services":   "Health Care",
+	"Logistics & Transportation":       "Industrials",
+	"Machinery":                        "Industrials",
+	"Marine":                           "Industrials",
+	"Media":                            "Communication Services",
+	"Metals & Mining":                  "Materials",
+	"Packaging":                        "Materials",
+	"Paper & Forest":                   "Materials",
+	"Pharmaceuticals":                  "Health Care",
+	"Professional Services":            "Industrials",
+	"Real Estate":                      "Real Estate",
+	"Retail":                           "Consumer Discretionary",
+	"Road & Rail":                      "Industrials",
+	"Semiconductors":                   "Information Technology",
+	"Technology":                       "Information Technology",
+	"Telecommunication":                "Communication Services",
+	"Textiles, Apparel & Luxury Goods": "Consumer Discretionary",
+	"Tobacco":                          "Consumer Staples",
+	"Trading Companies & Distributors": "Industrials",
+	"Transportation Infrastructure":    "Industrials",
+	"Utilities":                        "Utilities",
+}
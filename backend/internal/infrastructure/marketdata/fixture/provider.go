@@ -0,0 +1,93 @@
+package fixture
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/rubenpad/srs/internal/domain/entity"
+)
+
+type TickerData struct {
+	Quote           *entity.Quote                `json:"quote"`
+	Recommendations []entity.RecommendationTrend `json:"recommendations"`
+	Candles         []entity.Candle              `json:"candles"`
+	Profile         *entity.Company              `json:"profile"`
+}
+
+// MarketDataProvider serves market data from a JSON file keyed by ticker so
+// the application can run offline and tests do not depend on Finnhub.
+type MarketDataProvider struct {
+	data map[string]TickerData
+}
+
+func NewMarketDataProvider(path string) (*MarketDataProvider, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading market data fixture: %w", err)
+	}
+
+	var data map[string]TickerData
+	if err := json.Unmarshal(content, &data); err != nil {
+		return nil, fmt.Errorf("error decoding market data fixture: %w", err)
+	}
+
+	return NewMarketDataProviderFromData(data), nil
+}
+
+func NewMarketDataProviderFromData(data map[string]TickerData) *MarketDataProvider {
+	normalized := make(map[string]TickerData, len(data))
+	for ticker, value := range data {
+		normalized[strings.ToUpper(ticker)] = value
+	}
+
+	return &MarketDataProvider{data: normalized}
+}
+
+func (p *MarketDataProvider) GetQuote(ctx context.Context, ticker string) (*entity.Quote, error) {
+	data, ok := p.data[strings.ToUpper(ticker)]
+	if !ok || data.Quote == nil {
+		return nil, entity.ErrStockNotFound
+	}
+
+	quote := *data.Quote
+	return &quote, nil
+}
+
+func (p *MarketDataProvider) GetRecommendationTrends(ctx context.Context, ticker string) ([]entity.RecommendationTrend, error) {
+	data := p.data[strings.ToUpper(ticker)]
+
+	trends := make([]entity.RecommendationTrend, len(data.Recommendations))
+	copy(trends, data.Recommendations)
+	return trends, nil
+}
+
+func (p *MarketDataProvider) GetDailyCandles(ctx context.Context, ticker string, from, to time.Time) ([]entity.Candle, error) {
+	data := p.data[strings.ToUpper(ticker)]
+
+	candles := []entity.Candle{}
+	for _, candle := range data.Candles {
+		if candle.Time.Before(from) || candle.Time.After(to) {
+			continue
+		}
+
+		candle.Ticker = strings.ToUpper(ticker)
+		candles = append(candles, candle)
+	}
+
+	return candles, nil
+}
+
+func (p *MarketDataProvider) GetCompanyProfile(ctx context.Context, ticker string) (*entity.Company, error) {
+	data, ok := p.data[strings.ToUpper(ticker)]
+	if !ok || data.Profile == nil {
+		return nil, entity.ErrStockNotFound
+	}
+
+	profile := *data.Profile
+	profile.Ticker = strings.ToUpper(ticker)
+	return &profile, nil
+}
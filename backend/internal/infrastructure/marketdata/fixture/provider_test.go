@@ -0,0 +1,91 @@
+package fixture
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rubenpad/srs/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMarketDataProvider(t *testing.T) {
+	data := map[string]TickerData{
+		"aapl": {
+			Quote: &entity.Quote{Current: 213.49, Open: 211.56, High: 214.1, Low: 210.82, PreviousClose: 211.26, Change: 2.23, PercentChange: 1.0556},
+			Recommendations: []entity.RecommendationTrend{
+				{Period: "2025-04-01", StrongBuy: 14, Buy: 22, Hold: 16, Sell: 2},
+			},
+			Candles: []entity.Candle{
+				{Time: time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC), Open: 219.81, High: 223.68, Low: 218.9, Close: 223.19, Volume: 36412700},
+				{Time: time.Date(2025, 4, 2, 0, 0, 0, 0, time.UTC), Open: 221.32, High: 225.19, Low: 221.02, Close: 223.89, Volume: 35905900},
+			},
+			Profile: &entity.Company{Name: "Apple Inc", Exchange: "NASDAQ", Sector: "Information Technology", Industry: "Technology"},
+		},
+	}
+
+	content, err := json.Marshal(data)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "market_data.json")
+	require.NoError(t, os.WriteFile(path, content, 0o600))
+
+	provider, err := NewMarketDataProvider(path)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	quote, err := provider.GetQuote(ctx, "AAPL")
+	require.NoError(t, err)
+	assert.Equal(t, data["aapl"].Quote, quote)
+
+	trends, err := provider.GetRecommendationTrends(ctx, "aapl")
+	require.NoError(t, err)
+	assert.Equal(t, data["aapl"].Recommendations, trends)
+
+	candles, err := provider.GetDailyCandles(ctx, "AAPL", time.Date(2025, 4, 2, 0, 0, 0, 0, time.UTC), time.Date(2025, 4, 30, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.Len(t, candles, 1)
+	assert.Equal(t, "AAPL", candles[0].Ticker)
+	assert.Equal(t, 223.89, candles[0].Close)
+
+	profile, err := provider.GetCompanyProfile(ctx, "AAPL")
+	require.NoError(t, err)
+	assert.Equal(t, "AAPL", profile.Ticker)
+	assert.Equal(t, "Apple Inc", profile.Name)
+	assert.Equal(t, "Information Technology", profile.Sector)
+
+	_, err = provider.GetQuote(ctx, "MSFT")
+	assert.ErrorIs(t, err, entity.ErrStockNotFound)
+
+	_, err = provider.GetCompanyProfile(ctx, "MSFT")
+	assert.ErrorIs(t, err, entity.ErrStockNotFound)
+
+	trends, err = provider.GetRecommendationTrends(ctx, "MSFT")
+	require.NoError(t, err)
+	assert.Empty(t, trends)
+}
+
+func TestNewMarketDataProviderErrors(t *testing.T) {
+	_, err := NewMarketDataProvider(filepath.Join(t.TempDir(), "missing.json"))
+	assert.ErrorContains(t, err, "error reading market data fixture")
+
+	path := filepath.Join(t.TempDir(), "market_data.json")
+	require.NoError(t, os.WriteFile(path, []byte("{"), 0o600))
+
+	_, err = NewMarketDataProvider(path)
+	assert.ErrorContains(t, err, "error decoding market data fixture")
+}
+
+func TestBundledFixture(t *testing.T) {
+	provider, err := NewMarketDataProvider("../../../../fixtures/market_data.json")
+	require.NoError(t, err)
+
+	quote, err := provider.GetQuote(context.Background(), "AAPL")
+	require.NoError(t, err)
+	assert.NotZero(t, quote.Current)
+}
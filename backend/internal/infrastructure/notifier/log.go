@@ -0,0 +1,30 @@
+package notifier
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/rubenpad/srs/internal/domain/entity"
+)
+
+// LogNotifier writes alert events to the application log.
+type LogNotifier struct{}
+
+func NewLogNotifier() *LogNotifier {
+	return &LogNotifier{}
+}
+
+func (ln *LogNotifier) Notify(ctx context.Context, event entity.AlertEvent) error {
+	slog.InfoContext(ctx, "alert",
+		"rule", event.RuleName,
+		"ticker", event.Ticker,
+		"brokerage", event.Rating.Brokerage,
+		"action", event.Rating.Action,
+		"ratingFrom", event.Rating.RatingFrom,
+		"ratingTo", event.Rating.RatingTo,
+		"targetFrom", event.Rating.TargetFrom,
+		"targetTo", event.Rating.TargetTo,
+	)
+
+	return nil
+}
@@ -0,0 +1,50 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"net/smtp"
+	"strings"
+
+	"github.com/rubenpad/srs/internal/domain/entity"
+)
+
+// SmtpNotifier emails alert events through an SMTP server that accepts mail
+// without authentication, such as a local relay.
+type SmtpNotifier struct {
+	address string
+	from    string
+	to      []string
+}
+
+func NewSmtpNotifier(address, from string, to []string) *SmtpNotifier {
+	return &SmtpNotifier{address: address, from: from, to: to}
+}
+
+func (sn *SmtpNotifier) Notify(ctx context.Context, event entity.AlertEvent) error {
+	rating := event.Rating
+	subject := fmt.Sprintf("[srs] %s: %s %s %s", event.RuleName, rating.Ticker, rating.Action, rating.Brokerage)
+
+	var body strings.Builder
+	fmt.Fprintf(&body, "From: %s\r\n", sn.from)
+	fmt.Fprintf(&body, "To: %s\r\n", strings.Join(sn.to, ", "))
+	fmt.Fprintf(&body, "Subject: %s\r\n\r\n", subject)
+	fmt.Fprintf(&body, "Rule: %s\r\n", event.RuleName)
+	fmt.Fprintf(&body, "Ticker: %s (%s)\r\n", rating.Ticker, rating.Company)
+	fmt.Fprintf(&body, "Brokerage: %s %s\r\n", rating.Action, rating.Brokerage)
+	fmt.Fprintf(&body, "Rating: %s -> %s\r\n", rating.RatingFrom, rating.RatingTo)
+	fmt.Fprintf(&body, "Target: %s -> %s\r\n", rating.TargetFrom, rating.TargetTo)
+	fmt.Fprintf(&body, "Date: %s\r\n", rating.Time.Format("2006-01-02"))
+
+	done := make(chan error, 1)
+	go func() {
+		done <- smtp.SendMail(sn.address, nil, sn.from, sn.to, []byte(body.String()))
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,47 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/rubenpad/srs/internal/domain/entity"
+)
+
+// WebhookNotifier posts alert events as JSON to a fixed URL.
+type WebhookNotifier struct {
+	url        string
+	httpClient *http.Client
+}
+
+func NewWebhookNotifier(url string) *WebhookNotifier {
+	return &WebhookNotifier{url: url, httpClient: &http.Client{}}
+}
+
+func (wn *WebhookNotifier) Notify(ctx context.Context, event entity.AlertEvent) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, wn.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+	response, err := wn.httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return fmt.Errorf("alert webhook answered with status %d", response.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,83 @@
+package otel
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelprometheus "go.opentelemetry.io/otel/exporters/prometheus"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+// ScopeName is the instrumentation scope of the application meters.
+const ScopeName = "github.com/rubenpad/srs"
+
+// DurationBuckets are the histogram boundaries, in seconds, of the latency
+// instruments.
+var DurationBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.075, 0.1, 0.25, 0.5, 0.75, 1, 2.5, 5, 7.5, 10}
+
+// InitMeter installs the global meter provider, read by a Prometheus exporter,
+// and returns the handler serving the metrics in the Prometheus text format.
+func InitMeter(res *resource.Resource) (*sdkmetric.MeterProvider, http.Handler, error) {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+
+	exporter, err := otelprometheus.New(otelprometheus.WithRegisterer(registry))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithResource(res), sdkmetric.WithReader(exporter))
+	otel.SetMeterProvider(mp)
+
+	return mp, promhttp.HandlerFor(registry, promhttp.HandlerOpts{}), nil
+}
+
+// Upstream records the latency and the errors of the calls to an external
+// service.
+type Upstream struct {
+	name     attribute.KeyValue
+	duration metric.Float64Histogram
+	errors   metric.Int64Counter
+}
+
+func NewUpstream(name string) *Upstream {
+	meter := otel.Meter(ScopeName)
+
+	duration, err := meter.Float64Histogram("srs.upstream.request.duration",
+		metric.WithDescription("Duration of the requests to the external services."),
+		metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries(DurationBuckets...))
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	errors, err := meter.Int64Counter("srs.upstream.errors",
+		metric.WithDescription("Failed requests to the external services."))
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	return &Upstream{name: attribute.String("upstream", name), duration: duration, errors: errors}
+}
+
+// Record records a call to operation started at start, failed when err is
+// not nil.
+func (u *Upstream) Record(ctx context.Context, operation string, start time.Time, err error) {
+	attributes := metric.WithAttributes(u.name, attribute.String("operation", operation))
+
+	u.duration.Record(ctx, time.Since(start).Seconds(), attributes)
+	if err != nil {
+		u.errors.Add(ctx, 1, attributes)
+	}
+}
@@ -2,28 +2,107 @@ package otel
 
 import (
 	"context"
+	"fmt"
+	"os"
 
 	"go.opentelemetry.io/otel"
-	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
 	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
 )
 
-func InitTracer() (*sdktrace.TracerProvider, error) {
-	exporter, err := otlptrace.New(context.Background(), otlptracehttp.NewClient())
+// Trace exporters accepted by TracerConfig.Exporter.
+const (
+	ExporterOtlpHttp = "otlphttp"
+	ExporterOtlpGrpc = "otlpgrpc"
+	ExporterStdout   = "stdout"
+	ExporterNone     = "none"
+)
+
+// TracerConfig selects where the spans are exported and which traces are
+// sampled. The OTLP exporters read their endpoint, headers and TLS settings
+// from the standard OTEL_EXPORTER_OTLP_* variables.
+type TracerConfig struct {
+	Exporter string
+	// SampleRatio is the fraction, from 0 to 1, of the new traces recorded.
+	SampleRatio float64
+	// ParentBased makes the spans with a remote parent follow the sampling
+	// decision of the parent instead of SampleRatio.
+	ParentBased bool
+}
+
+// ResourceConfig describes the service in the exported telemetry.
+type ResourceConfig struct {
+	ServiceName    string
+	ServiceVersion string
+	Environment    string
+}
+
+// NewResource returns the resource shared by the tracer and the meter
+// providers. The OTEL_RESOURCE_ATTRIBUTES variable can add more attributes.
+func NewResource(ctx context.Context, config ResourceConfig) (*resource.Resource, error) {
+	attributes := resource.WithAttributes(
+		semconv.ServiceName(config.ServiceName),
+		semconv.ServiceVersion(config.ServiceVersion),
+		semconv.DeploymentEnvironment(config.Environment),
+	)
+
+	return resource.New(ctx, resource.WithFromEnv(), resource.WithTelemetrySDK(), attributes)
+}
+
+// InitTracer installs the global tracer provider and the W3C trace context
+// propagator. With the none exporter spans are not recorded, but the incoming
+// trace context is still propagated.
+func InitTracer(ctx context.Context, config TracerConfig, res *resource.Resource) (*sdktrace.TracerProvider, error) {
+	if config.SampleRatio < 0 || config.SampleRatio > 1 {
+		return nil, fmt.Errorf("trace sample ratio must be between 0 and 1, got %v", config.SampleRatio)
+	}
 
+	options := []sdktrace.TracerProviderOption{sdktrace.WithResource(res)}
+
+	exporter, err := newExporter(ctx, config.Exporter)
 	if err != nil {
 		return nil, err
 	}
 
-	tp := sdktrace.NewTracerProvider(
-		sdktrace.WithSampler(sdktrace.AlwaysSample()),
-		sdktrace.WithBatcher(exporter),
-	)
+	if exporter == nil {
+		options = append(options, sdktrace.WithSampler(sdktrace.NeverSample()))
+	} else {
+		options = append(options, sdktrace.WithSampler(newSampler(config)), sdktrace.WithBatcher(exporter))
+	}
+
+	tp := sdktrace.NewTracerProvider(options...)
 
 	otel.SetTracerProvider(tp)
 	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
 
 	return tp, nil
 }
+
+func newExporter(ctx context.Context, name string) (sdktrace.SpanExporter, error) {
+	switch name {
+	case ExporterOtlpHttp:
+		return otlptracehttp.New(ctx)
+	case ExporterOtlpGrpc:
+		return otlptracegrpc.New(ctx)
+	case ExporterStdout:
+		return stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+	case ExporterNone:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unknown trace exporter %q", name)
+	}
+}
+
+func newSampler(config TracerConfig) sdktrace.Sampler {
+	sampler := sdktrace.TraceIDRatioBased(config.SampleRatio)
+	if config.ParentBased {
+		return sdktrace.ParentBased(sampler)
+	}
+
+	return sampler
+}
@@ -0,0 +1,73 @@
+package otel
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/sdk/resource"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestInitTracer(t *testing.T) {
+	tests := []struct {
+		name   string
+		config TracerConfig
+		err    string
+	}{
+		{name: "unknown exporter", config: TracerConfig{Exporter: "jaeger", SampleRatio: 1}, err: `unknown trace exporter "jaeger"`},
+		{name: "negative ratio", config: TracerConfig{Exporter: ExporterNone, SampleRatio: -0.1}, err: "trace sample ratio must be between 0 and 1, got -0.1"},
+		{name: "ratio above one", config: TracerConfig{Exporter: ExporterNone, SampleRatio: 2}, err: "trace sample ratio must be between 0 and 1, got 2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := InitTracer(context.Background(), tt.config, resource.Empty())
+			assert.EqualError(t, err, tt.err)
+		})
+	}
+}
+
+func TestInitTracerWithoutExporter(t *testing.T) {
+	tp, err := InitTracer(context.Background(), TracerConfig{Exporter: ExporterNone, SampleRatio: 1}, resource.Empty())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = tp.Shutdown(context.Background()) })
+
+	_, span := tp.Tracer("test").Start(context.Background(), "span")
+	defer span.End()
+
+	assert.False(t, span.IsRecording())
+}
+
+func TestNewSampler(t *testing.T) {
+	sampledParent := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{1},
+		SpanID:     trace.SpanID{1},
+		TraceFlags: trace.FlagsSampled,
+		Remote:     true,
+	})
+	ctx := trace.ContextWithRemoteSpanContext(context.Background(), sampledParent)
+
+	tests := []struct {
+		name    string
+		config  TracerConfig
+		sampled bool
+	}{
+		{name: "ratio ignores the parent", config: TracerConfig{SampleRatio: 0}, sampled: false},
+		{name: "parent based follows the parent", config: TracerConfig{SampleRatio: 0, ParentBased: true}, sampled: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tp, err := InitTracer(context.Background(), TracerConfig{Exporter: ExporterStdout, SampleRatio: tt.config.SampleRatio, ParentBased: tt.config.ParentBased}, resource.Empty())
+			require.NoError(t, err)
+			t.Cleanup(func() { _ = tp.Shutdown(context.Background()) })
+
+			_, span := tp.Tracer("test").Start(ctx, "span")
+			defer span.End()
+
+			assert.Equal(t, tt.sampled, span.SpanContext().IsSampled())
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package reference
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/rubenpad/srs/internal/domain/entity"
+)
+
+// CompanyFileSource reads curated company reference data from a JSON file
+// holding an array of companies.
+type CompanyFileSource struct {
+	path string
+}
+
+func NewCompanyFileSource(path string) *CompanyFileSource {
+	return &CompanyFileSource{path}
+}
+
+func (cfs *CompanyFileSource) GetCompanies(ctx context.Context) ([]entity.Company, error) {
+	content, err := os.ReadFile(cfs.path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading company reference data: %w", err)
+	}
+
+	var companies []entity.Company
+	if err := json.Unmarshal(content, &companies); err != nil {
+		return nil, fmt.Errorf("error decoding company reference data: %w", err)
+	}
+
+	for i := range companies {
+		companies[i].Ticker = strings.ToUpper(companies[i].Ticker)
+		for j := range companies[i].SymbolHistory {
+			companies[i].SymbolHistory[j].FromTicker = strings.ToUpper(companies[i].SymbolHistory[j].FromTicker)
+			companies[i].SymbolHistory[j].ToTicker = companies[i].Ticker
+		}
+	}
+
+	return companies, nil
+}
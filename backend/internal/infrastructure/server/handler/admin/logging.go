@@ -0,0 +1,57 @@
+package admin
+
+import (
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rubenpad/srs/internal/infrastructure/logging"
+	"github.com/rubenpad/srs/internal/infrastructure/server/problem"
+)
+
+type loggingConfiguration struct {
+	Level  *string `json:"level,omitempty"`
+	Format *string `json:"format,omitempty"`
+}
+
+// GetLogging returns the current log level and format.
+func GetLogging(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, currentLogging())
+}
+
+// UpdateLogging changes the log level, the format or both without restarting
+// the server. Nothing is changed when any of them is invalid.
+func UpdateLogging(ctx *gin.Context) {
+	var request loggingConfiguration
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		problem.BadRequest(ctx, "invalid logging configuration body")
+		return
+	}
+
+	previousLevel := logging.Level()
+	if request.Level != nil {
+		if err := logging.SetLevel(*request.Level); err != nil {
+			problem.BadRequest(ctx, err.Error())
+			return
+		}
+	}
+
+	if request.Format != nil {
+		if err := logging.SetFormat(*request.Format); err != nil {
+			_ = logging.SetLevel(previousLevel)
+			problem.BadRequest(ctx, err.Error())
+			return
+		}
+	}
+
+	current := currentLogging()
+	slog.WarnContext(ctx, "logging configuration changed", "level", *current.Level, "format", *current.Format)
+	ctx.JSON(http.StatusOK, current)
+}
+
+func currentLogging() loggingConfiguration {
+	level := strings.ToLower(logging.Level())
+	format := logging.Format()
+	return loggingConfiguration{Level: &level, Format: &format}
+}
@@ -0,0 +1,105 @@
+package alert
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rubenpad/srs/internal/domain/entity"
+	"github.com/rubenpad/srs/internal/domain/service"
+	"github.com/rubenpad/srs/internal/infrastructure/server/middleware/pagination"
+	"github.com/rubenpad/srs/internal/infrastructure/server/problem"
+)
+
+type alertRuleRequest struct {
+	Name       string   `json:"name"`
+	Expression string   `json:"expression"`
+	Notifiers  []string `json:"notifiers"`
+	Enabled    *bool    `json:"enabled"`
+}
+
+type AlertController struct {
+	alertService *service.AlertService
+}
+
+func NewAlertController(alertService *service.AlertService) *AlertController {
+	return &AlertController{alertService}
+}
+
+func (ac *AlertController) GetAlerts(ctx *gin.Context) {
+	nextPage := ctx.GetString(pagination.NextPageKey)
+	pageSize := ctx.GetInt(pagination.PageSizeKey)
+
+	alerts, err := ac.alertService.GetAlerts(ctx, nextPage, pageSize)
+	if errors.Is(err, entity.ErrInvalidCursor) {
+		problem.BadRequest(ctx, "nextPage is not a valid cursor")
+		return
+	}
+
+	if err != nil {
+		problem.InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, alerts)
+}
+
+func (ac *AlertController) GetRules(ctx *gin.Context) {
+	rules, err := ac.alertService.GetRules(ctx)
+	if err != nil {
+		problem.InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, rules)
+}
+
+func (ac *AlertController) CreateRule(ctx *gin.Context) {
+	var request alertRuleRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		problem.BadRequest(ctx, "invalid alert rule body")
+		return
+	}
+
+	rule := entity.AlertRule{
+		Name:       request.Name,
+		Expression: request.Expression,
+		Notifiers:  request.Notifiers,
+		Enabled:    request.Enabled == nil || *request.Enabled,
+	}
+
+	created, err := ac.alertService.CreateRule(ctx, rule)
+	if errors.Is(err, entity.ErrInvalidAlertRule) {
+		problem.BadRequest(ctx, err.Error())
+		return
+	}
+
+	if err != nil {
+		problem.InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, created)
+}
+
+func (ac *AlertController) DeleteRule(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		problem.BadRequest(ctx, "id must be an integer")
+		return
+	}
+
+	err = ac.alertService.DeleteRule(ctx, id)
+	if errors.Is(err, entity.ErrAlertRuleNotFound) {
+		problem.NotFound(ctx, "alert rule not found")
+		return
+	}
+
+	if err != nil {
+		problem.InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
@@ -0,0 +1,51 @@
+package alert
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rubenpad/srs/internal/domain/entity"
+	"github.com/rubenpad/srs/internal/domain/service"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeAlertRepository struct {
+	entity.IAlertRepository
+	err error
+}
+
+func (f *fakeAlertRepository) GetEvents(ctx context.Context, nextPage string, pageSize int) ([]entity.AlertEvent, error) {
+	return []entity.AlertEvent{}, f.err
+}
+
+func TestGetAlerts(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{"page", nil, http.StatusOK},
+		{"invalid cursor", entity.ErrInvalidCursor, http.StatusBadRequest},
+		{"repository error", errors.New("error getting alert events"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := NewAlertController(service.NewAlertService(&fakeAlertRepository{err: tt.err}, nil))
+
+			engine := gin.New()
+			engine.GET("/alerts", controller.GetAlerts)
+
+			recorder := httptest.NewRecorder()
+			engine.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/alerts", nil))
+
+			assert.Equal(t, tt.status, recorder.Code)
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package company
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rubenpad/srs/internal/domain/entity"
+	"github.com/rubenpad/srs/internal/domain/service"
+	"github.com/rubenpad/srs/internal/infrastructure/server/problem"
+)
+
+type CompanyController struct {
+	companyService *service.CompanyService
+}
+
+func NewCompanyController(companyService *service.CompanyService) *CompanyController {
+	return &CompanyController{companyService}
+}
+
+func (cc *CompanyController) GetCompany(ctx *gin.Context) {
+	company, err := cc.companyService.GetCompany(ctx, ctx.Param("ticker"))
+
+	if errors.Is(err, entity.ErrCompanyNotFound) {
+		problem.NotFound(ctx, "company not found")
+		return
+	}
+
+	if err != nil {
+		problem.InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.Header("Cache-Control", "private, max-age=900")
+	ctx.JSON(http.StatusOK, company)
+}
+
+func (cc *CompanyController) LoadCompaniesData(ctx *gin.Context) {
+	go cc.companyService.LoadCompaniesData(context.WithoutCancel(ctx.Request.Context()))
+
+	ctx.JSON(http.StatusAccepted, gin.H{})
+}
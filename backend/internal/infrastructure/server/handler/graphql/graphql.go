@@ -0,0 +1,49 @@
+package graphql
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/graphql-go/graphql"
+	"github.com/rubenpad/srs/internal/domain/service"
+	schema "github.com/rubenpad/srs/internal/infrastructure/graphql"
+	"github.com/rubenpad/srs/internal/infrastructure/server/problem"
+)
+
+type graphqlRequest struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName"`
+	Variables     map[string]any `json:"variables"`
+}
+
+type GraphqlController struct {
+	schema             graphql.Schema
+	stockRatingService *service.StockRatingService
+}
+
+func NewGraphqlController(schema graphql.Schema, stockRatingService *service.StockRatingService) *GraphqlController {
+	return &GraphqlController{schema, stockRatingService}
+}
+
+func (gc *GraphqlController) Query(ctx *gin.Context) {
+	var request graphqlRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil || request.Query == "" {
+		problem.BadRequest(ctx, "body must be a JSON object with a query")
+		return
+	}
+
+	if err := schema.CheckLimits(request.Query, request.OperationName, request.Variables); err != nil {
+		problem.BadRequest(ctx, err.Error())
+		return
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:         gc.schema,
+		RequestString:  request.Query,
+		OperationName:  request.OperationName,
+		VariableValues: request.Variables,
+		Context:        schema.WithLoaders(ctx.Request.Context(), gc.stockRatingService),
+	})
+
+	ctx.JSON(http.StatusOK, result)
+}
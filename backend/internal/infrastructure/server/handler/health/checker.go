@@ -0,0 +1,179 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	StatusUp       = "up"
+	StatusDegraded = "degraded"
+	StatusDown     = "down"
+	// StatusMigrating reports the server unready while it migrates the
+	// database.
+	StatusMigrating = "migrating"
+)
+
+const defaultTimeout = 2 * time.Second
+
+// Check is a dependency verified before the server is reported ready.
+type Check struct {
+	Name string
+	// Critical checks make the server unready when they fail, the others only
+	// report it as degraded.
+	Critical bool
+	// Timeout bounds every run of the check, 2 seconds by default.
+	Timeout time.Duration
+	// CacheFor reuses the last result for a while, to spare rate limited
+	// services from the probes.
+	CacheFor time.Duration
+	Run      func(ctx context.Context) error
+}
+
+type CheckResult struct {
+	Name     string `json:"name"`
+	Status   string `json:"status"`
+	Critical bool   `json:"critical"`
+	Duration string `json:"duration"`
+	Error    string `json:"error,omitempty"`
+}
+
+type Report struct {
+	Status string        `json:"status"`
+	Checks []CheckResult `json:"checks"`
+}
+
+type cachedCheck struct {
+	Check
+
+	mu        sync.Mutex
+	result    CheckResult
+	checkedAt time.Time
+}
+
+// Checker runs the readiness checks.
+type Checker struct {
+	checks []*cachedCheck
+	held   atomic.Pointer[string]
+}
+
+func NewChecker(checks ...Check) *Checker {
+	checker := &Checker{}
+	for _, check := range checks {
+		checker.Add(check)
+	}
+
+	return checker
+}
+
+func (c *Checker) Add(check Check) {
+	if check.Timeout == 0 {
+		check.Timeout = defaultTimeout
+	}
+
+	c.checks = append(c.checks, &cachedCheck{Check: check})
+}
+
+// Hold makes the server unready with status, without running the checks,
+// until Release.
+func (c *Checker) Hold(status string) {
+	c.held.Store(&status)
+}
+
+func (c *Checker) Release() {
+	c.held.Store(nil)
+}
+
+// Check runs every check concurrently. The report is down when a critical
+// check fails and degraded when any other one does.
+func (c *Checker) Check(ctx context.Context) Report {
+	if status := c.held.Load(); status != nil {
+		return Report{Status: *status, Checks: []CheckResult{}}
+	}
+
+	results := make([]CheckResult, len(c.checks))
+
+	var wg sync.WaitGroup
+	for i, check := range c.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = check.run(ctx)
+		}()
+	}
+	wg.Wait()
+
+	report := Report{Status: StatusUp, Checks: results}
+	for _, result := range results {
+		if result.Status == StatusUp {
+			continue
+		}
+
+		if result.Critical {
+			report.Status = StatusDown
+		} else if report.Status == StatusUp {
+			report.Status = StatusDegraded
+		}
+	}
+
+	return report
+}
+
+// Ready answers 503 Service Unavailable while a critical check fails or the
+// checker is held.
+func (c *Checker) Ready(ctx *gin.Context) {
+	report := c.Check(ctx)
+
+	status := http.StatusOK
+	if report.Status != StatusUp && report.Status != StatusDegraded {
+		status = http.StatusServiceUnavailable
+	}
+
+	ctx.JSON(status, report)
+}
+
+// Live answers as long as the server can handle requests, whatever the state
+// of its dependencies.
+func Live(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, Report{Status: StatusUp, Checks: []CheckResult{}})
+}
+
+func (cc *cachedCheck) run(ctx context.Context) CheckResult {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+
+	if cc.CacheFor > 0 && !cc.checkedAt.IsZero() && time.Since(cc.checkedAt) < cc.CacheFor {
+		return cc.result
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, cc.Timeout)
+	defer cancel()
+
+	// A check ignoring its context must not hold the probe past the timeout.
+	done := make(chan error, 1)
+	start := time.Now()
+	go func() { done <- cc.Run(ctx) }()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = fmt.Errorf("timed out after %s", cc.Timeout)
+	}
+
+	result := CheckResult{Name: cc.Name, Status: StatusUp, Critical: cc.Critical, Duration: time.Since(start).Round(time.Millisecond).String()}
+	if err != nil {
+		result.Status = StatusDown
+		result.Error = err.Error()
+	}
+
+	cc.result = result
+	cc.checkedAt = time.Now()
+	return result
+}
@@ -0,0 +1,120 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func succeed(ctx context.Context) error { return nil }
+
+func fail(ctx context.Context) error { return errors.New("connection refused") }
+
+func TestReady(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name   string
+		checks []Check
+		status int
+		report string
+	}{
+		{
+			name:   "all checks pass",
+			checks: []Check{{Name: "database", Critical: true, Run: succeed}, {Name: "upstream", Run: succeed}},
+			status: http.StatusOK,
+			report: StatusUp,
+		},
+		{
+			name:   "a non critical check fails",
+			checks: []Check{{Name: "database", Critical: true, Run: succeed}, {Name: "upstream", Run: fail}},
+			status: http.StatusOK,
+			report: StatusDegraded,
+		},
+		{
+			name:   "a critical check fails",
+			checks: []Check{{Name: "database", Critical: true, Run: fail}, {Name: "upstream", Run: fail}},
+			status: http.StatusServiceUnavailable,
+			report: StatusDown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine := gin.New()
+			engine.GET("/ready", NewChecker(tt.checks...).Ready)
+
+			recorder := httptest.NewRecorder()
+			engine.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/ready", nil))
+			assert.Equal(t, tt.status, recorder.Code)
+
+			var report Report
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &report))
+			assert.Equal(t, tt.report, report.Status)
+			require.Len(t, report.Checks, len(tt.checks))
+			for i, check := range tt.checks {
+				assert.Equal(t, check.Name, report.Checks[i].Name)
+			}
+		})
+	}
+}
+
+func TestCheckTimeout(t *testing.T) {
+	blocked := make(chan struct{})
+	t.Cleanup(func() { close(blocked) })
+
+	checker := NewChecker(Check{Name: "stuck", Critical: true, Timeout: 10 * time.Millisecond, Run: func(ctx context.Context) error {
+		<-blocked
+		return nil
+	}})
+
+	report := checker.Check(context.Background())
+	assert.Equal(t, StatusDown, report.Status)
+	assert.Equal(t, "timed out after 10ms", report.Checks[0].Error)
+}
+
+func TestCheckCache(t *testing.T) {
+	runs := 0
+	checker := NewChecker(Check{Name: "finnhub", CacheFor: time.Minute, Run: func(ctx context.Context) error {
+		runs++
+		return nil
+	}})
+
+	checker.Check(context.Background())
+	checker.Check(context.Background())
+	assert.Equal(t, 1, runs)
+}
+
+func TestHold(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	runs := 0
+	checker := NewChecker(Check{Name: "database", Critical: true, Run: func(ctx context.Context) error {
+		runs++
+		return nil
+	}})
+
+	engine := gin.New()
+	engine.GET("/ready", checker.Ready)
+
+	checker.Hold(StatusMigrating)
+	recorder := httptest.NewRecorder()
+	engine.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/ready", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+	assert.JSONEq(t, `{"status": "migrating", "checks": []}`, recorder.Body.String())
+	assert.Zero(t, runs)
+
+	checker.Release()
+	recorder = httptest.NewRecorder()
+	engine.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/ready", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, 1, runs)
+}
@@ -0,0 +1,77 @@
+package sector
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rubenpad/srs/internal/domain/entity"
+	"github.com/rubenpad/srs/internal/domain/service"
+	"github.com/rubenpad/srs/internal/infrastructure/server/middleware/pagination"
+	"github.com/rubenpad/srs/internal/infrastructure/server/problem"
+)
+
+const (
+	defaultWindow = "30d"
+	maxWindowDays = 365
+)
+
+type SectorController struct {
+	sectorService *service.SectorService
+}
+
+func NewSectorController(sectorService *service.SectorService) *SectorController {
+	return &SectorController{sectorService}
+}
+
+func (sc *SectorController) GetSectors(ctx *gin.Context) {
+	window, err := parseWindow(ctx.DefaultQuery("window", defaultWindow))
+	if err != nil {
+		problem.BadRequest(ctx, err.Error())
+		return
+	}
+
+	sectors, err := sc.sectorService.GetSectors(ctx, window)
+	if err != nil {
+		problem.InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.Header("Cache-Control", "private, max-age=900")
+	ctx.JSON(http.StatusOK, sectors)
+}
+
+func (sc *SectorController) GetSectorRecommendations(ctx *gin.Context) {
+	window, err := parseWindow(ctx.DefaultQuery("window", defaultWindow))
+	if err != nil {
+		problem.BadRequest(ctx, err.Error())
+		return
+	}
+
+	sortBy := ctx.DefaultQuery("sort", entity.RecommendationSortDefault)
+	if sortBy != entity.RecommendationSortDefault && sortBy != entity.RecommendationSortUpside {
+		problem.BadRequest(ctx, "sort must be empty or "+entity.RecommendationSortUpside)
+		return
+	}
+
+	recommendations, err := sc.sectorService.GetSectorRecommendations(ctx, ctx.Param("sector"), window, ctx.GetInt(pagination.PageSizeKey), sortBy)
+	if err != nil {
+		problem.InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, recommendations)
+}
+
+// parseWindow parses windows expressed in days such as "7d" or "90d".
+func parseWindow(value string) (time.Duration, error) {
+	days, err := strconv.Atoi(strings.TrimSuffix(value, "d"))
+	if err != nil || !strings.HasSuffix(value, "d") || days < 1 || days > maxWindowDays {
+		return 0, fmt.Errorf("window must be a number of days between 1d and %dd", maxWindowDays)
+	}
+
+	return time.Duration(days) * 24 * time.Hour, nil
+}
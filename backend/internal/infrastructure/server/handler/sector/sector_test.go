@@ -0,0 +1,65 @@
+package sector
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rubenpad/srs/internal/domain/entity"
+	"github.com/rubenpad/srs/internal/domain/service"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeSectorRepository struct {
+	sector string
+	since  time.Time
+}
+
+func (f *fakeSectorRepository) GetTickerActivity(ctx context.Context, since time.Time) ([]entity.TickerActivity, error) {
+	return nil, nil
+}
+
+func (f *fakeSectorRepository) GetSectorRecommendations(ctx context.Context, sector string, since time.Time, pageSize int, sortBy string) ([]entity.StockRatingAggregate, error) {
+	f.sector, f.since = sector, since
+	return []entity.StockRatingAggregate{{Ticker: "XOM"}}, nil
+}
+
+func TestGetSectorRecommendations(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	today := time.Now().Truncate(24 * time.Hour)
+
+	tests := []struct {
+		name   string
+		query  string
+		status int
+		since  time.Time
+	}{
+		{"default window", "", http.StatusOK, today.AddDate(0, 0, -30)},
+		{"given window", "?window=7d&sort=upside", http.StatusOK, today.AddDate(0, 0, -7)},
+		{"invalid window", "?window=7w", http.StatusBadRequest, time.Time{}},
+		{"invalid sort", "?sort=score", http.StatusBadRequest, time.Time{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repository := &fakeSectorRepository{}
+			controller := NewSectorController(service.NewSectorService(repository, nil))
+
+			engine := gin.New()
+			engine.GET("/sectors/:sector/recommendations", controller.GetSectorRecommendations)
+
+			recorder := httptest.NewRecorder()
+			engine.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/sectors/Energy/recommendations"+tt.query, nil))
+
+			assert.Equal(t, tt.status, recorder.Code)
+			assert.Equal(t, tt.since, repository.since)
+			if tt.status == http.StatusOK {
+				assert.Equal(t, "Energy", repository.sector)
+				assert.Contains(t, recorder.Body.String(), `"ticker":"XOM"`)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package stock
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rubenpad/srs/internal/domain/entity"
+	"github.com/rubenpad/srs/internal/domain/service"
+	"github.com/rubenpad/srs/internal/infrastructure/server/problem"
+)
+
+const (
+	dateLayout        = "2006-01-02"
+	defaultCandleDays = 90
+	defaultLoadDays   = 365
+)
+
+type StockPriceController struct {
+	stockPriceService *service.StockPriceService
+}
+
+func NewStockPriceController(stockPriceService *service.StockPriceService) *StockPriceController {
+	return &StockPriceController{stockPriceService}
+}
+
+func (spc *StockPriceController) GetCandles(ctx *gin.Context) {
+	ticker := strings.ToUpper(ctx.Param("ticker"))
+
+	from, to, err := parseDateRange(ctx, defaultCandleDays)
+	if err != nil {
+		problem.BadRequest(ctx, err.Error())
+		return
+	}
+
+	resolution := ctx.DefaultQuery("resolution", entity.ResolutionDay)
+	if resolution != entity.ResolutionDay && resolution != entity.ResolutionWeek && resolution != entity.ResolutionMonth {
+		problem.BadRequest(ctx, fmt.Sprintf("resolution must be one of %s, %s or %s", entity.ResolutionDay, entity.ResolutionWeek, entity.ResolutionMonth))
+		return
+	}
+
+	candles, err := spc.stockPriceService.GetCandles(ctx, ticker, from, to, resolution)
+	if err != nil {
+		problem.InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.Header("Cache-Control", "private, max-age=900")
+	ctx.JSON(http.StatusOK, gin.H{"data": candles})
+}
+
+func (spc *StockPriceController) LoadStockPriceData(ctx *gin.Context) {
+	from, to, err := parseDateRange(ctx, defaultLoadDays)
+	if err != nil {
+		problem.BadRequest(ctx, err.Error())
+		return
+	}
+
+	go spc.stockPriceService.LoadStockPricesData(context.WithoutCancel(ctx.Request.Context()), from, to)
+
+	ctx.JSON(http.StatusAccepted, gin.H{})
+}
+
+// parseDateRange reads the from and to query parameters, defaulting to the
+// last defaultDays days.
+func parseDateRange(ctx *gin.Context, defaultDays int) (time.Time, time.Time, error) {
+	to := time.Now().UTC().Truncate(24 * time.Hour)
+	from := to.AddDate(0, 0, -defaultDays)
+
+	if value := ctx.Query("to"); value != "" {
+		parsed, err := time.Parse(dateLayout, value)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("to must be a date with format %s", dateLayout)
+		}
+		to = parsed
+	}
+
+	if value := ctx.Query("from"); value != "" {
+		parsed, err := time.Parse(dateLayout, value)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("from must be a date with format %s", dateLayout)
+		}
+		from = parsed
+	}
+
+	if from.After(to) {
+		return time.Time{}, time.Time{}, fmt.Errorf("from must be before to")
+	}
+
+	return from, to, nil
+}
@@ -1,12 +1,17 @@
 package stock
 
 import (
+	"context"
+	"errors"
 	"log/slog"
 	"net/http"
 
+	"github.com/rubenpad/srs/internal/domain/entity"
 	"github.com/rubenpad/srs/internal/domain/service"
 	"github.com/rubenpad/srs/internal/infrastructure/server/middleware/pagination"
 	"github.com/rubenpad/srs/internal/infrastructure/server/middleware/search"
+	"github.com/rubenpad/srs/internal/infrastructure/server/problem"
+	"github.com/rubenpad/srs/internal/infrastructure/server/representation"
 
 	"github.com/gin-gonic/gin"
 )
@@ -21,49 +26,78 @@ func NewStockRatingController(stockRatingService *service.StockRatingService) *S
 
 func (src *StockRatingController) GetStockDetails(ctx *gin.Context) {
 	ticker := ctx.Param("ticker")
-	stockDetails := src.stockRatingService.GetStockDetails(ctx, ticker)
+	stockDetails, err := src.stockRatingService.GetStockDetails(ctx, ticker)
 
-	if stockDetails == nil {
-		ctx.JSON(http.StatusNotFound, gin.H{
-			"code":    "not_found",
-			"message": "stock details not found",
-		})
+	switch {
+	case errors.Is(err, entity.ErrStockNotFound):
+		problem.NotFound(ctx, "stock details not found")
+		return
+	case errors.Is(err, entity.ErrUpstreamTimeout):
+		problem.Write(ctx, problem.New(http.StatusGatewayTimeout, problem.CodeUpstreamTimeout, "stock details providers did not respond in time").
+			With("sections", stockDetails.Status))
+		return
+	case err != nil:
+		slog.ErrorContext(ctx, err.Error(), "ticker", ticker)
+		problem.Write(ctx, problem.New(http.StatusBadGateway, problem.CodeUpstreamError, "stock details providers failed").
+			With("sections", stockDetails.Status))
 		return
 	}
 
-	ctx.Header("Cache-Control", "private, max-age=900")
+	// Details missing a section are not cached, so the next request retries
+	// the providers that failed.
+	if stockDetails.Status.IsOk() {
+		ctx.Header("Cache-Control", "private, max-age=900")
+	} else {
+		ctx.Header("Cache-Control", "no-store")
+	}
 	ctx.JSON(http.StatusOK, stockDetails)
 }
 
+// GetStockRatings answers /api/v2: numeric targets, paged with the cursor of
+// the last rating.
 func (src *StockRatingController) GetStockRatings(ctx *gin.Context) {
-	search := ctx.GetString(search.SearchKey)
-	nextPage := ctx.GetString(pagination.NextPageKey)
-	pageSize := ctx.GetInt(pagination.PageSizeKey)
+	after, err := entity.ParseStockRatingCursor(ctx.GetString(pagination.NextPageKey))
+	if err != nil {
+		problem.BadRequest(ctx, "nextPage is not a valid cursor")
+		return
+	}
 
-	stockRatings, err := src.stockRatingService.GetStockRatings(ctx, nextPage, pageSize, search)
+	stockRatings, err := src.stockRatingService.GetStockRatings(ctx, after, ctx.GetInt(pagination.PageSizeKey), ctx.GetString(search.SearchKey))
 	if err != nil {
-		slog.Error(err.Error())
-		ctx.JSON(http.StatusInternalServerError, gin.H{
-			"code":    "internal_server_error",
-			"message": "error processing the request",
-		})
+		problem.InternalServerError(ctx, err)
 		return
 	}
 
 	ctx.Header("Cache-Control", "private, max-age=900")
-	ctx.JSON(http.StatusOK, stockRatings)
+	ctx.JSON(http.StatusOK, representation.NewStockRatingPageV2(stockRatings.Data, stockRatings.NextPage))
+}
+
+// GetStockRatingsV1 answers /api/v1: text targets, paged by ticker.
+func (src *StockRatingController) GetStockRatingsV1(ctx *gin.Context) {
+	after := entity.StockRatingCursor{Ticker: ctx.GetString(pagination.NextPageKey)}
+
+	stockRatings, err := src.stockRatingService.GetStockRatings(ctx, after, ctx.GetInt(pagination.PageSizeKey), ctx.GetString(search.SearchKey))
+	if err != nil {
+		problem.InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.Header("Cache-Control", "private, max-age=900")
+	ctx.JSON(http.StatusOK, representation.NewStockRatingPageV1(stockRatings.Data, stockRatings.NextPage))
 }
 
 func (src *StockRatingController) GetStockRecommendations(ctx *gin.Context) {
 	pageSize := ctx.GetInt(pagination.PageSizeKey)
 
-	stockRecommendations, err := src.stockRatingService.GetStockRecommendations(ctx, pageSize)
+	sortBy := ctx.DefaultQuery("sort", entity.RecommendationSortDefault)
+	if sortBy != entity.RecommendationSortDefault && sortBy != entity.RecommendationSortUpside {
+		problem.BadRequest(ctx, "sort must be empty or "+entity.RecommendationSortUpside)
+		return
+	}
+
+	stockRecommendations, err := src.stockRatingService.GetStockRecommendations(ctx, pageSize, sortBy, "")
 	if err != nil {
-		slog.Error(err.Error())
-		ctx.JSON(http.StatusInternalServerError, gin.H{
-			"code":    "internal_server_error",
-			"message": "error processing the request",
-		})
+		problem.InternalServerError(ctx, err)
 		return
 	}
 
@@ -71,7 +105,7 @@ func (src *StockRatingController) GetStockRecommendations(ctx *gin.Context) {
 }
 
 func (src *StockRatingController) LoadStockRatingData(ctx *gin.Context) {
-	go src.stockRatingService.LoadStockRatingsData(ctx, ctx.Query("useCustomFormat") == "true")
+	go src.stockRatingService.LoadStockRatingsData(context.WithoutCancel(ctx.Request.Context()), ctx.Query("useCustomFormat") == "true")
 
 	ctx.JSON(http.StatusAccepted, gin.H{})
 }
@@ -0,0 +1,54 @@
+package stock
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rubenpad/srs/internal/domain/entity"
+	"github.com/rubenpad/srs/internal/domain/service"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeStockRatingApi struct {
+	entity.IStockRatingApi
+	details *entity.StockDetails
+}
+
+func (f *fakeStockRatingApi) GetStockDetails(ctx context.Context, ticker string) (*entity.StockDetails, error) {
+	return f.details, nil
+}
+
+func TestGetStockDetailsCaching(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ok := entity.StockDetailsStatus{Quote: entity.NewSectionOk(), Recommendations: entity.NewSectionOk(), KeyFacts: entity.NewSectionOk()}
+	partial := ok
+	partial.KeyFacts = entity.NewSectionError("upstream_error", "key facts unavailable")
+
+	tests := []struct {
+		name         string
+		status       entity.StockDetailsStatus
+		cacheControl string
+	}{
+		{"every section ok", ok, "private, max-age=900"},
+		{"a section failed", partial, "no-store"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stockRatingApi := &fakeStockRatingApi{details: &entity.StockDetails{Status: tt.status}}
+			controller := NewStockRatingController(service.NewStockRatingService(nil, stockRatingApi, nil, nil))
+
+			engine := gin.New()
+			engine.GET("/stock-details/:ticker", controller.GetStockDetails)
+
+			recorder := httptest.NewRecorder()
+			engine.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/stock-details/AAPL", nil))
+
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			assert.Equal(t, tt.cacheControl, recorder.Header().Get("Cache-Control"))
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package stream
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rubenpad/srs/internal/domain/entity"
+	"github.com/rubenpad/srs/internal/infrastructure/stream"
+)
+
+const heartbeatInterval = 15 * time.Second
+
+type StreamController struct {
+	hub    *stream.Hub
+	encode func(entity.StockRating) any
+}
+
+// NewStreamController streams the hub events, sending the ratings in the
+// shape returned by encode.
+func NewStreamController[T any](hub *stream.Hub, encode func(entity.StockRating) T) *StreamController {
+	return &StreamController{hub, func(rating entity.StockRating) any { return encode(rating) }}
+}
+
+// StreamStockRatings sends a "rating" event for every saved stock rating,
+// only those of the tickers given in the ticker query parameter when present,
+// and a "progress" event for every ingestion progress update.
+func (sc *StreamController) StreamStockRatings(ctx *gin.Context) {
+	subscription := sc.hub.Subscribe(tickers(ctx))
+	defer sc.hub.Unsubscribe(subscription)
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+
+	ctx.Header("Content-Type", "text/event-stream")
+	ctx.Header("Cache-Control", "no-cache")
+	ctx.Header("Connection", "keep-alive")
+	ctx.Header("X-Accel-Buffering", "no")
+
+	// The headers are flushed right away so the client sees the stream open
+	// before the first event or heartbeat.
+	ctx.Writer.WriteHeader(http.StatusOK)
+	ctx.Writer.Flush()
+
+	ctx.Stream(func(w io.Writer) bool {
+		select {
+		case <-ctx.Request.Context().Done():
+			return false
+		case <-heartbeat.C:
+			_, err := io.WriteString(w, ": heartbeat\n\n")
+			return err == nil
+		case event, open := <-subscription.Events:
+			if !open {
+				return false
+			}
+
+			data := event.Data
+			if rating, ok := data.(entity.StockRating); ok {
+				data = sc.encode(rating)
+			}
+
+			ctx.SSEvent(event.Name, data)
+			return true
+		}
+	})
+}
+
+func tickers(ctx *gin.Context) []string {
+	tickers := []string{}
+	for _, value := range ctx.QueryArray("ticker") {
+		for _, ticker := range strings.Split(value, ",") {
+			if ticker = strings.TrimSpace(ticker); ticker != "" {
+				tickers = append(tickers, ticker)
+			}
+		}
+	}
+
+	return tickers
+}
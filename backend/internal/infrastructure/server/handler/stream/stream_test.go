@@ -0,0 +1,51 @@
+package stream
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rubenpad/srs/internal/infrastructure/server/representation"
+	"github.com/rubenpad/srs/internal/infrastructure/stream"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStreamStockRatings(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	hub := stream.NewHub()
+	defer hub.Close()
+
+	engine := gin.New()
+	engine.GET("/api/stream/stock-ratings", NewStreamController(hub, representation.NewStockRatingV2).StreamStockRatings)
+	server := httptest.NewServer(engine)
+	defer server.Close()
+
+	// The request times out well before the first heartbeat, so the response
+	// only arrives if the headers are flushed when the client connects.
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/api/stream/stock-ratings", nil)
+	require.NoError(t, err)
+
+	response, err := http.DefaultClient.Do(request)
+	require.NoError(t, err)
+	defer response.Body.Close()
+
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Equal(t, "text/event-stream", response.Header.Get("Content-Type"))
+	assert.Equal(t, "no-cache", response.Header.Get("Cache-Control"))
+
+	hub.Publish(stream.Event{Name: stream.EventRating, Ticker: "AAPL", Data: map[string]string{"ticker": "AAPL"}})
+
+	line, err := bufio.NewReader(response.Body).ReadString('\n')
+	require.NoError(t, err)
+	assert.Equal(t, "event:rating", strings.TrimSpace(line))
+}
@@ -0,0 +1,116 @@
+package webhook
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rubenpad/srs/internal/domain/entity"
+	"github.com/rubenpad/srs/internal/domain/service"
+	"github.com/rubenpad/srs/internal/infrastructure/server/middleware/pagination"
+	"github.com/rubenpad/srs/internal/infrastructure/server/problem"
+)
+
+type webhookRequest struct {
+	Url    string   `json:"url"`
+	Events []string `json:"events"`
+	Secret string   `json:"secret"`
+}
+
+type WebhookController struct {
+	webhookService *service.WebhookService
+}
+
+func NewWebhookController(webhookService *service.WebhookService) *WebhookController {
+	return &WebhookController{webhookService}
+}
+
+func (wc *WebhookController) CreateWebhook(ctx *gin.Context) {
+	var request webhookRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		problem.BadRequest(ctx, "invalid webhook body")
+		return
+	}
+
+	webhook, err := wc.webhookService.CreateWebhook(ctx, entity.Webhook{
+		Url:    request.Url,
+		Events: request.Events,
+		Secret: request.Secret,
+	})
+
+	if errors.Is(err, entity.ErrInvalidWebhook) {
+		problem.BadRequest(ctx, err.Error())
+		return
+	}
+
+	if err != nil {
+		problem.InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, webhook)
+}
+
+func (wc *WebhookController) GetWebhooks(ctx *gin.Context) {
+	webhooks, err := wc.webhookService.GetWebhooks(ctx)
+	if err != nil {
+		problem.InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, webhooks)
+}
+
+func (wc *WebhookController) DeleteWebhook(ctx *gin.Context) {
+	id, ok := webhookID(ctx)
+	if !ok {
+		return
+	}
+
+	err := wc.webhookService.DeleteWebhook(ctx, id)
+	if errors.Is(err, entity.ErrWebhookNotFound) {
+		problem.NotFound(ctx, "webhook not found")
+		return
+	}
+
+	if err != nil {
+		problem.InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (wc *WebhookController) GetDeliveries(ctx *gin.Context) {
+	id, ok := webhookID(ctx)
+	if !ok {
+		return
+	}
+
+	nextPage := ctx.GetString(pagination.NextPageKey)
+	pageSize := ctx.GetInt(pagination.PageSizeKey)
+
+	deliveries, err := wc.webhookService.GetDeliveries(ctx, id, nextPage, pageSize)
+	if errors.Is(err, entity.ErrInvalidCursor) {
+		problem.BadRequest(ctx, "nextPage is not a valid cursor")
+		return
+	}
+
+	if err != nil {
+		problem.InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, deliveries)
+}
+
+func webhookID(ctx *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		problem.BadRequest(ctx, "id must be an integer")
+		return 0, false
+	}
+
+	return id, true
+}
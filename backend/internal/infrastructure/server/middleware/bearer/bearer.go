@@ -0,0 +1,26 @@
+package bearer
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rubenpad/srs/internal/infrastructure/server/problem"
+)
+
+// Middleware only lets through the requests with an "Authorization: Bearer"
+// header carrying token. The comparison takes the same time wherever the
+// tokens differ, so it does not leak how much of a guess was right.
+func Middleware(token string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		presented, ok := strings.CutPrefix(ctx.GetHeader("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(presented), []byte(token)) != 1 {
+			ctx.Header("WWW-Authenticate", "Bearer")
+			problem.Write(ctx, problem.New(http.StatusUnauthorized, problem.CodeUnauthorized, "a valid bearer token is required"))
+			return
+		}
+
+		ctx.Next()
+	}
+}
@@ -0,0 +1,46 @@
+package bearer
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name          string
+		authorization string
+		status        int
+	}{
+		{name: "valid token", authorization: "Bearer secret", status: http.StatusOK},
+		{name: "missing header", status: http.StatusUnauthorized},
+		{name: "wrong token", authorization: "Bearer secreT", status: http.StatusUnauthorized},
+		{name: "token prefix", authorization: "Bearer secre", status: http.StatusUnauthorized},
+		{name: "other scheme", authorization: "Basic secret", status: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine := gin.New()
+			engine.GET("/", Middleware("secret"), func(ctx *gin.Context) { ctx.Status(http.StatusOK) })
+
+			request := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.authorization != "" {
+				request.Header.Set("Authorization", tt.authorization)
+			}
+
+			recorder := httptest.NewRecorder()
+			engine.ServeHTTP(recorder, request)
+
+			assert.Equal(t, tt.status, recorder.Code)
+			if tt.status == http.StatusUnauthorized {
+				assert.Equal(t, "Bearer", recorder.Header().Get("WWW-Authenticate"))
+			}
+		})
+	}
+}
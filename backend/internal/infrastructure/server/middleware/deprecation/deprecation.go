@@ -0,0 +1,25 @@
+package deprecation
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Middleware marks the responses of a deprecated API version with the
+// Deprecation header (RFC 9745) and links to the same path under the
+// successor version prefix.
+func Middleware(deprecatedAt time.Time, prefix string, successorPrefix string) gin.HandlerFunc {
+	deprecation := fmt.Sprintf("@%d", deprecatedAt.Unix())
+
+	return func(ctx *gin.Context) {
+		successor := successorPrefix + strings.TrimPrefix(ctx.Request.URL.Path, prefix)
+
+		ctx.Header("Deprecation", deprecation)
+		ctx.Header("Link", fmt.Sprintf(`<%s>; rel="successor-version"`, successor))
+
+		ctx.Next()
+	}
+}
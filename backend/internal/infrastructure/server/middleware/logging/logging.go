@@ -31,10 +31,10 @@ func Middleware() gin.HandlerFunc {
 		method := c.Request.Method
 		statusCode := c.Writer.Status()
 
-		slog.Info("response",
-			slog.Duration("timestamp", time.Duration(timestamp.Unix())),
+		slog.InfoContext(c, "response",
+			slog.Time("timestamp", timestamp),
 			slog.Int("statusCode", statusCode),
-			slog.Duration("latency", time.Duration(latency.Milliseconds())),
+			slog.Duration("latency", latency),
 			slog.String("clientIP", clientIP),
 			slog.String("method", method),
 			slog.String("path", path),
@@ -0,0 +1,45 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	srsotel "github.com/rubenpad/srs/internal/infrastructure/otel"
+)
+
+// unmatchedRoute labels the requests that did not match any route, keeping
+// arbitrary paths out of the metric attributes.
+const unmatchedRoute = "unmatched"
+
+// Middleware records the duration of every request by method, route and
+// status code.
+func Middleware() gin.HandlerFunc {
+	duration, err := otel.Meter(srsotel.ScopeName).Float64Histogram("http.server.request.duration",
+		metric.WithDescription("Duration of the HTTP requests."),
+		metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries(srsotel.DurationBuckets...))
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	return func(ctx *gin.Context) {
+		start := time.Now()
+
+		ctx.Next()
+
+		route := ctx.FullPath()
+		if route == "" {
+			route = unmatchedRoute
+		}
+
+		duration.Record(ctx.Request.Context(), time.Since(start).Seconds(), metric.WithAttributes(
+			attribute.String("http.request.method", ctx.Request.Method),
+			attribute.String("http.route", route),
+			attribute.Int("http.response.status_code", ctx.Writer.Status()),
+		))
+	}
+}
@@ -0,0 +1,42 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestMiddleware(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	otel.SetMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))
+
+	engine := gin.New()
+	engine.Use(Middleware())
+	engine.GET("/api/companies/:ticker", func(ctx *gin.Context) { ctx.Status(http.StatusOK) })
+
+	for _, target := range []string{"/api/companies/AAPL", "/api/companies/MSFT", "/api/unknown"} {
+		engine.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, target, nil))
+	}
+
+	var metrics metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &metrics))
+	require.Len(t, metrics.ScopeMetrics, 1)
+	require.Len(t, metrics.ScopeMetrics[0].Metrics, 1)
+
+	counts := map[string]uint64{}
+	for _, point := range metrics.ScopeMetrics[0].Metrics[0].Data.(metricdata.Histogram[float64]).DataPoints {
+		route, _ := point.Attributes.Value(attribute.Key("http.route"))
+		counts[route.AsString()] += point.Count
+	}
+
+	assert.Equal(t, map[string]uint64{"/api/companies/:ticker": 2, unmatchedRoute: 1}, counts)
+}
@@ -2,10 +2,10 @@ package pagination
 
 import (
 	"fmt"
-	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"github.com/rubenpad/srs/internal/infrastructure/server/problem"
 )
 
 const minPageSize = 1
@@ -20,12 +20,12 @@ func Middleware() gin.HandlerFunc {
 
 		pageSizeValue, err := strconv.Atoi(pageSizeStrValue)
 		if err != nil {
-			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("%s parameter must be an integer", PageSizeKey)})
+			problem.BadRequest(ctx, fmt.Sprintf("%s parameter must be an integer", PageSizeKey))
 			return
 		}
 
 		if err := validatePageSize(pageSizeValue); err != nil {
-			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			problem.BadRequest(ctx, err.Error())
 			return
 		}
 
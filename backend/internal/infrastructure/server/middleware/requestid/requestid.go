@@ -0,0 +1,30 @@
+package requestid
+
+import (
+	"regexp"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rubenpad/srs/internal/infrastructure/logging"
+)
+
+const Header = "X-Request-ID"
+
+// validID limits the IDs taken from the clients to what is safe to log.
+var validID = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+// Middleware identifies every request with the X-Request-ID header sent by
+// the client, or a new UUID when it is missing or invalid. The ID is echoed
+// in the response and carried by the request context for the logs.
+func Middleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id := ctx.GetHeader(Header)
+		if !validID.MatchString(id) {
+			id = uuid.NewString()
+		}
+
+		ctx.Header(Header, id)
+		ctx.Request = ctx.Request.WithContext(logging.WithRequestID(ctx.Request.Context(), id))
+		ctx.Next()
+	}
+}
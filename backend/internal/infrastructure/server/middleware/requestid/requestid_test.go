@@ -0,0 +1,52 @@
+package requestid
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rubenpad/srs/internal/infrastructure/logging"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name     string
+		header   string
+		expected string
+	}{
+		{name: "honours the client ID", header: "checkout-42", expected: "checkout-42"},
+		{name: "generates a missing ID"},
+		{name: "replaces an invalid ID", header: "bad id\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var logged string
+			engine := gin.New()
+			engine.Use(Middleware())
+			engine.GET("/", func(ctx *gin.Context) {
+				logged = logging.RequestID(ctx.Request.Context())
+			})
+
+			request := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.header != "" {
+				request.Header.Set(Header, tt.header)
+			}
+			recorder := httptest.NewRecorder()
+			engine.ServeHTTP(recorder, request)
+
+			id := recorder.Header().Get(Header)
+			if tt.expected != "" {
+				assert.Equal(t, tt.expected, id)
+			} else {
+				assert.NoError(t, uuid.Validate(id))
+			}
+			assert.Equal(t, id, logged)
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package validation
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/legacy"
+	"github.com/gin-gonic/gin"
+	"github.com/rubenpad/srs/internal/infrastructure/server/problem"
+)
+
+// Middleware rejects the requests whose parameters or body do not match the
+// operation documented in doc. Requests to undocumented routes are left to
+// the router.
+func Middleware(doc *openapi3.T) (gin.HandlerFunc, error) {
+	router, err := legacy.NewRouter(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	options := &openapi3filter.Options{
+		AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+		MultiError:         false,
+	}
+
+	return func(ctx *gin.Context) {
+		route, pathParams, err := router.FindRoute(ctx.Request)
+		if err != nil {
+			ctx.Next()
+			return
+		}
+
+		request, err := validationRequest(ctx, route)
+		if err != nil {
+			problem.BadRequest(ctx, "request body could not be read")
+			return
+		}
+
+		input := &openapi3filter.RequestValidationInput{
+			Request:    request,
+			PathParams: pathParams,
+			Route:      route,
+			Options:    options,
+		}
+
+		if err := openapi3filter.ValidateRequest(ctx, input); err != nil {
+			problem.BadRequest(ctx, detail(err))
+			return
+		}
+
+		ctx.Next()
+	}, nil
+}
+
+// validationRequest returns a copy of the request for the validator, which
+// consumes the body. The handlers decode bodies as JSON whatever their
+// content type, so the copy is validated as JSON when the operation does not
+// document the content type the client sent.
+func validationRequest(ctx *gin.Context, route *routers.Route) (*http.Request, error) {
+	request := ctx.Request.Clone(ctx)
+	if ctx.Request.Body == nil || ctx.Request.Body == http.NoBody {
+		return request, nil
+	}
+
+	body, err := io.ReadAll(ctx.Request.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.Request.Body = io.NopCloser(bytes.NewReader(body))
+	request.Body = io.NopCloser(bytes.NewReader(body))
+
+	requestBody := route.Operation.RequestBody
+	if requestBody == nil || requestBody.Value == nil {
+		return request, nil
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(request.Header.Get("Content-Type"))
+	if requestBody.Value.GetMediaType(mediaType) == nil && requestBody.Value.GetMediaType("application/json") != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+
+	return request, nil
+}
+
+// detail describes err in one line, without the schema dumps of the
+// validator errors.
+func detail(err error) string {
+	var requestError *openapi3filter.RequestError
+	if !errors.As(err, &requestError) {
+		return err.Error()
+	}
+
+	reason := requestError.Reason
+	var schemaError *openapi3.SchemaError
+	if errors.As(requestError.Err, &schemaError) {
+		reason = schemaError.Reason
+		if pointer := schemaError.JSONPointer(); len(pointer) > 0 {
+			reason = fmt.Sprintf("%s: %s", strings.Join(pointer, "."), reason)
+		}
+	} else if requestError.Err != nil && reason == "" {
+		reason = requestError.Err.Error()
+	}
+
+	switch {
+	case requestError.Parameter != nil:
+		return fmt.Sprintf("%s parameter %s", requestError.Parameter.Name, reason)
+	case requestError.RequestBody != nil:
+		return fmt.Sprintf("request body %s", reason)
+	default:
+		return reason
+	}
+}
@@ -0,0 +1,56 @@
+package openapi
+
+import (
+	"context"
+	"embed"
+	"net/http"
+	"net/url"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gin-gonic/gin"
+)
+
+// Documents of the API versions, loadable with Load.
+const (
+	V1 = "v1.yaml"
+	V2 = "v2.yaml"
+)
+
+//go:embed *.yaml
+var specs embed.FS
+
+// Load parses and validates the embedded OpenAPI document of an API version,
+// resolving the references to the other versions documents.
+func Load(name string) (*openapi3.T, error) {
+	loader := openapi3.NewLoader()
+	loader.IsExternalRefsAllowed = true
+	loader.ReadFromURIFunc = func(_ *openapi3.Loader, location *url.URL) ([]byte, error) {
+		return specs.ReadFile(location.Path)
+	}
+
+	doc, err := loader.LoadFromFile(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := doc.Validate(context.Background()); err != nil {
+		return nil, err
+	}
+
+	return doc, nil
+}
+
+// Handler serves doc as JSON, with the references to other documents
+// copied into its components so it stands on its own.
+func Handler(doc *openapi3.T) (gin.HandlerFunc, error) {
+	doc.InternalizeRefs(context.Background(), nil)
+
+	body, err := doc.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx *gin.Context) {
+		ctx.Data(http.StatusOK, "application/json; charset=utf-8", body)
+	}, nil
+}
@@ -0,0 +1,128 @@
+package problem
+
+import (
+	"encoding/json"
+	"log/slog"
+	"maps"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+const ContentType = "application/problem+json"
+
+const (
+	CodeBadRequest          = "bad_request"
+	CodeUnauthorized        = "unauthorized"
+	CodeNotFound            = "not_found"
+	CodeMethodNotAllowed    = "method_not_allowed"
+	CodeNotImplemented      = "not_implemented"
+	CodeInternalServerError = "internal_server_error"
+	CodeUpstreamTimeout     = "upstream_timeout"
+	CodeUpstreamError       = "upstream_error"
+)
+
+// Problem is an RFC 7807 problem details body, the error envelope of every
+// endpoint. Code identifies the kind of problem for clients; Extensions are
+// serialised as additional top level members.
+type Problem struct {
+	Type       string         `json:"type"`
+	Title      string         `json:"title"`
+	Status     int            `json:"status"`
+	Detail     string         `json:"detail,omitempty"`
+	Instance   string         `json:"instance,omitempty"`
+	Code       string         `json:"code"`
+	Extensions map[string]any `json:"-"`
+}
+
+func New(status int, code string, detail string) *Problem {
+	return &Problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: detail,
+		Code:   code,
+	}
+}
+
+// With adds an extension member to the problem.
+func (p *Problem) With(key string, value any) *Problem {
+	if p.Extensions == nil {
+		p.Extensions = map[string]any{}
+	}
+
+	p.Extensions[key] = value
+	return p
+}
+
+func (p *Problem) MarshalJSON() ([]byte, error) {
+	type problem Problem
+	body, err := json.Marshal((*problem)(p))
+	if err != nil || len(p.Extensions) == 0 {
+		return body, err
+	}
+
+	members := map[string]any{}
+	if err := json.Unmarshal(body, &members); err != nil {
+		return nil, err
+	}
+
+	extensions := maps.Clone(p.Extensions)
+	maps.Copy(extensions, members)
+	return json.Marshal(extensions)
+}
+
+// Write sends the problem and aborts the remaining handlers.
+func Write(ctx *gin.Context, p *Problem) {
+	if p.Instance == "" {
+		p.Instance = ctx.Request.URL.Path
+	}
+
+	body, err := json.Marshal(p)
+	if err != nil {
+		slog.ErrorContext(ctx, "error encoding problem", "error", err)
+		ctx.AbortWithStatus(p.Status)
+		return
+	}
+
+	ctx.Abort()
+	ctx.Data(p.Status, ContentType, body)
+}
+
+func BadRequest(ctx *gin.Context, detail string) {
+	Write(ctx, New(http.StatusBadRequest, CodeBadRequest, detail))
+}
+
+func NotFound(ctx *gin.Context, detail string) {
+	Write(ctx, New(http.StatusNotFound, CodeNotFound, detail))
+}
+
+// InternalServerError logs err and answers without exposing it.
+func InternalServerError(ctx *gin.Context, err error) {
+	slog.ErrorContext(ctx, err.Error())
+	Write(ctx, New(http.StatusInternalServerError, CodeInternalServerError, "error processing the request"))
+}
+
+// NoRoute answers requests to unknown paths.
+func NoRoute(ctx *gin.Context) {
+	NotFound(ctx, "no endpoint at this path")
+}
+
+// NoMethod answers requests with a method the path does not support.
+func NoMethod(ctx *gin.Context) {
+	Write(ctx, New(http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed for this path"))
+}
+
+// NotImplemented answers the endpoints of the features the configured storage
+// backend does not provide.
+func NotImplemented(ctx *gin.Context) {
+	Write(ctx, New(http.StatusNotImplemented, CodeNotImplemented, "not available with the configured storage backend"))
+}
+
+// Recovery answers panics in the handlers with an internal server error.
+func Recovery() gin.HandlerFunc {
+	return gin.CustomRecovery(func(ctx *gin.Context, recovered any) {
+		slog.ErrorContext(ctx, "panic serving request", "panic", recovered, "path", ctx.Request.URL.Path)
+		Write(ctx, New(http.StatusInternalServerError, CodeInternalServerError, "error processing the request"))
+	})
+}
@@ -0,0 +1,42 @@
+package problem
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWrite(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Request = httptest.NewRequest(http.MethodGet, "/api/stock-details/NOPE", nil)
+
+	Write(ctx, New(http.StatusGatewayTimeout, CodeUpstreamTimeout, "providers did not respond in time").
+		With("sections", map[string]string{"quote": "error"}).
+		With("status", "ignored"))
+
+	assert.True(t, ctx.IsAborted())
+	assert.Equal(t, http.StatusGatewayTimeout, recorder.Code)
+	assert.Equal(t, ContentType, recorder.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{
+		"type": "about:blank",
+		"title": "Gateway Timeout",
+		"status": 504,
+		"detail": "providers did not respond in time",
+		"instance": "/api/stock-details/NOPE",
+		"code": "upstream_timeout",
+		"sections": {"quote": "error"}
+	}`, recorder.Body.String())
+}
+
+func TestMarshalWithoutExtensions(t *testing.T) {
+	body, err := json.Marshal(New(http.StatusNotFound, CodeNotFound, ""))
+
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"type": "about:blank", "title": "Not Found", "status": 404, "code": "not_found"}`, string(body))
+}
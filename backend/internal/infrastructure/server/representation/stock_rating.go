@@ -0,0 +1,109 @@
+package representation
+
+import (
+	"time"
+
+	"github.com/rubenpad/srs/internal/domain/entity"
+)
+
+// Page is the envelope of the paged responses of every API version.
+type Page[T any] struct {
+	Data     []T    `json:"data"`
+	NextPage string `json:"nextPage"`
+}
+
+// StockRatingV1 is the /api/v1 shape of a stock rating, frozen to the fields
+// it had before the API was versioned: the targets as the upstream text. The
+// fields added since are only served by v2.
+type StockRatingV1 struct {
+	Brokerage         string    `json:"brokerage"`
+	Action            string    `json:"action"`
+	Company           string    `json:"company"`
+	Ticker            string    `json:"ticker"`
+	RatingFrom        string    `json:"rating_from"`
+	RatingTo          string    `json:"rating_to"`
+	TargetFrom        string    `json:"target_from"`
+	TargetTo          string    `json:"target_to"`
+	Time              time.Time `json:"time"`
+	TargetPriceChange float64   `json:"target_price_change"`
+	Score             float32   `json:"score"`
+}
+
+// StockRatingV2 is the /api/v2 shape of a stock rating: numeric targets in
+// Currency, null when the upstream text could not be parsed.
+type StockRatingV2 struct {
+	Ticker            string    `json:"ticker"`
+	Company           string    `json:"company"`
+	Brokerage         string    `json:"brokerage"`
+	Action            string    `json:"action"`
+	RatingFrom        string    `json:"rating_from"`
+	RatingTo          string    `json:"rating_to"`
+	TargetFrom        *float64  `json:"target_from"`
+	TargetTo          *float64  `json:"target_to"`
+	Currency          *string   `json:"currency"`
+	Time              time.Time `json:"time"`
+	TargetPriceChange float64   `json:"target_price_change"`
+	Score             float32   `json:"score"`
+	ImpliedUpside     *float64  `json:"implied_upside"`
+}
+
+func NewStockRatingV1(rating entity.StockRating) StockRatingV1 {
+	return StockRatingV1{
+		Brokerage:         rating.Brokerage,
+		Action:            rating.Action,
+		Company:           rating.Company,
+		Ticker:            rating.Ticker,
+		RatingFrom:        rating.RatingFrom,
+		RatingTo:          rating.RatingTo,
+		TargetFrom:        rating.TargetFrom,
+		TargetTo:          rating.TargetTo,
+		Time:              rating.Time,
+		TargetPriceChange: rating.TargetPriceChange,
+		Score:             rating.Score,
+	}
+}
+
+func NewStockRatingV2(rating entity.StockRating) StockRatingV2 {
+	return StockRatingV2{
+		Ticker:            rating.Ticker,
+		Company:           rating.Company,
+		Brokerage:         rating.Brokerage,
+		Action:            rating.Action,
+		RatingFrom:        rating.RatingFrom,
+		RatingTo:          rating.RatingTo,
+		TargetFrom:        rating.TargetFromValue,
+		TargetTo:          rating.TargetToValue,
+		Currency:          rating.Currency,
+		Time:              rating.Time,
+		TargetPriceChange: rating.TargetPriceChange,
+		Score:             rating.Score,
+		ImpliedUpside:     rating.ImpliedUpside,
+	}
+}
+
+// NewStockRatingPageV1 maps a page of ratings to the v1 shape, where
+// nextPage is the ticker of the last rating: the next page starts at the
+// following ticker.
+func NewStockRatingPageV1(ratings []entity.StockRating, nextPage string) Page[StockRatingV1] {
+	page := Page[StockRatingV1]{Data: make([]StockRatingV1, 0, len(ratings))}
+	for _, rating := range ratings {
+		page.Data = append(page.Data, NewStockRatingV1(rating))
+	}
+
+	if nextPage != "" && len(ratings) > 0 {
+		page.NextPage = ratings[len(ratings)-1].Ticker
+	}
+
+	return page
+}
+
+// NewStockRatingPageV2 maps a page of ratings to the v2 shape, where
+// nextPage is the opaque cursor of the last rating.
+func NewStockRatingPageV2(ratings []entity.StockRating, nextPage string) Page[StockRatingV2] {
+	page := Page[StockRatingV2]{Data: make([]StockRatingV2, 0, len(ratings)), NextPage: nextPage}
+	for _, rating := range ratings {
+		page.Data = append(page.Data, NewStockRatingV2(rating))
+	}
+
+	return page
+}
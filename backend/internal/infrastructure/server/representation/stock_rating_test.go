@@ -0,0 +1,62 @@
+package representation
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/rubenpad/srs/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testRatings() []entity.StockRating {
+	target, currency := 15.5, "USD"
+	return []entity.StockRating{
+		{Ticker: "AAPL", Brokerage: "Citigroup", TargetFrom: "$10.00", TargetTo: "$15.50", TargetToValue: &target, Currency: &currency, Time: time.Date(2025, 3, 14, 0, 0, 0, 0, time.UTC)},
+		{Ticker: "AAPL", Brokerage: "JPMorgan Chase & Co.", TargetFrom: "n/a", TargetTo: "n/a", Time: time.Date(2025, 3, 13, 0, 0, 0, 0, time.UTC)},
+	}
+}
+
+func TestNewStockRatingPageV1(t *testing.T) {
+	ratings := testRatings()
+
+	page := NewStockRatingPageV1(ratings, entity.NewStockRatingCursor(ratings[1]).String())
+
+	assert.Equal(t, "AAPL", page.NextPage)
+	require.Len(t, page.Data, 2)
+	assert.Equal(t, "$15.50", page.Data[0].TargetTo)
+
+	assert.Empty(t, NewStockRatingPageV1(ratings, "").NextPage)
+}
+
+// TestStockRatingV1Golden guards the v1 shape against the fields added to the
+// stock rating since: testdata/stock_rating_v1.json is a rating as the API
+// served it before it was versioned.
+func TestStockRatingV1Golden(t *testing.T) {
+	golden, err := os.ReadFile("testdata/stock_rating_v1.json")
+	require.NoError(t, err)
+
+	body, err := json.Marshal(NewStockRatingV1(testRatings()[0]))
+	require.NoError(t, err)
+
+	assert.JSONEq(t, string(golden), string(body))
+}
+
+func TestNewStockRatingPageV2(t *testing.T) {
+	ratings := testRatings()
+	cursor := entity.NewStockRatingCursor(ratings[1]).String()
+
+	body, err := json.Marshal(NewStockRatingPageV2(ratings, cursor))
+	require.NoError(t, err)
+
+	var page map[string]any
+	require.NoError(t, json.Unmarshal(body, &page))
+	assert.Equal(t, cursor, page["nextPage"])
+
+	data := page["data"].([]any)
+	assert.Equal(t, 15.5, data[0].(map[string]any)["target_to"])
+	assert.Nil(t, data[0].(map[string]any)["target_from"])
+	assert.NotContains(t, data[0], "target_to_value")
+}
@@ -2,65 +2,320 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"log/slog"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
 	"time"
 
+	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/gin-gonic/gin"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rubenpad/srs/database"
+	"github.com/rubenpad/srs/internal/domain/entity"
 	"github.com/rubenpad/srs/internal/domain/service"
 	"github.com/rubenpad/srs/internal/infrastructure/api"
+	"github.com/rubenpad/srs/internal/infrastructure/config"
+	"github.com/rubenpad/srs/internal/infrastructure/graphql"
+	"github.com/rubenpad/srs/internal/infrastructure/grpcserver"
+	"github.com/rubenpad/srs/internal/infrastructure/server/handler/admin"
+	"github.com/rubenpad/srs/internal/infrastructure/server/handler/alert"
+	"github.com/rubenpad/srs/internal/infrastructure/server/handler/company"
+	graphqlhandler "github.com/rubenpad/srs/internal/infrastructure/server/handler/graphql"
 	"github.com/rubenpad/srs/internal/infrastructure/server/handler/health"
+	"github.com/rubenpad/srs/internal/infrastructure/server/handler/sector"
 	"github.com/rubenpad/srs/internal/infrastructure/server/handler/stock"
+	streamhandler "github.com/rubenpad/srs/internal/infrastructure/server/handler/stream"
+	webhookhandler "github.com/rubenpad/srs/internal/infrastructure/server/handler/webhook"
+	"github.com/rubenpad/srs/internal/infrastructure/server/middleware/bearer"
+	"github.com/rubenpad/srs/internal/infrastructure/server/middleware/deprecation"
 	"github.com/rubenpad/srs/internal/infrastructure/server/middleware/logging"
+	"github.com/rubenpad/srs/internal/infrastructure/server/middleware/metrics"
 	"github.com/rubenpad/srs/internal/infrastructure/server/middleware/pagination"
+	"github.com/rubenpad/srs/internal/infrastructure/server/middleware/requestid"
 	"github.com/rubenpad/srs/internal/infrastructure/server/middleware/search"
+	"github.com/rubenpad/srs/internal/infrastructure/server/middleware/validation"
+	"github.com/rubenpad/srs/internal/infrastructure/server/openapi"
+	"github.com/rubenpad/srs/internal/infrastructure/server/problem"
+	"github.com/rubenpad/srs/internal/infrastructure/server/representation"
 	"github.com/rubenpad/srs/internal/infrastructure/storage/cockroach"
+	"github.com/rubenpad/srs/internal/infrastructure/stream"
+	"github.com/rubenpad/srs/internal/infrastructure/webhook"
 	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	"go.opentelemetry.io/otel/metric/noop"
+	"google.golang.org/grpc"
 )
 
+const (
+	apiUnversionedPrefix = "/api"
+	apiV1Prefix          = "/api/v1"
+	apiV2Prefix          = "/api/v2"
+)
+
+// apiV1DeprecatedAt is announced in the Deprecation header of /api/v1.
+var apiV1DeprecatedAt = time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC)
+
 type Server struct {
 	httpAddress string
 	engine      *gin.Engine
 
+	grpcAddress string
+	grpcServer  *grpc.Server
+
+	stockRatingService *service.StockRatingService
+	stockRatingStream  *stream.Hub
+
+	readiness *health.Checker
+	migrate   func(ctx context.Context) error
+
 	shutdownTimeout time.Duration
 }
 
-func New(ctx context.Context, host string, port uint, shutdownTimeout time.Duration, connectionPool *pgxpool.Pool) (context.Context, Server) {
+func New(ctx context.Context, configuration config.Config, connectionPool *pgxpool.Pool, stockRatingRepository entity.IStockRatingRepository, marketDataProvider entity.MarketDataProvider, companySource entity.ICompanySource, notifiers map[string]entity.INotifier) (context.Context, Server) {
 	gin.SetMode(gin.ReleaseMode)
 
 	server := Server{
 		engine:          gin.New(),
-		httpAddress:     fmt.Sprintf("%s:%d", host, port),
-		shutdownTimeout: shutdownTimeout,
+		httpAddress:     fmt.Sprintf("%s:%d", configuration.Host, configuration.Port),
+		shutdownTimeout: configuration.ShutdownTimeout,
 	}
 
-	server.registerRoutes(connectionPool)
-	return serverContext(ctx), server
+	ctx = serverContext(ctx)
+	server.registerRoutes(ctx, configuration.StockRatingApi, connectionPool, stockRatingRepository, marketDataProvider, companySource, notifiers)
+	return ctx, server
 }
 
-func (s *Server) registerRoutes(connectionPool *pgxpool.Pool) {
+func (s *Server) registerRoutes(ctx context.Context, stockRatingApiConfig config.StockRatingApi, connectionPool *pgxpool.Pool, stockRatingRepository entity.IStockRatingRepository, marketDataProvider entity.MarketDataProvider, companySource entity.ICompanySource, notifiers map[string]entity.INotifier) {
+	// Handlers pass their gin.Context down as a context.Context; the fallback
+	// makes it carry the request span and cancellation.
+	s.engine.ContextWithFallback = true
+	s.engine.HandleMethodNotAllowed = true
+	s.engine.NoRoute(problem.NoRoute)
+	s.engine.NoMethod(problem.NoMethod)
 	s.engine.Use(
-		gin.Recovery(),
+		problem.Recovery(),
+		requestid.Middleware(),
+		// The request durations are recorded per route by metrics.Middleware.
+		otelgin.Middleware("srs", otelgin.WithMeterProvider(noop.NewMeterProvider())),
 		logging.Middleware(),
-		pagination.Middleware(),
-		search.Middleware(),
-		otelgin.Middleware("srs"),
+		metrics.Middleware(),
 	)
 
-	stockRatingRepository := cockroach.NewStockRatingRepository(connectionPool)
-	stockRatingService := service.NewStockRatingService(stockRatingRepository, api.NewStockRatingApi())
+	// Without the database, with the ratings in memory or SQLite, the features
+	// stored there answer 501 and listen to no ingestion.
+	hasDatabase := connectionPool != nil
+	requiresDatabase := func(handler gin.HandlerFunc) gin.HandlerFunc {
+		if hasDatabase {
+			return handler
+		}
+
+		return problem.NotImplemented
+	}
+
+	var companyRepository entity.ICompanyRepository
+	if hasDatabase {
+		companyRepository = cockroach.NewCompanyRepository(connectionPool)
+	}
+
+	stockRatingApi := api.NewStockRatingApi(stockRatingApiConfig, marketDataProvider, entity.NewBrokerageRegistry(entity.DefaultBrokerages))
+	stockRatingService := service.NewStockRatingService(stockRatingRepository, stockRatingApi, companyRepository, marketDataProvider)
 	stockRatingController := stock.NewStockRatingController(stockRatingService)
+	s.stockRatingService = stockRatingService
+
+	graphqlSchema, err := graphql.NewSchema(stockRatingService)
+	if err != nil {
+		log.Fatal("invalid graphql schema", err)
+	}
+	graphqlController := graphqlhandler.NewGraphqlController(graphqlSchema, stockRatingService)
+
+	stockPriceRepository := cockroach.NewStockPriceRepository(connectionPool)
+	stockPriceService := service.NewStockPriceService(stockPriceRepository, stockRatingRepository, marketDataProvider)
+	stockPriceController := stock.NewStockPriceController(stockPriceService)
+
+	companyService := service.NewCompanyService(companyRepository, stockRatingRepository, marketDataProvider, companySource)
+	companyController := company.NewCompanyController(companyService)
+
+	sectorService := service.NewSectorService(cockroach.NewSectorRepository(connectionPool), marketDataProvider)
+	sectorController := sector.NewSectorController(sectorService)
+
+	alertService := service.NewAlertService(cockroach.NewAlertRepository(connectionPool), notifiers)
+	alertController := alert.NewAlertController(alertService)
+
+	webhookService := service.NewWebhookService(cockroach.NewWebhookRepository(connectionPool), stockRatingRepository, webhook.NewSender())
+	webhookController := webhookhandler.NewWebhookController(webhookService)
+
+	if hasDatabase {
+		stockRatingService.AddListener(stockPriceService)
+		stockRatingService.AddListener(alertService)
+		stockRatingService.AddListener(webhookService)
+		go webhookService.Run(ctx)
+	}
+
+	hub := stream.NewHub()
+	stockRatingService.AddListener(hub)
+	s.stockRatingStream = hub
+	streamControllerV1 := streamhandler.NewStreamController(hub, representation.NewStockRatingV1)
+	streamControllerV2 := streamhandler.NewStreamController(hub, representation.NewStockRatingV2)
+	go func() {
+		<-ctx.Done()
+		hub.Close()
+	}()
 
 	s.engine.GET("/api/health", health.HealthCheck)
-	s.engine.GET("/api/stock-ratings", stockRatingController.GetStockRatings)
-	s.engine.POST("/api/stock-ratings-data", stockRatingController.LoadStockRatingData)
-	s.engine.GET("/api/stock-recommendations", stockRatingController.GetStockRecommendations)
-	s.engine.GET("/api/stock-details/:ticker", stockRatingController.GetStockDetails)
+	s.engine.GET("/api/health/live", health.Live)
+	s.readiness = newReadinessChecker(connectionPool, stockRatingRepository, stockRatingApi, marketDataProvider)
+	s.engine.GET("/api/health/ready", s.readiness.Ready)
+
+	// The unversioned routes predate the versions and keep answering as v1,
+	// the version their clients were written against.
+	unversioned := s.apiGroup(apiUnversionedPrefix, openapi.V1, deprecation.Middleware(apiV1DeprecatedAt, apiUnversionedPrefix, apiV2Prefix))
+	v1 := s.apiGroup(apiV1Prefix, openapi.V1, deprecation.Middleware(apiV1DeprecatedAt, apiV1Prefix, apiV2Prefix))
+	for _, group := range []*gin.RouterGroup{unversioned, v1} {
+		group.GET("/stock-ratings", stockRatingController.GetStockRatingsV1)
+		group.GET("/stream/stock-ratings", streamControllerV1.StreamStockRatings)
+	}
+
+	v2 := s.apiGroup(apiV2Prefix, openapi.V2)
+	v2.GET("/stock-ratings", stockRatingController.GetStockRatings)
+	v2.GET("/stream/stock-ratings", streamControllerV2.StreamStockRatings)
+
+	for _, group := range []*gin.RouterGroup{unversioned, v1, v2} {
+		group.POST("/stock-ratings-data", stockRatingController.LoadStockRatingData)
+		group.GET("/stock-recommendations", stockRatingController.GetStockRecommendations)
+		group.GET("/stock-details/:ticker", stockRatingController.GetStockDetails)
+		group.POST("/stock-prices-data", requiresDatabase(stockPriceController.LoadStockPriceData))
+		group.GET("/stocks/:ticker/candles", requiresDatabase(stockPriceController.GetCandles))
+		group.POST("/companies-data", requiresDatabase(companyController.LoadCompaniesData))
+		group.GET("/companies/:ticker", requiresDatabase(companyController.GetCompany))
+		group.GET("/sectors", requiresDatabase(sectorController.GetSectors))
+		group.GET("/sectors/:sector/recommendations", requiresDatabase(sectorController.GetSectorRecommendations))
+		group.GET("/alerts", requiresDatabase(alertController.GetAlerts))
+		group.GET("/alert-rules", requiresDatabase(alertController.GetRules))
+		group.POST("/alert-rules", requiresDatabase(alertController.CreateRule))
+		group.DELETE("/alert-rules/:id", requiresDatabase(alertController.DeleteRule))
+		group.GET("/webhooks", requiresDatabase(webhookController.GetWebhooks))
+		group.POST("/webhooks", requiresDatabase(webhookController.CreateWebhook))
+		group.DELETE("/webhooks/:id", requiresDatabase(webhookController.DeleteWebhook))
+		group.GET("/webhooks/:id/deliveries", requiresDatabase(webhookController.GetDeliveries))
+		group.POST("/graphql", graphqlController.Query)
+	}
+}
+
+// newReadinessChecker checks the database, when there is one, and the
+// upstream services before the server takes traffic.
+func newReadinessChecker(connectionPool *pgxpool.Pool, stockRatingRepository entity.IStockRatingRepository, stockRatingApi *api.StockRatingApi, marketDataProvider entity.MarketDataProvider) *health.Checker {
+	checker := health.NewChecker()
+
+	if connectionPool != nil {
+		migrationVersion, err := database.LatestVersion()
+		if err != nil {
+			log.Fatal("invalid embedded migrations", err)
+		}
+
+		checker.Add(health.Check{Name: "database", Critical: true, Run: func(ctx context.Context) error {
+			return connectionPool.Ping(ctx)
+		}})
+		checker.Add(health.Check{Name: "migrations", Critical: true, Run: func(ctx context.Context) error {
+			return cockroach.CheckMigrationVersion(ctx, connectionPool, migrationVersion)
+		}})
+	}
+
+	// The ratings kept out of the database, in SQLite, have their own check.
+	if healthChecker, ok := stockRatingRepository.(entity.HealthChecker); ok {
+		checker.Add(health.Check{Name: "stock_ratings", Critical: true, Run: healthChecker.HealthCheck})
+	}
+
+	for _, check := range upstreamChecks(stockRatingApi, marketDataProvider) {
+		checker.Add(check)
+	}
+
+	return checker
+}
+
+// upstreamChecks verify that the rating API and the market data provider
+// accept the configured credentials. They are critical: a pod with a missing
+// or revoked key would fail every load and stock details request, so it is
+// kept out of the rotation instead.
+func upstreamChecks(stockRatingApi *api.StockRatingApi, marketDataProvider entity.MarketDataProvider) []health.Check {
+	checks := []health.Check{
+		{Name: "stock_rating_api", Critical: true, Timeout: 5 * time.Second, CacheFor: time.Minute, Run: stockRatingApi.HealthCheck},
+	}
+
+	if healthChecker, ok := marketDataProvider.(entity.HealthChecker); ok {
+		checks = append(checks, health.Check{Name: "market_data", Critical: true, Timeout: 5 * time.Second, CacheFor: 5 * time.Minute, Run: healthChecker.HealthCheck})
+	}
+
+	return checks
+}
+
+// apiGroup returns the route group of an API version under prefix, serving
+// its OpenAPI document and validating the requests against it.
+func (s *Server) apiGroup(prefix string, specName string, middlewares ...gin.HandlerFunc) *gin.RouterGroup {
+	spec := loadSpec(prefix, specName)
+	validationMiddleware, err := validation.Middleware(spec)
+	if err != nil {
+		log.Fatal("invalid openapi spec", err)
+	}
+
+	group := s.engine.Group(prefix, middlewares...)
+	group.Use(
+		validationMiddleware,
+		pagination.Middleware(),
+		search.Middleware(),
+	)
+	// The handler changes the document it serves, so it gets its own copy.
+	specHandler, err := openapi.Handler(loadSpec(prefix, specName))
+	if err != nil {
+		log.Fatal("invalid openapi spec", err)
+	}
+	group.GET("/openapi.json", specHandler)
+
+	return group
+}
+
+// loadSpec loads the OpenAPI document of an API version served under prefix,
+// which the document's server has to match for the requests to be validated.
+func loadSpec(prefix string, specName string) *openapi3.T {
+	spec, err := openapi.Load(specName)
+	if err != nil {
+		log.Fatal("invalid openapi spec", err)
+	}
+
+	spec.Servers = openapi3.Servers{{URL: prefix}}
+	return spec
+}
+
+// EnableMetrics serves handler, the Prometheus metrics, at /metrics. It must
+// be called before Run.
+func (s *Server) EnableMetrics(handler http.Handler) {
+	s.engine.GET("/metrics", gin.WrapH(handler))
+}
+
+// EnableAdmin serves the admin endpoints under /api/admin to the requests
+// authenticated with token. It must be called before Run.
+func (s *Server) EnableAdmin(token string) {
+	group := s.engine.Group("/api/admin", bearer.Middleware(token))
+	group.GET("/logging", admin.GetLogging)
+	group.PUT("/logging", admin.UpdateLogging)
+}
+
+// EnableGrpc serves the gRPC API on port next to the HTTP server, sharing its
+// services. It must be called before Run.
+func (s *Server) EnableGrpc(host string, port uint) {
+	s.grpcAddress = fmt.Sprintf("%s:%d", host, port)
+	s.grpcServer = grpcserver.New(s.stockRatingService, s.stockRatingStream)
+}
+
+// EnableMigrations runs migrate once the server listens, reporting it
+// unready as migrating until migrate returns. The server stops when migrate
+// fails. It must be called before Run.
+func (s *Server) EnableMigrations(migrate func(ctx context.Context) error) {
+	s.migrate = migrate
+	s.readiness.Hold(health.StatusMigrating)
 }
 
 func (s *Server) Run(ctx context.Context) error {
@@ -77,13 +332,70 @@ func (s *Server) Run(ctx context.Context) error {
 		}
 	}()
 
+	if s.grpcServer != nil {
+		listener, err := net.Listen("tcp", s.grpcAddress)
+		if err != nil {
+			return fmt.Errorf("failed to listen for grpc: %w", err)
+		}
+
+		slog.Info("gRPC server running on", "grpcAddress", s.grpcAddress)
+		go func() {
+			if err := s.grpcServer.Serve(listener); err != nil {
+				log.Fatal("grpc server shut down", err)
+			}
+		}()
+	}
+
+	if s.migrate != nil {
+		if err := s.runMigrations(ctx); err != nil {
+			return errors.Join(err, s.shutdown(server))
+		}
+	}
+
 	<-ctx.Done()
+	return s.shutdown(server)
+}
+
+func (s *Server) runMigrations(ctx context.Context) error {
+	slog.InfoContext(ctx, "migrating the database")
+
+	start := time.Now()
+	if err := s.migrate(ctx); err != nil {
+		return fmt.Errorf("failed to migrate the database: %w", err)
+	}
+
+	slog.InfoContext(ctx, "database migrated", "duration", time.Since(start).String())
+	s.readiness.Release()
+	return nil
+}
+
+func (s *Server) shutdown(server *http.Server) error {
 	ctxShutDown, cancel := context.WithTimeout(context.Background(), s.shutdownTimeout)
 	defer cancel()
 
+	if s.grpcServer != nil {
+		s.stopGrpc(ctxShutDown)
+	}
+
 	return server.Shutdown(ctxShutDown)
 }
 
+// stopGrpc waits for the in-flight calls to finish and closes the remaining
+// ones when ctx expires first.
+func (s *Server) stopGrpc(ctx context.Context) {
+	stopped := make(chan struct{})
+	go func() {
+		s.grpcServer.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		s.grpcServer.Stop()
+	}
+}
+
 func serverContext(ctx context.Context) context.Context {
 	c := make(chan os.Signal, 1)
 	signal.Notify(c, os.Interrupt)
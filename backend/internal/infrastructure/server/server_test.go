@@ -0,0 +1,242 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rubenpad/srs/internal/infrastructure/api"
+	"github.com/rubenpad/srs/internal/infrastructure/config"
+	"github.com/rubenpad/srs/internal/infrastructure/logging"
+	"github.com/rubenpad/srs/internal/infrastructure/marketdata/finnhub"
+	"github.com/rubenpad/srs/internal/infrastructure/server/handler/health"
+	"github.com/rubenpad/srs/internal/infrastructure/server/openapi"
+	"github.com/rubenpad/srs/internal/infrastructure/server/problem"
+	"github.com/rubenpad/srs/internal/infrastructure/storage/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testAdminToken = "admin-token"
+
+func newTestServer(t *testing.T) Server {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	configuration := config.Default()
+	configuration.Host = "localhost"
+	configuration.ShutdownTimeout = time.Second
+
+	_, server := New(ctx, configuration, nil, memory.NewStockRatingRepository(), nil, nil, nil)
+	server.EnableAdmin(testAdminToken)
+	return server
+}
+
+func TestRoutesAreDocumented(t *testing.T) {
+	// The unversioned routes answer as v1.
+	specs := map[string]string{apiUnversionedPrefix: openapi.V1, apiV1Prefix: openapi.V1, apiV2Prefix: openapi.V2}
+	undocumented := []string{"/api/health", "/api/health/live", "/api/health/ready", "/api/admin/logging"}
+
+	param := regexp.MustCompile(`:(\w+)`)
+	for _, route := range newTestServer(t).engine.Routes() {
+		if slices.Contains(undocumented, route.Path) {
+			continue
+		}
+
+		require.True(t, strings.HasPrefix(route.Path, apiUnversionedPrefix+"/"), "%s is not under %s", route.Path, apiUnversionedPrefix)
+		prefix := apiUnversionedPrefix
+		for _, version := range []string{apiV1Prefix, apiV2Prefix} {
+			if strings.HasPrefix(route.Path, version+"/") {
+				prefix = version
+			}
+		}
+
+		spec, err := openapi.Load(specs[prefix])
+		require.NoError(t, err)
+
+		path := param.ReplaceAllString(strings.TrimPrefix(route.Path, prefix), "{$1}")
+		item := spec.Paths.Find(path)
+		require.NotNil(t, item, "%s is not documented in %s", path, specs[prefix])
+		assert.NotNil(t, item.GetOperation(route.Method), "%s %s is not documented in %s", route.Method, path, specs[prefix])
+	}
+}
+
+func TestProblemResponses(t *testing.T) {
+	server := newTestServer(t)
+
+	tests := []struct {
+		name   string
+		method string
+		target string
+		body   string
+		status int
+		detail string
+	}{
+		{"page size out of range", http.MethodGet, "/api/v2/stock-ratings?pageSize=0", "", http.StatusBadRequest, "pageSize parameter"},
+		{"invalid enum", http.MethodGet, "/api/v1/stock-recommendations?sort=price", "", http.StatusBadRequest, "sort parameter"},
+		{"invalid unversioned enum", http.MethodGet, "/api/stock-recommendations?sort=price", "", http.StatusBadRequest, "sort parameter"},
+		{"invalid date", http.MethodPost, "/api/v2/stock-prices-data?from=yesterday", "", http.StatusBadRequest, "from parameter"},
+		{"invalid path parameter", http.MethodDelete, "/api/v2/webhooks/abc", "", http.StatusBadRequest, "id parameter"},
+		{"missing body field", http.MethodPost, "/api/v1/alert-rules", `{"name": "rule"}`, http.StatusBadRequest, "request body"},
+		{"invalid body field", http.MethodPost, "/api/v2/webhooks", `{"url": "https://example.com", "events": ["rating.deleted"]}`, http.StatusBadRequest, "request body"},
+		{"invalid cursor", http.MethodGet, "/api/v2/stock-ratings?nextPage=AAPL", "", http.StatusBadRequest, "nextPage"},
+		{"no database", http.MethodGet, "/api/v2/sectors", "", http.StatusNotImplemented, "storage backend"},
+		{"unknown path", http.MethodGet, "/api/v3/stock-ratings", "", http.StatusNotFound, "no endpoint"},
+		{"unsupported method", http.MethodPut, "/api/health", "", http.StatusMethodNotAllowed, "method not allowed"},
+		{"unauthenticated admin request", http.MethodPut, "/api/admin/logging", `{"level": "debug"}`, http.StatusUnauthorized, "bearer token"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			server.engine.ServeHTTP(recorder, httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body)))
+
+			assert.Equal(t, tt.status, recorder.Code)
+			assert.Equal(t, problem.ContentType, recorder.Header().Get("Content-Type"))
+
+			var body problem.Problem
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+			assert.Equal(t, tt.status, body.Status)
+			assert.Contains(t, body.Detail, tt.detail)
+		})
+	}
+}
+
+func TestOpenApiIsServed(t *testing.T) {
+	server := newTestServer(t)
+
+	for _, version := range []string{"/api", apiV1Prefix, apiV2Prefix} {
+		recorder := httptest.NewRecorder()
+		server.engine.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, version+"/openapi.json", nil))
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.NotContains(t, recorder.Body.String(), ".yaml#", "external references are not resolved")
+
+		var spec map[string]any
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &spec))
+		assert.Equal(t, "3.0.3", spec["openapi"])
+	}
+}
+
+func TestV1IsDeprecated(t *testing.T) {
+	server := newTestServer(t)
+
+	recorder := httptest.NewRecorder()
+	server.engine.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/openapi.json", nil))
+	assert.Equal(t, fmt.Sprintf("@%d", apiV1DeprecatedAt.Unix()), recorder.Header().Get("Deprecation"))
+	assert.Equal(t, `</api/v2/openapi.json>; rel="successor-version"`, recorder.Header().Get("Link"))
+
+	recorder = httptest.NewRecorder()
+	server.engine.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/stock-recommendations", nil))
+	assert.Equal(t, fmt.Sprintf("@%d", apiV1DeprecatedAt.Unix()), recorder.Header().Get("Deprecation"))
+	assert.Equal(t, `</api/v2/stock-recommendations>; rel="successor-version"`, recorder.Header().Get("Link"))
+
+	recorder = httptest.NewRecorder()
+	server.engine.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/v2/openapi.json", nil))
+	assert.Empty(t, recorder.Header().Get("Deprecation"))
+}
+
+func TestAdminLogging(t *testing.T) {
+	server := newTestServer(t)
+	t.Cleanup(func() {
+		require.NoError(t, logging.Set(logging.Config{Level: "info", Format: logging.FormatJson}))
+	})
+
+	request := func(method string, body string, token string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(method, "/api/admin/logging", strings.NewReader(body))
+		if token != "" {
+			request.Header.Set("Authorization", "Bearer "+token)
+		}
+
+		recorder := httptest.NewRecorder()
+		server.engine.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	recorder := request(http.MethodPut, `{"level": "debug", "format": "text"}`, testAdminToken)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.JSONEq(t, `{"level": "debug", "format": "text"}`, recorder.Body.String())
+
+	recorder = request(http.MethodPut, `{"level": "verbose"}`, testAdminToken)
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "unknown log level")
+
+	recorder = request(http.MethodPut, `{"level": "error", "format": "xml"}`, testAdminToken)
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+
+	for _, token := range []string{"", "wrong-token"} {
+		recorder = request(http.MethodPut, `{"level": "error"}`, token)
+		assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+
+		recorder = request(http.MethodGet, "", token)
+		assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+	}
+
+	recorder = request(http.MethodGet, "", testAdminToken)
+	assert.JSONEq(t, `{"level": "debug", "format": "text"}`, recorder.Body.String(), "rejected updates change nothing")
+}
+
+func TestUpstreamChecksAreCritical(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	ratingApi := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"items": [], "next_page": ""}`))
+	}))
+	defer ratingApi.Close()
+
+	// Without a key the Finnhub check fails before calling Finnhub.
+	marketDataProvider := finnhub.NewMarketDataProvider("")
+	stockRatingApi := api.NewStockRatingApi(config.StockRatingApi{StockRatingApiUrl: ratingApi.URL}, marketDataProvider, nil)
+	checker := health.NewChecker(upstreamChecks(stockRatingApi, marketDataProvider)...)
+
+	engine := gin.New()
+	engine.GET("/api/health/ready", checker.Ready)
+	recorder := httptest.NewRecorder()
+	engine.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/health/ready", nil))
+
+	assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+
+	var report health.Report
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &report))
+	assert.Equal(t, health.StatusDown, report.Status)
+
+	statuses := map[string]string{}
+	for _, check := range report.Checks {
+		statuses[check.Name] = check.Status
+	}
+	assert.Equal(t, map[string]string{"stock_rating_api": health.StatusUp, "market_data": health.StatusDown}, statuses)
+}
+
+func TestRunMigrations(t *testing.T) {
+	server := newTestServer(t)
+	server.httpAddress = "localhost:0"
+
+	migrating := make(chan struct{})
+	failed := make(chan struct{})
+	server.EnableMigrations(func(ctx context.Context) error {
+		close(migrating)
+		<-failed
+		return errors.New("migration 3 is dirty")
+	})
+
+	done := make(chan error, 1)
+	go func() { done <- server.Run(context.Background()) }()
+	<-migrating
+
+	recorder := httptest.NewRecorder()
+	server.engine.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/health/ready", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+	assert.JSONEq(t, `{"status": "migrating", "checks": []}`, recorder.Body.String())
+
+	close(failed)
+	assert.ErrorContains(t, <-done, "failed to migrate the database: migration 3 is dirty", "the server stops when the migrations fail")
+}
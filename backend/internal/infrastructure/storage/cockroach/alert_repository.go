@@ -0,0 +1,152 @@
+package cockroach
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strconv"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rubenpad/srs/internal/domain/entity"
+)
+
+type AlertRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewAlertRepository(pool *pgxpool.Pool) *AlertRepository {
+	return &AlertRepository{pool}
+}
+
+func (ar *AlertRepository) SaveRule(ctx context.Context, rule entity.AlertRule) (*entity.AlertRule, error) {
+	query := `
+		INSERT INTO alert_rule (name, expression, notifiers, enabled)
+		VALUES (@name, @expression, @notifiers, @enabled)
+		RETURNING id, name, expression, notifiers, enabled, created_at
+	`
+
+	args := pgx.NamedArgs{
+		"name":       rule.Name,
+		"expression": rule.Expression,
+		"notifiers":  rule.Notifiers,
+		"enabled":    rule.Enabled,
+	}
+
+	rows, err := ar.pool.Query(ctx, query, args)
+	if err != nil {
+		errorMessage := "error saving alert rule"
+		slog.ErrorContext(ctx, errorMessage, "error", err)
+		return nil, errors.New(errorMessage)
+	}
+
+	saved, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[entity.AlertRule])
+	if err != nil {
+		return nil, err
+	}
+
+	return &saved, nil
+}
+
+func (ar *AlertRepository) GetRules(ctx context.Context) ([]entity.AlertRule, error) {
+	query := `
+		SELECT
+			id,
+			name,
+			expression,
+			notifiers,
+			enabled,
+			created_at
+		FROM alert_rule
+		ORDER BY id ASC
+	`
+
+	rows, err := ar.pool.Query(ctx, query)
+	if err != nil {
+		errorMessage := "error getting alert rules"
+		slog.ErrorContext(ctx, errorMessage, "error", err)
+		return nil, errors.New(errorMessage)
+	}
+
+	return pgx.CollectRows(rows, pgx.RowToStructByName[entity.AlertRule])
+}
+
+func (ar *AlertRepository) DeleteRule(ctx context.Context, id int64) error {
+	result, err := ar.pool.Exec(ctx, `DELETE FROM alert_rule WHERE id = @id`, pgx.NamedArgs{"id": id})
+	if err != nil {
+		errorMessage := "error deleting alert rule"
+		slog.ErrorContext(ctx, errorMessage, "error", err)
+		return errors.New(errorMessage)
+	}
+
+	if result.RowsAffected() == 0 {
+		return entity.ErrAlertRuleNotFound
+	}
+
+	return nil
+}
+
+func (ar *AlertRepository) SaveEvent(ctx context.Context, event entity.AlertEvent) (*entity.AlertEvent, error) {
+	query := `
+		INSERT INTO alert_event (rule_id, rule_name, ticker, rating)
+		VALUES (@rule_id, @rule_name, @ticker, @rating)
+		RETURNING id, rule_id, rule_name, ticker, rating, created_at
+	`
+
+	args := pgx.NamedArgs{
+		"rule_id":   event.RuleID,
+		"rule_name": event.RuleName,
+		"ticker":    event.Ticker,
+		"rating":    event.Rating,
+	}
+
+	rows, err := ar.pool.Query(ctx, query, args)
+	if err != nil {
+		errorMessage := "error saving alert event"
+		slog.ErrorContext(ctx, errorMessage, "error", err)
+		return nil, errors.New(errorMessage)
+	}
+
+	saved, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[entity.AlertEvent])
+	if err != nil {
+		return nil, err
+	}
+
+	return &saved, nil
+}
+
+// GetEvents returns the alert events newest first. nextPage is the id of the
+// last event of the previous page.
+func (ar *AlertRepository) GetEvents(ctx context.Context, nextPage string, pageSize int) ([]entity.AlertEvent, error) {
+	var before int64
+	if nextPage != "" {
+		parsed, err := strconv.ParseInt(nextPage, 10, 64)
+		if err != nil {
+			return nil, entity.ErrInvalidCursor
+		}
+		before = parsed
+	}
+
+	query := `
+		SELECT
+			id,
+			rule_id,
+			rule_name,
+			ticker,
+			rating,
+			created_at
+		FROM alert_event
+		WHERE (@before = 0 OR id < @before)
+		ORDER BY id DESC
+		LIMIT @pageSize
+	`
+
+	rows, err := ar.pool.Query(ctx, query, pgx.NamedArgs{"before": before, "pageSize": pageSize})
+	if err != nil {
+		errorMessage := "error getting alert events"
+		slog.ErrorContext(ctx, errorMessage, "error", err)
+		return nil, errors.New(errorMessage)
+	}
+
+	return pgx.CollectRows(rows, pgx.RowToStructByName[entity.AlertEvent])
+}
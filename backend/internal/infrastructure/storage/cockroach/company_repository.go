@@ -0,0 +1,190 @@
+package cockroach
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rubenpad/srs/internal/domain/entity"
+)
+
+const upsertCompanyQuery = `UPSERT INTO company (
+				ticker,
+				name,
+				exchange,
+				sector,
+				industry,
+				aliases,
+				updated_at)
+			  VALUES (
+				@ticker,
+				@name,
+				@exchange,
+				@sector,
+				@industry,
+				@aliases,
+				CURRENT_TIMESTAMP)`
+
+const upsertSymbolChangeQuery = `UPSERT INTO company_symbol_history (
+				from_ticker,
+				to_ticker,
+				changed_at)
+			  VALUES (
+				@from_ticker,
+				@to_ticker,
+				@changed_at)`
+
+const selectCompanyColumns = `
+		SELECT
+			ticker,
+			name,
+			exchange,
+			sector,
+			industry,
+			aliases
+		FROM company`
+
+type CompanyRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewCompanyRepository(pool *pgxpool.Pool) *CompanyRepository {
+	return &CompanyRepository{pool}
+}
+
+func (cr *CompanyRepository) Save(ctx context.Context, company entity.Company) error {
+	aliases := company.Aliases
+	if aliases == nil {
+		aliases = []string{}
+	}
+
+	err := pgx.BeginFunc(ctx, cr.pool, func(tx pgx.Tx) error {
+		_, err := tx.Exec(ctx, upsertCompanyQuery, pgx.NamedArgs{
+			"ticker":   company.Ticker,
+			"name":     company.Name,
+			"exchange": company.Exchange,
+			"sector":   company.Sector,
+			"industry": company.Industry,
+			"aliases":  aliases,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, change := range company.SymbolHistory {
+			_, err := tx.Exec(ctx, upsertSymbolChangeQuery, pgx.NamedArgs{
+				"from_ticker": change.FromTicker,
+				"to_ticker":   company.Ticker,
+				"changed_at":  change.ChangedAt,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		errorMessage := "error saving company"
+		slog.ErrorContext(ctx, errorMessage, "error", err, "ticker", company.Ticker)
+		return errors.New(errorMessage)
+	}
+
+	return nil
+}
+
+// GetCompany returns the company currently trading as ticker or, when ticker
+// is a former symbol, the company it was renamed to.
+func (cr *CompanyRepository) GetCompany(ctx context.Context, ticker string) (*entity.Company, error) {
+	query := selectCompanyColumns + `
+		WHERE ticker = @ticker
+		OR ticker = (SELECT to_ticker FROM company_symbol_history WHERE from_ticker = @ticker ORDER BY changed_at DESC LIMIT 1)
+		ORDER BY (ticker = @ticker) DESC
+		LIMIT 1
+	`
+
+	rows, err := cr.pool.Query(ctx, query, pgx.NamedArgs{"ticker": ticker})
+	if err != nil {
+		errorMessage := "error getting company"
+		slog.ErrorContext(ctx, errorMessage, "error", err)
+		return nil, errors.New(errorMessage)
+	}
+
+	company, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[entity.Company])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, entity.ErrCompanyNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := cr.getSymbolHistory(ctx, company.Ticker)
+	if err != nil {
+		return nil, err
+	}
+
+	company.SymbolHistory = history[company.Ticker]
+	return &company, nil
+}
+
+func (cr *CompanyRepository) GetCompanies(ctx context.Context) ([]entity.Company, error) {
+	rows, err := cr.pool.Query(ctx, selectCompanyColumns+` ORDER BY ticker ASC`)
+	if err != nil {
+		errorMessage := "error getting companies"
+		slog.ErrorContext(ctx, errorMessage, "error", err)
+		return nil, errors.New(errorMessage)
+	}
+
+	companies, err := pgx.CollectRows(rows, pgx.RowToStructByName[entity.Company])
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := cr.getSymbolHistory(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range companies {
+		companies[i].SymbolHistory = history[companies[i].Ticker]
+	}
+
+	return companies, nil
+}
+
+// getSymbolHistory returns the symbol changes grouped by current ticker, for
+// every company when ticker is empty.
+func (cr *CompanyRepository) getSymbolHistory(ctx context.Context, ticker string) (map[string][]entity.SymbolChange, error) {
+	query := `
+		SELECT
+			from_ticker,
+			to_ticker,
+			changed_at
+		FROM company_symbol_history
+		WHERE (@ticker = '' OR to_ticker = @ticker)
+		ORDER BY to_ticker ASC, changed_at DESC
+	`
+
+	rows, err := cr.pool.Query(ctx, query, pgx.NamedArgs{"ticker": ticker})
+	if err != nil {
+		errorMessage := "error getting company symbol history"
+		slog.ErrorContext(ctx, errorMessage, "error", err)
+		return nil, errors.New(errorMessage)
+	}
+
+	changes, err := pgx.CollectRows(rows, pgx.RowToStructByName[entity.SymbolChange])
+	if err != nil {
+		return nil, err
+	}
+
+	history := make(map[string][]entity.SymbolChange)
+	for _, change := range changes {
+		history[change.ToTicker] = append(history[change.ToTicker], change)
+	}
+
+	return history, nil
+}
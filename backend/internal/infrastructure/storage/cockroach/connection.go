@@ -0,0 +1,160 @@
+package cockroach
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rubenpad/srs/internal/infrastructure/config"
+)
+
+// Defaults of the connection parameters neither configured nor in the URL.
+const (
+	defaultSslMode         = "require"
+	defaultMaxConns        = 40
+	defaultMaxConnLifetime = 300 * time.Second
+)
+
+// ConnectionString returns the pgx connection URL of the database, with the
+// pool settings and the statement timeout. applicationName identifies the
+// binary in the CockroachDB sessions unless one is configured.
+func ConnectionString(configuration config.Database, applicationName string) (string, error) {
+	connectionURL, err := baseURL(configuration, "postgresql", applicationName)
+	if err != nil {
+		return "", err
+	}
+
+	query := connectionURL.Query()
+	if configuration.DatabaseMaxConns > 0 {
+		query.Set("pool_max_conns", strconv.Itoa(int(configuration.DatabaseMaxConns)))
+	}
+
+	if configuration.DatabaseMinConns > 0 {
+		query.Set("pool_min_conns", strconv.Itoa(int(configuration.DatabaseMinConns)))
+	}
+
+	if configuration.DatabaseMaxConnLifetime > 0 {
+		query.Set("pool_max_conn_lifetime", configuration.DatabaseMaxConnLifetime.String())
+	}
+
+	if configuration.DatabaseMaxConnIdleTime > 0 {
+		query.Set("pool_max_conn_idle_time", configuration.DatabaseMaxConnIdleTime.String())
+	}
+
+	if configuration.DatabaseStatementTimeout > 0 {
+		query.Set("statement_timeout", strconv.FormatInt(configuration.DatabaseStatementTimeout.Milliseconds(), 10))
+	}
+
+	setDefault(query, "pool_max_conns", strconv.Itoa(defaultMaxConns))
+	setDefault(query, "pool_max_conn_lifetime", defaultMaxConnLifetime.String())
+	if lifetime, err := time.ParseDuration(query.Get("pool_max_conn_lifetime")); err == nil {
+		// Spread the reconnections of the pods started together.
+		setDefault(query, "pool_max_conn_lifetime_jitter", (lifetime / 10).String())
+	}
+
+	connectionURL.RawQuery = query.Encode()
+	return connectionURL.String(), nil
+}
+
+// MigrationURL returns the golang-migrate URL of the database. It has neither
+// the pool settings nor the statement timeout, which would cut long
+// migrations.
+func MigrationURL(configuration config.Database, applicationName string) (string, error) {
+	connectionURL, err := baseURL(configuration, "cockroachdb", applicationName)
+	if err != nil {
+		return "", err
+	}
+
+	query := connectionURL.Query()
+	for key := range query {
+		if strings.HasPrefix(key, "pool_") {
+			query.Del(key)
+		}
+	}
+
+	connectionURL.RawQuery = query.Encode()
+	return connectionURL.String(), nil
+}
+
+// NewPool connects to the database, tracing its queries, and verifies the
+// connection.
+func NewPool(ctx context.Context, configuration config.Database, applicationName string) (*pgxpool.Pool, error) {
+	connectionString, err := ConnectionString(configuration, applicationName)
+	if err != nil {
+		return nil, err
+	}
+
+	poolConfig, err := pgxpool.ParseConfig(connectionString)
+	if err != nil {
+		return nil, fmt.Errorf("invalid database configuration: %w", err)
+	}
+
+	poolConfig.ConnConfig.Tracer = NewQueryTracer()
+	pool, err := pgxpool.NewWithConfig(ctx, poolConfig)
+	if err != nil {
+		return nil, fmt.Errorf("error creating connection pool: %w", err)
+	}
+
+	if err := pool.Ping(ctx); err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("error connecting to the database: %w", err)
+	}
+
+	return pool, nil
+}
+
+// baseURL builds the URL from the configured one or the discrete fields, so
+// the credentials are escaped, and sets the TLS and session parameters.
+func baseURL(configuration config.Database, scheme string, applicationName string) (*url.URL, error) {
+	var connectionURL *url.URL
+	if configuration.DatabaseUrl != "" {
+		parsed, err := url.Parse(configuration.DatabaseUrl.Value())
+		if err != nil {
+			return nil, fmt.Errorf("invalid database URL: %w", err)
+		}
+
+		connectionURL = parsed
+	} else {
+		connectionURL = &url.URL{
+			User: url.UserPassword(configuration.DatabaseUser, configuration.DatabasePassword.Value()),
+			Host: net.JoinHostPort(configuration.DatabaseHost, strconv.FormatUint(uint64(configuration.DatabasePort), 10)),
+			Path: "/" + configuration.Database,
+		}
+
+		if configuration.DatabasePassword == "" {
+			connectionURL.User = url.User(configuration.DatabaseUser)
+		}
+	}
+
+	connectionURL.Scheme = scheme
+
+	query := connectionURL.Query()
+	for key, value := range map[string]string{
+		"sslmode":          configuration.DatabaseSslMode,
+		"sslrootcert":      configuration.DatabaseSslRootCert,
+		"sslcert":          configuration.DatabaseSslCert,
+		"sslkey":           configuration.DatabaseSslKey,
+		"application_name": configuration.DatabaseApplicationName,
+	} {
+		if value != "" {
+			query.Set(key, value)
+		}
+	}
+
+	setDefault(query, "sslmode", defaultSslMode)
+	setDefault(query, "application_name", applicationName)
+
+	connectionURL.RawQuery = query.Encode()
+	return connectionURL, nil
+}
+
+func setDefault(query url.Values, key, value string) {
+	if !query.Has(key) {
+		query.Set(key, value)
+	}
+}
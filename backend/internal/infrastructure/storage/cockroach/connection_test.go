@@ -0,0 +1,125 @@
+package cockroach
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rubenpad/srs/internal/infrastructure/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConnectionString(t *testing.T) {
+	fields := config.Database{
+		Database:     "srs",
+		DatabaseHost: "db.example.com",
+		DatabasePort: 26257,
+		DatabaseUser: "srs",
+	}
+
+	tests := []struct {
+		name      string
+		configure func(*config.Database)
+		expected  map[string]string
+	}{
+		{
+			name:      "defaults",
+			configure: func(*config.Database) {},
+			expected: map[string]string{
+				"sslmode":                       "require",
+				"application_name":              "srs-test",
+				"pool_max_conns":                "40",
+				"pool_max_conn_lifetime":        "5m0s",
+				"pool_max_conn_lifetime_jitter": "30s",
+			},
+		},
+		{
+			name: "configured options",
+			configure: func(database *config.Database) {
+				database.DatabaseSslMode = "verify-full"
+				database.DatabaseSslRootCert = "/certs/ca.crt"
+				database.DatabaseMaxConns = 10
+				database.DatabaseMaxConnLifetime = time.Minute
+				database.DatabaseStatementTimeout = 5 * time.Second
+				database.DatabaseApplicationName = "srs-api"
+			},
+			expected: map[string]string{
+				"sslmode":                       "verify-full",
+				"sslrootcert":                   "/certs/ca.crt",
+				"application_name":              "srs-api",
+				"pool_max_conns":                "10",
+				"pool_max_conn_lifetime":        "1m0s",
+				"pool_max_conn_lifetime_jitter": "6s",
+				"statement_timeout":             "5000",
+			},
+		},
+		{
+			name: "url parameters win over the defaults",
+			configure: func(database *config.Database) {
+				database.DatabaseUrl = "postgresql://root@localhost:26257/defaultdb?sslmode=disable&pool_max_conns=4"
+			},
+			expected: map[string]string{
+				"sslmode":        "disable",
+				"pool_max_conns": "4",
+			},
+		},
+		{
+			name: "configured options win over the url",
+			configure: func(database *config.Database) {
+				database.DatabaseUrl = "postgresql://root@localhost:26257/defaultdb?sslmode=disable"
+				database.DatabaseSslMode = "require"
+			},
+			expected: map[string]string{"sslmode": "require"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			database := fields
+			tt.configure(&database)
+
+			connectionString, err := ConnectionString(database, "srs-test")
+			require.NoError(t, err)
+
+			parsed, err := url.Parse(connectionString)
+			require.NoError(t, err)
+			for key, value := range tt.expected {
+				assert.Equal(t, value, parsed.Query().Get(key), key)
+			}
+
+		})
+	}
+}
+
+func TestConnectionStringEscapesCredentials(t *testing.T) {
+	connectionString, err := ConnectionString(config.Database{
+		Database:                 "srs",
+		DatabaseHost:             "db.example.com",
+		DatabasePort:             26257,
+		DatabaseUser:             "srs",
+		DatabasePassword:         "p@ss:w/rd?#",
+		DatabaseStatementTimeout: 5 * time.Second,
+	}, "srs-test")
+	require.NoError(t, err)
+
+	poolConfig, err := pgxpool.ParseConfig(connectionString)
+	require.NoError(t, err)
+	assert.Equal(t, "p@ss:w/rd?#", poolConfig.ConnConfig.Password)
+	assert.Equal(t, "db.example.com", poolConfig.ConnConfig.Host)
+	assert.Equal(t, "srs", poolConfig.ConnConfig.Database)
+	assert.Equal(t, "5000", poolConfig.ConnConfig.RuntimeParams["statement_timeout"])
+	assert.Equal(t, "srs-test", poolConfig.ConnConfig.RuntimeParams["application_name"])
+	assert.Equal(t, int32(40), poolConfig.MaxConns)
+}
+
+func TestMigrationURL(t *testing.T) {
+	migrationURL, err := MigrationURL(config.Database{
+		DatabaseUrl:              "postgresql://root@localhost:26257/defaultdb?sslmode=disable&pool_max_conns=4",
+		DatabaseStatementTimeout: time.Second,
+	}, "srs-migrations")
+	require.NoError(t, err)
+
+	assert.Equal(t, "cockroachdb://root@localhost:26257/defaultdb?application_name=srs-migrations&sslmode=disable", migrationURL)
+}
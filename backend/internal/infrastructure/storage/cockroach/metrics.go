@@ -0,0 +1,60 @@
+package cockroach
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	srsotel "github.com/rubenpad/srs/internal/infrastructure/otel"
+)
+
+// ObservePool reports the statistics of the connection pool every time the
+// metrics are collected.
+func ObservePool(pool *pgxpool.Pool) error {
+	meter := otel.Meter(srsotel.ScopeName)
+
+	connections, err := meter.Int64ObservableGauge("srs.db.pool.connections",
+		metric.WithDescription("Connections of the pool by state."))
+	if err != nil {
+		return err
+	}
+
+	maxConnections, err := meter.Int64ObservableGauge("srs.db.pool.connections.max",
+		metric.WithDescription("Maximum size of the pool."))
+	if err != nil {
+		return err
+	}
+
+	acquires, err := meter.Int64ObservableCounter("srs.db.pool.acquires",
+		metric.WithDescription("Connections acquired from the pool by result."))
+	if err != nil {
+		return err
+	}
+
+	acquireDuration, err := meter.Float64ObservableCounter("srs.db.pool.acquire.duration",
+		metric.WithDescription("Total time spent acquiring connections from the pool."),
+		metric.WithUnit("s"))
+	if err != nil {
+		return err
+	}
+
+	_, err = meter.RegisterCallback(func(_ context.Context, observer metric.Observer) error {
+		stat := pool.Stat()
+
+		observer.ObserveInt64(connections, int64(stat.IdleConns()), metric.WithAttributes(attribute.String("state", "idle")))
+		observer.ObserveInt64(connections, int64(stat.AcquiredConns()), metric.WithAttributes(attribute.String("state", "acquired")))
+		observer.ObserveInt64(connections, int64(stat.ConstructingConns()), metric.WithAttributes(attribute.String("state", "constructing")))
+		observer.ObserveInt64(maxConnections, int64(stat.MaxConns()))
+		observer.ObserveInt64(acquires, stat.AcquireCount(), metric.WithAttributes(attribute.String("result", "acquired")))
+		observer.ObserveInt64(acquires, stat.EmptyAcquireCount(), metric.WithAttributes(attribute.String("result", "waited")))
+		observer.ObserveInt64(acquires, stat.CanceledAcquireCount(), metric.WithAttributes(attribute.String("result", "canceled")))
+		observer.ObserveFloat64(acquireDuration, stat.AcquireDuration().Seconds())
+
+		return nil
+	}, connections, maxConnections, acquires, acquireDuration)
+
+	return err
+}
@@ -0,0 +1,188 @@
+package cockroach
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rubenpad/srs/internal/infrastructure/config"
+)
+
+const (
+	migrationLeaseTTL  = 30 * time.Second
+	migrationLeasePoll = 2 * time.Second
+)
+
+// ErrMigrationLeaseLost is the cause of the lease context once the lease could
+// not be renewed: another replica may be migrating by then.
+var ErrMigrationLeaseLost = errors.New("migration lease lost")
+
+const createMigrationLeaseQuery = `
+	CREATE TABLE IF NOT EXISTS migration_lease (
+		id INT PRIMARY KEY,
+		holder STRING NOT NULL,
+		expires_at TIMESTAMPTZ NOT NULL
+	)`
+
+// The lease is taken when free, expired or already held by the same holder.
+const acquireMigrationLeaseQuery = `
+	INSERT INTO migration_lease (id, holder, expires_at)
+	VALUES (1, @holder, now() + @ttl * INTERVAL '1 millisecond')
+	ON CONFLICT (id) DO UPDATE SET holder = excluded.holder, expires_at = excluded.expires_at
+	WHERE migration_lease.expires_at < now() OR migration_lease.holder = excluded.holder
+	RETURNING holder`
+
+// MigrationLease lets a single replica at a time migrate the database.
+// CockroachDB has no advisory locks, so the lease is a row with an expiry,
+// renewed while its holder runs and taken over once it expires, when the
+// holder died.
+type MigrationLease struct {
+	pool   *pgxpool.Pool
+	holder string
+	ttl    time.Duration
+}
+
+// NewMigrationLease identifies the holder with the host name, the pod name in
+// Kubernetes, and a random suffix.
+func NewMigrationLease(pool *pgxpool.Pool) *MigrationLease {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "srs"
+	}
+
+	return &MigrationLease{pool: pool, holder: hostname + "-" + uuid.NewString()[:8], ttl: migrationLeaseTTL}
+}
+
+// Acquire waits until it holds the lease, polling while another replica does,
+// and renews it in the background until release. The returned context is
+// cancelled with ErrMigrationLeaseLost as soon as a renewal fails, so the work
+// done under the lease stops before another replica can take it over.
+func (l *MigrationLease) Acquire(ctx context.Context) (leaseCtx context.Context, release func(), err error) {
+	if _, err := l.pool.Exec(ctx, createMigrationLeaseQuery); err != nil {
+		return nil, nil, fmt.Errorf("error creating migration lease: %w", err)
+	}
+
+	for {
+		acquired, err := l.tryAcquire(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if acquired {
+			break
+		}
+
+		slog.InfoContext(ctx, "waiting for another replica to migrate the database")
+		select {
+		case <-ctx.Done():
+			return nil, nil, ctx.Err()
+		case <-time.After(migrationLeasePoll):
+		}
+	}
+
+	leaseCtx, cancelLease := context.WithCancelCause(ctx)
+	renewCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	renewed := make(chan struct{})
+	go func() {
+		defer close(renewed)
+		l.renew(renewCtx, cancelLease)
+	}()
+
+	return leaseCtx, func() {
+		cancel()
+		<-renewed
+		cancelLease(nil)
+		l.release(context.WithoutCancel(ctx))
+	}, nil
+}
+
+func (l *MigrationLease) tryAcquire(ctx context.Context) (bool, error) {
+	var holder string
+	err := l.pool.QueryRow(ctx, acquireMigrationLeaseQuery, pgx.NamedArgs{
+		"holder": l.holder,
+		"ttl":    l.ttl.Milliseconds(),
+	}).Scan(&holder)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, fmt.Errorf("error acquiring migration lease: %w", err)
+	}
+
+	return true, nil
+}
+
+// renew extends the lease until ctx is done. When a renewal fails it cancels
+// the lease context and stops: the lease cannot be trusted any more.
+func (l *MigrationLease) renew(ctx context.Context, cancelLease context.CancelCauseFunc) {
+	ticker := time.NewTicker(l.ttl / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			acquired, err := l.tryAcquire(ctx)
+			switch {
+			case ctx.Err() != nil:
+				return
+			case err != nil:
+				slog.ErrorContext(ctx, "error renewing migration lease", "error", err)
+				cancelLease(fmt.Errorf("%w: %w", ErrMigrationLeaseLost, err))
+				return
+			case !acquired:
+				slog.ErrorContext(ctx, "migration lease lost", "holder", l.holder)
+				cancelLease(ErrMigrationLeaseLost)
+				return
+			}
+		}
+	}
+}
+
+func (l *MigrationLease) release(ctx context.Context) {
+	_, err := l.pool.Exec(ctx, `DELETE FROM migration_lease WHERE id = 1 AND holder = @holder`, pgx.NamedArgs{"holder": l.holder})
+	if err != nil {
+		slog.ErrorContext(ctx, "error releasing migration lease", "error", err)
+	}
+}
+
+// Migrate applies the pending migrations embedded in the binary, or in the
+// configured directory, once it holds the migration lease. The replicas
+// waiting for the lease find nothing left to apply. Losing the lease stops the
+// migrations and fails with ErrMigrationLeaseLost.
+func Migrate(ctx context.Context, pool *pgxpool.Pool, configuration config.Database, applicationName string) error {
+	leaseCtx, release, err := NewMigrationLease(pool).Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	migrator, err := NewMigrator(configuration, applicationName)
+	if err != nil {
+		return err
+	}
+	defer migrator.Close()
+
+	// Stop between two migrations on shutdown or when the lease is lost,
+	// rather than leaving one dirty.
+	stop := context.AfterFunc(leaseCtx, migrator.Stop)
+	defer stop()
+
+	if err := migrator.Up(0); err != nil {
+		return fmt.Errorf("error applying migrations: %w", err)
+	}
+
+	if cause := context.Cause(leaseCtx); errors.Is(cause, ErrMigrationLeaseLost) {
+		return fmt.Errorf("error applying migrations: %w", cause)
+	}
+
+	return nil
+}
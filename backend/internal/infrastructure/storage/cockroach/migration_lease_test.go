@@ -0,0 +1,36 @@
+package cockroach
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenewCancelsTheLeaseWhenItFails(t *testing.T) {
+	// Nothing listens on port 1, so every renewal fails to connect.
+	pool, err := pgxpool.New(context.Background(), "postgresql://srs@127.0.0.1:1/srs?connect_timeout=1")
+	require.NoError(t, err)
+	defer pool.Close()
+
+	lease := &MigrationLease{pool: pool, holder: "test", ttl: 30 * time.Millisecond}
+	leaseCtx, cancelLease := context.WithCancelCause(context.Background())
+	defer cancelLease(nil)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		lease.renew(context.Background(), cancelLease)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("renew kept running after a failed renewal")
+	}
+
+	assert.ErrorIs(t, context.Cause(leaseCtx), ErrMigrationLeaseLost)
+}
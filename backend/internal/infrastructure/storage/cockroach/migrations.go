@@ -0,0 +1,37 @@
+package cockroach
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// CheckMigrationVersion fails when the schema is older than expected or a
+// migration was left half applied. A newer schema is accepted, so the pods of
+// the previous release keep serving during a rolling update.
+func CheckMigrationVersion(ctx context.Context, pool *pgxpool.Pool, expected uint) error {
+	var version int64
+	var dirty bool
+
+	err := pool.QueryRow(ctx, "SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&version, &dirty)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("no migration applied, expected version %d", expected)
+	}
+
+	if err != nil {
+		return fmt.Errorf("error reading migration version: %w", err)
+	}
+
+	if dirty {
+		return fmt.Errorf("migration %d is dirty", version)
+	}
+
+	if version < int64(expected) {
+		return fmt.Errorf("database is at migration %d, expected %d", version, expected)
+	}
+
+	return nil
+}
@@ -0,0 +1,187 @@
+package cockroach
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/golang-migrate/migrate/v4"
+	_ "github.com/golang-migrate/migrate/v4/database/cockroachdb"
+	"github.com/golang-migrate/migrate/v4/source"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	"github.com/rubenpad/srs/database"
+	"github.com/rubenpad/srs/internal/infrastructure/config"
+)
+
+// Migration is a migration of the source and whether the database has it.
+type Migration struct {
+	Version uint
+	Name    string
+	Applied bool
+}
+
+// MigrationStatus is the version of the database and the migrations of the
+// source.
+type MigrationStatus struct {
+	// Version is 0 when no migration was applied.
+	Version    uint
+	Dirty      bool
+	Migrations []Migration
+}
+
+// Migrator applies and rolls back the schema migrations.
+type Migrator struct {
+	migrate *migrate.Migrate
+	source  source.Driver
+}
+
+// NewMigrator connects to the database with the migrations of the configured
+// directory, or the ones embedded in the binary when none is configured.
+func NewMigrator(configuration config.Database, applicationName string) (*Migrator, error) {
+	databaseURL, err := MigrationURL(configuration, applicationName)
+	if err != nil {
+		return nil, err
+	}
+
+	var migrations fs.FS = database.Migrations
+	directory := "migrations"
+	if configuration.DatabaseMigrationsPath != "" {
+		migrations = os.DirFS(configuration.DatabaseMigrationsPath)
+		directory = "."
+	}
+
+	sourceDriver, err := iofs.New(migrations, directory)
+	if err != nil {
+		return nil, fmt.Errorf("error reading migrations: %w", err)
+	}
+
+	instance, err := migrate.NewWithSourceInstance("iofs", sourceDriver, databaseURL)
+	if err != nil {
+		sourceDriver.Close()
+		return nil, fmt.Errorf("error connecting to the database: %w", err)
+	}
+
+	instance.Log = migrationLogger{}
+	return &Migrator{migrate: instance, source: sourceDriver}, nil
+}
+
+// Up applies the next n migrations, or all of them when n is 0. Having none
+// to apply is not an error.
+func (m *Migrator) Up(n uint) error {
+	var err error
+	if n == 0 {
+		err = m.migrate.Up()
+	} else {
+		err = m.migrate.Steps(int(n))
+	}
+
+	return ignoreNoChange(err)
+}
+
+// Down rolls back the last n migrations.
+func (m *Migrator) Down(n uint) error {
+	if n == 0 {
+		return errors.New("the number of migrations to roll back is required")
+	}
+
+	return ignoreNoChange(m.migrate.Steps(-int(n)))
+}
+
+// Goto applies or rolls back the migrations up to version.
+func (m *Migrator) Goto(version uint) error {
+	return ignoreNoChange(m.migrate.Migrate(version))
+}
+
+// Force sets the version without running any migration and clears the dirty
+// flag, once a failed migration was fixed by hand. -1 means no migration.
+func (m *Migrator) Force(version int) error {
+	return m.migrate.Force(version)
+}
+
+// Version returns the version of the database, 0 when no migration was
+// applied, and whether its last migration failed.
+func (m *Migrator) Version() (uint, bool, error) {
+	version, dirty, err := m.migrate.Version()
+	if errors.Is(err, migrate.ErrNilVersion) {
+		return 0, false, nil
+	}
+
+	return version, dirty, err
+}
+
+// Status lists the migrations of the source with the ones already applied.
+func (m *Migrator) Status() (MigrationStatus, error) {
+	version, dirty, err := m.Version()
+	if err != nil {
+		return MigrationStatus{}, err
+	}
+
+	migrations, err := listMigrations(m.source)
+	if err != nil {
+		return MigrationStatus{}, err
+	}
+
+	for i := range migrations {
+		migrations[i].Applied = migrations[i].Version <= version
+	}
+
+	return MigrationStatus{Version: version, Dirty: dirty, Migrations: migrations}, nil
+}
+
+// Stop makes a running command stop after the current migration, so it is
+// not left dirty.
+func (m *Migrator) Stop() {
+	select {
+	case m.migrate.GracefulStop <- true:
+	default:
+	}
+}
+
+func (m *Migrator) Close() error {
+	sourceErr, databaseErr := m.migrate.Close()
+	return errors.Join(sourceErr, databaseErr)
+}
+
+func listMigrations(sourceDriver source.Driver) ([]Migration, error) {
+	var migrations []Migration
+
+	version, err := sourceDriver.First()
+	for err == nil {
+		body, name, readErr := sourceDriver.ReadUp(version)
+		if readErr != nil {
+			return nil, fmt.Errorf("error reading migration %d: %w", version, readErr)
+		}
+
+		body.Close()
+		migrations = append(migrations, Migration{Version: version, Name: name})
+		version, err = sourceDriver.Next(version)
+	}
+
+	if !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("error reading migrations: %w", err)
+	}
+
+	return migrations, nil
+}
+
+func ignoreNoChange(err error) error {
+	if errors.Is(err, migrate.ErrNoChange) {
+		return nil
+	}
+
+	return err
+}
+
+// migrationLogger reports every migration run.
+type migrationLogger struct{}
+
+func (migrationLogger) Printf(format string, v ...any) {
+	slog.Info(strings.TrimSpace(fmt.Sprintf(format, v...)))
+}
+
+func (migrationLogger) Verbose() bool {
+	return false
+}
@@ -0,0 +1,44 @@
+package cockroach
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	"github.com/rubenpad/srs/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListMigrations(t *testing.T) {
+	t.Run("embedded migrations", func(t *testing.T) {
+		sourceDriver, err := iofs.New(database.Migrations, "migrations")
+		require.NoError(t, err)
+		defer sourceDriver.Close()
+
+		migrations, err := listMigrations(sourceDriver)
+		require.NoError(t, err)
+
+		latest, err := database.LatestVersion()
+		require.NoError(t, err)
+		require.NotEmpty(t, migrations)
+		assert.Equal(t, Migration{Version: 1, Name: "create_stock_tables"}, migrations[0])
+		assert.Equal(t, latest, migrations[len(migrations)-1].Version)
+	})
+
+	t.Run("directory", func(t *testing.T) {
+		directory := t.TempDir()
+		for _, name := range []string{"000002_second.up.sql", "000001_first.up.sql", "000001_first.down.sql"} {
+			require.NoError(t, os.WriteFile(filepath.Join(directory, name), []byte("SELECT 1;"), 0o600))
+		}
+
+		sourceDriver, err := iofs.New(os.DirFS(directory), ".")
+		require.NoError(t, err)
+		defer sourceDriver.Close()
+
+		migrations, err := listMigrations(sourceDriver)
+		require.NoError(t, err)
+		assert.Equal(t, []Migration{{Version: 1, Name: "first"}, {Version: 2, Name: "second"}}, migrations)
+	})
+}
@@ -0,0 +1,56 @@
+package cockroach
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rubenpad/srs/internal/domain/entity"
+)
+
+type SectorRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewSectorRepository(pool *pgxpool.Pool) *SectorRepository {
+	return &SectorRepository{pool}
+}
+
+func (sr *SectorRepository) GetTickerActivity(ctx context.Context, since time.Time) ([]entity.TickerActivity, error) {
+	query := `
+		SELECT
+			COALESCE(NULLIF(c.sector, ''), @unclassified) AS sector,
+			COALESCE(NULLIF(c.industry, ''), @unclassified) AS industry,
+			sr.ticker,
+			COUNT(*) AS ratings,
+			COUNT(CASE WHEN sr.action = 'upgraded by' THEN 1 ELSE NULL END) AS upgrades,
+			COUNT(CASE WHEN sr.action = 'downgraded by' THEN 1 ELSE NULL END) AS downgrades,
+			SUM(sr.score) AS score_sum,
+			SUM(sr.target_price_change) AS target_price_change_sum
+		FROM stock_rating sr
+		LEFT JOIN company c ON c.ticker = sr.ticker
+		WHERE sr.time >= @since
+		GROUP BY 1, 2, 3
+		ORDER BY 1, 2, 3
+	`
+
+	args := pgx.NamedArgs{"since": since, "unclassified": entity.UnclassifiedSector}
+	rows, err := sr.pool.Query(ctx, query, args)
+
+	if err != nil {
+		errorMessage := "error getting ticker activity"
+		slog.ErrorContext(ctx, errorMessage, "error", err)
+		return nil, errors.New(errorMessage)
+	}
+
+	defer rows.Close()
+
+	return pgx.CollectRows(rows, pgx.RowToStructByName[entity.TickerActivity])
+}
+
+func (sr *SectorRepository) GetSectorRecommendations(ctx context.Context, sector string, since time.Time, pageSize int, sortBy string) ([]entity.StockRatingAggregate, error) {
+	return getStockRecommendations(ctx, sr.pool, pageSize, sortBy, sector, since)
+}
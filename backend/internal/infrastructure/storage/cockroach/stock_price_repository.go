@@ -0,0 +1,90 @@
+package cockroach
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rubenpad/srs/internal/domain/entity"
+)
+
+const upsertCandleQuery = `UPSERT INTO stock_price (
+				ticker,
+				time,
+				open,
+				high,
+				low,
+				close,
+				volume)
+			  VALUES (
+				@ticker,
+				@time,
+				@open,
+				@high,
+				@low,
+				@close,
+				@volume)`
+
+type StockPriceRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewStockPriceRepository(pool *pgxpool.Pool) *StockPriceRepository {
+	return &StockPriceRepository{pool}
+}
+
+func (spr *StockPriceRepository) SaveCandles(ctx context.Context, candles []entity.Candle) error {
+	batch := &pgx.Batch{}
+	for _, candle := range candles {
+		batch.Queue(upsertCandleQuery, pgx.NamedArgs{
+			"ticker": candle.Ticker,
+			"time":   candle.Time,
+			"open":   candle.Open,
+			"high":   candle.High,
+			"low":    candle.Low,
+			"close":  candle.Close,
+			"volume": candle.Volume,
+		})
+	}
+
+	if err := spr.pool.SendBatch(ctx, batch).Close(); err != nil {
+		errorMessage := "error saving stock prices"
+		slog.ErrorContext(ctx, errorMessage, "error", err)
+		return errors.New(errorMessage)
+	}
+
+	return nil
+}
+
+func (spr *StockPriceRepository) GetCandles(ctx context.Context, ticker string, from, to time.Time) ([]entity.Candle, error) {
+	query := `
+		SELECT
+			ticker,
+			time,
+			open,
+			high,
+			low,
+			close,
+			volume
+		FROM stock_price
+		WHERE ticker = @ticker
+		AND time BETWEEN @from AND @to
+		ORDER BY time ASC
+	`
+
+	args := pgx.NamedArgs{"ticker": ticker, "from": from, "to": to}
+	rows, err := spr.pool.Query(ctx, query, args)
+
+	if err != nil {
+		errorMessage := "error getting stock prices"
+		slog.ErrorContext(ctx, errorMessage, "error", err)
+		return nil, errors.New(errorMessage)
+	}
+
+	defer rows.Close()
+
+	return pgx.CollectRows(rows, pgx.RowToStructByName[entity.Candle])
+}
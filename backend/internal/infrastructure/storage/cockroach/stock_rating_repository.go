@@ -3,7 +3,9 @@ package cockroach
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log/slog"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
@@ -20,6 +22,9 @@ const insertQuery = `INSERT INTO stock_rating (
 				rating_to,
 				target_from,
 				target_to,
+				target_from_value,
+				target_to_value,
+				currency,
 				time,
 				target_price_change,
 				score)
@@ -32,10 +37,21 @@ const insertQuery = `INSERT INTO stock_rating (
 				@rating_to,
 				@target_from,
 				@target_to,
+				@target_from_value,
+				@target_to_value,
+				@currency,
 				@time,
 				@target_price_change,
 				@score)`
 
+// latestClose selects the most recent stored close price of the outer query ticker.
+const latestClose = `(SELECT sp.close FROM stock_price sp WHERE sp.ticker = %s ORDER BY sp.time DESC LIMIT 1)`
+
+var recommendationsOrderBy = map[string]string{
+	entity.RecommendationSortDefault: "strong_buy_ratings DESC, buy_ratings DESC, target_price_change DESC, time DESC, score DESC",
+	entity.RecommendationSortUpside:  "implied_upside DESC NULLS LAST, strong_buy_ratings DESC, buy_ratings DESC, time DESC",
+}
+
 type StockRatingRepository struct {
 	pool *pgxpool.Pool
 }
@@ -44,7 +60,7 @@ func NewStockRatingRepository(pool *pgxpool.Pool) *StockRatingRepository {
 	return &StockRatingRepository{pool}
 }
 
-func (srr *StockRatingRepository) GetStockRatings(context context.Context, nextPage string, pageSize int, search string) ([]entity.StockRating, error) {
+func (srr *StockRatingRepository) GetStockRatings(context context.Context, after entity.StockRatingCursor, pageSize int, search string) ([]entity.StockRating, error) {
 	query := `
         SELECT 
             brokerage,
@@ -55,22 +71,34 @@ func (srr *StockRatingRepository) GetStockRatings(context context.Context, nextP
             rating_to,
             target_from,
             target_to,
+            target_from_value,
+            target_to_value,
+            currency,
             time,
             target_price_change,
-			score
+			score,
+			ROUND((target_to_value / NULLIF(` + fmt.Sprintf(latestClose, "stock_rating.ticker") + `, 0) - 1) * 100, 2) AS implied_upside
         FROM stock_rating
-        WHERE (@nextPage = '' OR ticker > @nextPage)
+        WHERE (@ticker = ''
+            OR ticker > @ticker
+            OR (ticker = @ticker AND @brokerage != '' AND (brokerage > @brokerage OR (brokerage = @brokerage AND time < @time))))
 		AND (@search = '' OR UPPER(ticker) BETWEEN UPPER(@search) AND CONCAT(UPPER(@search), 'ÿ'))
-        ORDER BY ticker ASC
+        ORDER BY ticker ASC, brokerage ASC, time DESC
         LIMIT @pageSize
 	`
 
-	args := pgx.NamedArgs{"nextPage": nextPage, "pageSize": pageSize, "search": search}
+	args := pgx.NamedArgs{
+		"ticker":    after.Ticker,
+		"brokerage": after.Brokerage,
+		"time":      after.Time,
+		"pageSize":  pageSize,
+		"search":    search,
+	}
 	rows, err := srr.pool.Query(context, query, args)
 
 	if err != nil {
 		errorMessage := "error getting stock ratings"
-		slog.Error(errorMessage, "error", err)
+		slog.ErrorContext(context, errorMessage, "error", err)
 		return nil, errors.New(errorMessage)
 	}
 
@@ -79,7 +107,7 @@ func (srr *StockRatingRepository) GetStockRatings(context context.Context, nextP
 	return pgx.CollectRows(rows, pgx.RowToStructByName[entity.StockRating])
 }
 
-func (srr *StockRatingRepository) Save(ctx context.Context, stockRating entity.StockRating) {
+func (srr *StockRatingRepository) Save(ctx context.Context, stockRating entity.StockRating) error {
 	args := pgx.NamedArgs{
 		"brokerage":           stockRating.Brokerage,
 		"action":              stockRating.Action,
@@ -89,6 +117,9 @@ func (srr *StockRatingRepository) Save(ctx context.Context, stockRating entity.S
 		"rating_to":           stockRating.RatingTo,
 		"target_from":         stockRating.TargetFrom,
 		"target_to":           stockRating.TargetTo,
+		"target_from_value":   stockRating.TargetFromValue,
+		"target_to_value":     stockRating.TargetToValue,
+		"currency":            stockRating.Currency,
 		"time":                stockRating.Time,
 		"target_price_change": stockRating.TargetPriceChange,
 		"score":               stockRating.Score,
@@ -99,19 +130,34 @@ func (srr *StockRatingRepository) Save(ctx context.Context, stockRating entity.S
 	if err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
-			slog.Info(
+			slog.InfoContext(ctx,
 				"skipping duplicate stock rating - probably running the load data again",
 				"constraint", pgErr.ConstraintName,
 				"data", stockRating,
 			)
-			return
+			return entity.ErrDuplicateStockRating
 		}
 
-		slog.Error("error saving stock rating", "error", err)
+		slog.ErrorContext(ctx, "error saving stock rating", "error", err)
+		return err
 	}
+
+	return nil
+}
+
+func (ssr *StockRatingRepository) GetStockRecommendations(ctx context.Context, pageSize int, sortBy string, sector string) ([]entity.StockRatingAggregate, error) {
+	return getStockRecommendations(ctx, ssr.pool, pageSize, sortBy, sector, time.Time{})
 }
 
-func (ssr *StockRatingRepository) GetStockRecommendations(ctx context.Context, pageSize int) ([]entity.StockRatingAggregate, error) {
+// getStockRecommendations aggregates the ratings since the given time, all of
+// them when it is zero, of the sector's tickers or of every ticker when the
+// sector is empty.
+func getStockRecommendations(ctx context.Context, pool *pgxpool.Pool, pageSize int, sortBy string, sector string, since time.Time) ([]entity.StockRatingAggregate, error) {
+	orderBy, ok := recommendationsOrderBy[sortBy]
+	if !ok {
+		return nil, fmt.Errorf("unknown recommendations sort %q", sortBy)
+	}
+
 	query := `
 		WITH latest_stock_ratings AS
   		(SELECT
@@ -141,40 +187,70 @@ func (ssr *StockRatingRepository) GetStockRecommendations(ctx context.Context, p
 				target_price_change,
 				score,
              	ROW_NUMBER() OVER (PARTITION BY ticker, brokerage ORDER BY time DESC) AS rn
-      		FROM stock_rating) AS ranked_stock_ratings
+      		FROM stock_rating
+			WHERE time >= @since
+			AND (@sector = '' OR ticker IN (SELECT ticker FROM company WHERE LOWER(sector) = LOWER(@sector)))) AS ranked_stock_ratings
    		WHERE rn <= 5
-   		GROUP BY ticker)
-		SELECT
+   		GROUP BY ticker),
+		consensus_targets AS
+		(SELECT
 			ticker,
-			time,
-			strong_buy_ratings,
-			buy_ratings,
-			hold_ratings,
-			sell_ratings,
-			score,
-			ROUND(avg_price_change * 100, 2) as target_price_change,
+			percentile_cont(0.5) WITHIN GROUP (ORDER BY target::FLOAT) AS median,
+			AVG(target) AS mean,
+			MAX(target) AS high,
+			MIN(target) AS low
+		FROM (SELECT
+				ticker,
+				target_to_value AS target,
+				ROW_NUMBER() OVER (PARTITION BY ticker, brokerage ORDER BY time DESC) AS rn
+			FROM stock_rating
+			WHERE time >= @since) AS active_targets
+		WHERE rn = 1 AND target > 0
+		GROUP BY ticker),
+		recommendations AS
+		(SELECT
+			lsr.ticker,
+			lsr.time,
+			lsr.strong_buy_ratings,
+			lsr.buy_ratings,
+			lsr.hold_ratings,
+			lsr.sell_ratings,
+			lsr.score,
+			ROUND(lsr.avg_price_change * 100, 2) as target_price_change,
 			(CASE
-				WHEN rating BETWEEN 4.5 AND 5 THEN 'Strong Buy'
-				WHEN rating BETWEEN 3.5 AND 4.4 THEN 'Buy'
-				WHEN rating BETWEEN 2.5 AND 3.4 THEN 'Hold'
-				WHEN rating BETWEEN 1.5 AND 2.4 THEN 'Sell'
-				WHEN rating BETWEEN 1.0 AND 1.4 THEN 'Strong Sell'
-			END) as rating
-		FROM latest_stock_ratings
-		WHERE ROUND(avg_price_change * 100, 2) > 0
-		ORDER BY strong_buy_ratings DESC, buy_ratings DESC, target_price_change DESC, time DESC, score DESC
+				WHEN lsr.rating BETWEEN 4.5 AND 5 THEN 'Strong Buy'
+				WHEN lsr.rating BETWEEN 3.5 AND 4.4 THEN 'Buy'
+				WHEN lsr.rating BETWEEN 2.5 AND 3.4 THEN 'Hold'
+				WHEN lsr.rating BETWEEN 1.5 AND 2.4 THEN 'Sell'
+				WHEN lsr.rating BETWEEN 1.0 AND 1.4 THEN 'Strong Sell'
+			END) as rating,
+			` + fmt.Sprintf(latestClose, "lsr.ticker") + ` AS current_price,
+			ROUND(ct.median::DECIMAL, 2) AS consensus_target_median,
+			ROUND(ct.mean, 2) AS consensus_target_mean,
+			ct.high AS consensus_target_high,
+			ct.low AS consensus_target_low
+		FROM latest_stock_ratings lsr
+		LEFT JOIN consensus_targets ct ON ct.ticker = lsr.ticker
+		WHERE ROUND(lsr.avg_price_change * 100, 2) > 0)
+		SELECT
+			*,
+			ROUND((consensus_target_median / NULLIF(current_price, 0) - 1) * 100, 2) AS implied_upside
+		FROM recommendations
+		ORDER BY ` + orderBy + `
 		LIMIT @pageSize;	
 	`
 
 	args := pgx.NamedArgs{
 		"pageSize": pageSize,
+		"sector":   sector,
+		"since":    since,
 	}
 
-	rows, err := ssr.pool.Query(ctx, query, args)
+	rows, err := pool.Query(ctx, query, args)
 
 	if err != nil {
 		errorMessage := "error getting stock ratings"
-		slog.Error(errorMessage, "error", err)
+		slog.ErrorContext(ctx, errorMessage, "error", err)
 		return nil, errors.New(errorMessage)
 	}
 
@@ -182,3 +258,174 @@ func (ssr *StockRatingRepository) GetStockRecommendations(ctx context.Context, p
 
 	return pgx.CollectRows(rows, pgx.RowToStructByName[entity.StockRatingAggregate])
 }
+
+func (srr *StockRatingRepository) GetTickers(ctx context.Context) ([]string, error) {
+	rows, err := srr.pool.Query(ctx, `SELECT DISTINCT ticker FROM stock_rating ORDER BY ticker ASC`)
+
+	if err != nil {
+		errorMessage := "error getting tickers"
+		slog.ErrorContext(ctx, errorMessage, "error", err)
+		return nil, errors.New(errorMessage)
+	}
+
+	defer rows.Close()
+
+	return pgx.CollectRows(rows, pgx.RowTo[string])
+}
+
+func (srr *StockRatingRepository) GetLatestStockRatings(ctx context.Context, tickers []string, limit int) ([]entity.StockRating, error) {
+	query := `
+		SELECT
+			brokerage,
+			action,
+			company,
+			ticker,
+			rating_from,
+			rating_to,
+			target_from,
+			target_to,
+			target_from_value,
+			target_to_value,
+			currency,
+			time,
+			target_price_change,
+			score,
+			ROUND((target_to_value / NULLIF(` + fmt.Sprintf(latestClose, "ranked.ticker") + `, 0) - 1) * 100, 2) AS implied_upside
+		FROM (
+			SELECT
+				*,
+				ROW_NUMBER() OVER (PARTITION BY ticker ORDER BY time DESC, brokerage ASC) AS position
+			FROM stock_rating
+			WHERE ticker = ANY(@tickers)
+		) AS ranked
+		WHERE position <= @limit
+		ORDER BY ticker ASC, time DESC, brokerage ASC
+	`
+
+	rows, err := srr.pool.Query(ctx, query, pgx.NamedArgs{"tickers": tickers, "limit": limit})
+	if err != nil {
+		errorMessage := "error getting latest stock ratings"
+		slog.ErrorContext(ctx, errorMessage, "error", err)
+		return nil, errors.New(errorMessage)
+	}
+
+	defer rows.Close()
+
+	return pgx.CollectRows(rows, pgx.RowToStructByName[entity.StockRating])
+}
+
+// GetRatingsMissingTargetValues returns, in primary key order, up to limit
+// ratings after the given one whose numeric targets have not been set.
+func (srr *StockRatingRepository) GetRatingsMissingTargetValues(ctx context.Context, after entity.StockRating, limit int) ([]entity.StockRating, error) {
+	query := `
+		SELECT
+			ticker,
+			brokerage,
+			time,
+			target_from,
+			target_to
+		FROM stock_rating
+		WHERE (ticker, brokerage, time) > (@ticker, @brokerage, @time)
+		AND (target_from_value IS NULL OR target_to_value IS NULL OR currency IS NULL)
+		ORDER BY ticker ASC, brokerage ASC, time ASC
+		LIMIT @limit
+	`
+
+	args := pgx.NamedArgs{"ticker": after.Ticker, "brokerage": after.Brokerage, "time": after.Time, "limit": limit}
+	rows, err := srr.pool.Query(ctx, query, args)
+
+	if err != nil {
+		errorMessage := "error getting stock ratings missing target values"
+		slog.ErrorContext(ctx, errorMessage, "error", err)
+		return nil, errors.New(errorMessage)
+	}
+
+	defer rows.Close()
+
+	return pgx.CollectRows(rows, pgx.RowToStructByNameLax[entity.StockRating])
+}
+
+func (srr *StockRatingRepository) UpdateTargetValues(ctx context.Context, stockRating entity.StockRating) error {
+	query := `
+		UPDATE stock_rating
+		SET target_from_value = @target_from_value, target_to_value = @target_to_value, currency = @currency
+		WHERE ticker = @ticker AND brokerage = @brokerage AND time = @time
+	`
+
+	args := pgx.NamedArgs{
+		"ticker":            stockRating.Ticker,
+		"brokerage":         stockRating.Brokerage,
+		"time":              stockRating.Time,
+		"target_from_value": stockRating.TargetFromValue,
+		"target_to_value":   stockRating.TargetToValue,
+		"currency":          stockRating.Currency,
+	}
+
+	if _, err := srr.pool.Exec(ctx, query, args); err != nil {
+		slog.ErrorContext(ctx, "error updating stock rating target values", "error", err)
+		return err
+	}
+
+	return nil
+}
+
+// GetBrokerageCounts returns the number of stored ratings of every brokerage name.
+func (srr *StockRatingRepository) GetBrokerageCounts(ctx context.Context) (map[string]int64, error) {
+	rows, err := srr.pool.Query(ctx, `SELECT brokerage, COUNT(*) FROM stock_rating GROUP BY brokerage`)
+	if err != nil {
+		errorMessage := "error getting brokerages"
+		slog.ErrorContext(ctx, errorMessage, "error", err)
+		return nil, errors.New(errorMessage)
+	}
+
+	defer rows.Close()
+
+	counts := make(map[string]int64)
+	var brokerage string
+	var count int64
+	_, err = pgx.ForEachRow(rows, []any{&brokerage, &count}, func() error {
+		counts[brokerage] = count
+		return nil
+	})
+
+	return counts, err
+}
+
+// MergeBrokerage renames the ratings of alias to canonical. Ratings that
+// already exist under the canonical name are duplicates and are deleted.
+func (srr *StockRatingRepository) MergeBrokerage(ctx context.Context, alias, canonical string) (int64, int64, error) {
+	var renamed, merged int64
+
+	err := pgx.BeginFunc(ctx, srr.pool, func(tx pgx.Tx) error {
+		args := pgx.NamedArgs{"alias": alias, "canonical": canonical}
+
+		result, err := tx.Exec(ctx, `
+			UPDATE stock_rating SET brokerage = @canonical
+			WHERE brokerage = @alias
+			AND NOT EXISTS (
+				SELECT 1 FROM stock_rating AS existing
+				WHERE existing.ticker = stock_rating.ticker
+				AND existing.brokerage = @canonical
+				AND existing.time = stock_rating.time)
+		`, args)
+		if err != nil {
+			return err
+		}
+		renamed = result.RowsAffected()
+
+		result, err = tx.Exec(ctx, `DELETE FROM stock_rating WHERE brokerage = @alias`, args)
+		if err != nil {
+			return err
+		}
+		merged = result.RowsAffected()
+
+		return nil
+	})
+
+	if err != nil {
+		slog.ErrorContext(ctx, "error merging brokerage", "error", err, "alias", alias, "canonical", canonical)
+		return 0, 0, err
+	}
+
+	return renamed, merged, nil
+}
@@ -0,0 +1,41 @@
+package cockroach
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/rubenpad/srs/internal/domain/entity"
+	"github.com/rubenpad/srs/internal/infrastructure/config"
+	"github.com/rubenpad/srs/internal/infrastructure/storage/storagetest"
+	"github.com/stretchr/testify/require"
+)
+
+// TestStockRatingRepository runs the conformance tests against the database
+// of SRS_TEST_DATABASE_URL, emptying its ratings, prices and companies. It
+// is skipped when the variable is not set.
+func TestStockRatingRepository(t *testing.T) {
+	databaseURL := os.Getenv("SRS_TEST_DATABASE_URL")
+	if databaseURL == "" {
+		t.Skip("SRS_TEST_DATABASE_URL is not set")
+	}
+
+	ctx := context.Background()
+	configuration := config.Database{DatabaseUrl: config.Secret(databaseURL)}
+
+	migrator, err := NewMigrator(configuration, "srs-test")
+	require.NoError(t, err)
+	require.NoError(t, migrator.Up(0))
+	require.NoError(t, migrator.Close())
+
+	pool, err := NewPool(ctx, configuration, "srs-test")
+	require.NoError(t, err)
+	t.Cleanup(pool.Close)
+
+	storagetest.StockRatingRepository(t, func(t *testing.T) entity.IStockRatingRepository {
+		_, err := pool.Exec(ctx, "TRUNCATE stock_rating, stock_price, company")
+		require.NoError(t, err)
+
+		return NewStockRatingRepository(pool)
+	})
+}
@@ -0,0 +1,97 @@
+package cockroach
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	srsotel "github.com/rubenpad/srs/internal/infrastructure/otel"
+)
+
+// QueryTracer is a pgx.QueryTracer and pgx.BatchTracer creating a client
+// span for every query and batch run through the pool. Set it as the
+// ConnConfig.Tracer of the pool.
+type QueryTracer struct {
+	tracer trace.Tracer
+}
+
+func NewQueryTracer() *QueryTracer {
+	return &QueryTracer{tracer: otel.Tracer(srsotel.ScopeName)}
+}
+
+func (qt *QueryTracer) TraceQueryStart(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	operation := queryOperation(data.SQL)
+
+	ctx, _ = qt.tracer.Start(ctx, operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system", "cockroachdb"),
+			attribute.String("db.namespace", conn.Config().Database),
+			attribute.String("db.operation.name", operation),
+			attribute.String("db.query.text", data.SQL),
+		))
+
+	return ctx
+}
+
+func (qt *QueryTracer) TraceQueryEnd(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryEndData) {
+	span := trace.SpanFromContext(ctx)
+	defer span.End()
+
+	if data.Err != nil && !errors.Is(data.Err, pgx.ErrNoRows) {
+		span.RecordError(data.Err)
+		span.SetStatus(codes.Error, data.Err.Error())
+		return
+	}
+
+	span.SetAttributes(attribute.Int64("db.response.rows_affected", data.CommandTag.RowsAffected()))
+}
+
+func (qt *QueryTracer) TraceBatchStart(ctx context.Context, conn *pgx.Conn, data pgx.TraceBatchStartData) context.Context {
+	ctx, _ = qt.tracer.Start(ctx, "BATCH",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system", "cockroachdb"),
+			attribute.String("db.namespace", conn.Config().Database),
+			attribute.String("db.operation.name", "BATCH"),
+			attribute.Int("db.operation.batch.size", data.Batch.Len()),
+		))
+
+	return ctx
+}
+
+// TraceBatchQuery records the queries of a batch as events of its span.
+func (qt *QueryTracer) TraceBatchQuery(ctx context.Context, conn *pgx.Conn, data pgx.TraceBatchQueryData) {
+	attributes := []attribute.KeyValue{attribute.String("db.query.text", data.SQL)}
+	if data.Err != nil {
+		attributes = append(attributes, attribute.String("error", data.Err.Error()))
+	}
+
+	trace.SpanFromContext(ctx).AddEvent("query", trace.WithAttributes(attributes...))
+}
+
+func (qt *QueryTracer) TraceBatchEnd(ctx context.Context, conn *pgx.Conn, data pgx.TraceBatchEndData) {
+	span := trace.SpanFromContext(ctx)
+	defer span.End()
+
+	if data.Err != nil {
+		span.RecordError(data.Err)
+		span.SetStatus(codes.Error, data.Err.Error())
+	}
+}
+
+// queryOperation returns the SQL command of query, naming its span.
+func queryOperation(query string) string {
+	fields := strings.Fields(query)
+	if len(fields) == 0 {
+		return "query"
+	}
+
+	return strings.ToUpper(fields[0])
+}
@@ -0,0 +1,23 @@
+package cockroach
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryOperation(t *testing.T) {
+	tests := []struct {
+		query     string
+		operation string
+	}{
+		{"\n        SELECT ticker FROM stock_rating", "SELECT"},
+		{"insert into stock_rating (ticker) values ($1)", "INSERT"},
+		{"WITH claimed AS (UPDATE webhook_delivery SET status = 'pending')", "WITH"},
+		{"", "query"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.operation, queryOperation(tt.query), tt.query)
+	}
+}
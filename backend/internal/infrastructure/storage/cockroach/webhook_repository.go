@@ -0,0 +1,243 @@
+package cockroach
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rubenpad/srs/internal/domain/entity"
+)
+
+type webhookDispatchRow struct {
+	entity.WebhookDelivery
+	Url    string
+	Secret string
+}
+
+type WebhookRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewWebhookRepository(pool *pgxpool.Pool) *WebhookRepository {
+	return &WebhookRepository{pool}
+}
+
+func (wr *WebhookRepository) SaveWebhook(ctx context.Context, webhook entity.Webhook) (*entity.Webhook, error) {
+	query := `
+		INSERT INTO webhook (url, secret, events)
+		VALUES (@url, @secret, @events)
+		RETURNING id, url, secret, events, created_at
+	`
+
+	args := pgx.NamedArgs{
+		"url":    webhook.Url,
+		"secret": webhook.Secret,
+		"events": webhook.Events,
+	}
+
+	rows, err := wr.pool.Query(ctx, query, args)
+	if err != nil {
+		errorMessage := "error saving webhook"
+		slog.ErrorContext(ctx, errorMessage, "error", err)
+		return nil, errors.New(errorMessage)
+	}
+
+	saved, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[entity.Webhook])
+	if err != nil {
+		return nil, err
+	}
+
+	return &saved, nil
+}
+
+func (wr *WebhookRepository) GetWebhooks(ctx context.Context) ([]entity.Webhook, error) {
+	query := `
+		SELECT
+			id,
+			url,
+			secret,
+			events,
+			created_at
+		FROM webhook
+		ORDER BY id ASC
+	`
+
+	rows, err := wr.pool.Query(ctx, query)
+	if err != nil {
+		errorMessage := "error getting webhooks"
+		slog.ErrorContext(ctx, errorMessage, "error", err)
+		return nil, errors.New(errorMessage)
+	}
+
+	return pgx.CollectRows(rows, pgx.RowToStructByName[entity.Webhook])
+}
+
+func (wr *WebhookRepository) DeleteWebhook(ctx context.Context, id int64) error {
+	result, err := wr.pool.Exec(ctx, `DELETE FROM webhook WHERE id = @id`, pgx.NamedArgs{"id": id})
+	if err != nil {
+		errorMessage := "error deleting webhook"
+		slog.ErrorContext(ctx, errorMessage, "error", err)
+		return errors.New(errorMessage)
+	}
+
+	if result.RowsAffected() == 0 {
+		return entity.ErrWebhookNotFound
+	}
+
+	return nil
+}
+
+func (wr *WebhookRepository) EnqueueDeliveries(ctx context.Context, event string, payload []byte) (int64, error) {
+	query := `
+		INSERT INTO webhook_delivery (webhook_id, event, payload)
+		SELECT id, @event, @payload
+		FROM webhook
+		WHERE @event = ANY(events)
+	`
+
+	result, err := wr.pool.Exec(ctx, query, pgx.NamedArgs{"event": event, "payload": string(payload)})
+	if err != nil {
+		errorMessage := "error enqueuing webhook deliveries"
+		slog.ErrorContext(ctx, errorMessage, "error", err, "event", event)
+		return 0, errors.New(errorMessage)
+	}
+
+	return result.RowsAffected(), nil
+}
+
+func (wr *WebhookRepository) ClaimDeliveries(ctx context.Context, limit int, leaseUntil time.Time) ([]entity.WebhookDispatch, error) {
+	query := `
+		WITH claimed AS (
+			UPDATE webhook_delivery
+			SET next_attempt_at = @leaseUntil
+			WHERE id IN (
+				SELECT id
+				FROM webhook_delivery
+				WHERE status = @pending AND next_attempt_at <= now()
+				ORDER BY next_attempt_at ASC
+				LIMIT @limit
+			)
+			RETURNING
+				id,
+				webhook_id,
+				event,
+				payload,
+				status,
+				attempts,
+				last_status_code,
+				last_error,
+				next_attempt_at,
+				created_at,
+				delivered_at
+		)
+		SELECT claimed.*, webhook.url, webhook.secret
+		FROM claimed
+		JOIN webhook ON webhook.id = claimed.webhook_id
+	`
+
+	args := pgx.NamedArgs{
+		"leaseUntil": leaseUntil,
+		"pending":    entity.DeliveryStatusPending,
+		"limit":      limit,
+	}
+
+	rows, err := wr.pool.Query(ctx, query, args)
+	if err != nil {
+		errorMessage := "error claiming webhook deliveries"
+		slog.ErrorContext(ctx, errorMessage, "error", err)
+		return nil, errors.New(errorMessage)
+	}
+
+	claimed, err := pgx.CollectRows(rows, pgx.RowToStructByName[webhookDispatchRow])
+	if err != nil {
+		return nil, err
+	}
+
+	dispatches := make([]entity.WebhookDispatch, 0, len(claimed))
+	for _, row := range claimed {
+		dispatches = append(dispatches, entity.WebhookDispatch{
+			Webhook:  entity.Webhook{ID: row.WebhookID, Url: row.Url, Secret: row.Secret},
+			Delivery: row.WebhookDelivery,
+		})
+	}
+
+	return dispatches, nil
+}
+
+func (wr *WebhookRepository) UpdateDelivery(ctx context.Context, delivery entity.WebhookDelivery) error {
+	query := `
+		UPDATE webhook_delivery
+		SET
+			status = @status,
+			attempts = @attempts,
+			last_status_code = @lastStatusCode,
+			last_error = @lastError,
+			next_attempt_at = @nextAttemptAt,
+			delivered_at = @deliveredAt
+		WHERE id = @id
+	`
+
+	args := pgx.NamedArgs{
+		"id":             delivery.ID,
+		"status":         delivery.Status,
+		"attempts":       delivery.Attempts,
+		"lastStatusCode": delivery.LastStatusCode,
+		"lastError":      delivery.LastError,
+		"nextAttemptAt":  delivery.NextAttemptAt,
+		"deliveredAt":    delivery.DeliveredAt,
+	}
+
+	if _, err := wr.pool.Exec(ctx, query, args); err != nil {
+		errorMessage := "error updating webhook delivery"
+		slog.ErrorContext(ctx, errorMessage, "error", err, "id", delivery.ID)
+		return errors.New(errorMessage)
+	}
+
+	return nil
+}
+
+// GetDeliveries returns the deliveries of a webhook newest first. nextPage is
+// the id of the last delivery of the previous page.
+func (wr *WebhookRepository) GetDeliveries(ctx context.Context, webhookID int64, nextPage string, pageSize int) ([]entity.WebhookDelivery, error) {
+	var before int64
+	if nextPage != "" {
+		parsed, err := strconv.ParseInt(nextPage, 10, 64)
+		if err != nil {
+			return nil, entity.ErrInvalidCursor
+		}
+		before = parsed
+	}
+
+	query := `
+		SELECT
+			id,
+			webhook_id,
+			event,
+			payload,
+			status,
+			attempts,
+			last_status_code,
+			last_error,
+			next_attempt_at,
+			created_at,
+			delivered_at
+		FROM webhook_delivery
+		WHERE webhook_id = @webhookID AND (@before = 0 OR id < @before)
+		ORDER BY id DESC
+		LIMIT @pageSize
+	`
+
+	args := pgx.NamedArgs{"webhookID": webhookID, "before": before, "pageSize": pageSize}
+	rows, err := wr.pool.Query(ctx, query, args)
+	if err != nil {
+		errorMessage := "error getting webhook deliveries"
+		slog.ErrorContext(ctx, errorMessage, "error", err)
+		return nil, errors.New(errorMessage)
+	}
+
+	return pgx.CollectRows(rows, pgx.RowToStructByName[entity.WebhookDelivery])
+}
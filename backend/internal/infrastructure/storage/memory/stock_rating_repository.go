@@ -0,0 +1,317 @@
+// Package memory stores the stock ratings in memory, for the tests and the
+// demo mode. Nothing survives a restart.
+package memory
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rubenpad/srs/internal/domain/entity"
+)
+
+// ratingValues weighs the ratings the same way as the recommendations query
+// of the cockroach repository: 5 for the buy ratings down to 2 for the sell
+// ones. Ratings missing here are left out of the average.
+var ratingValues = map[string]int{
+	"Strong-Buy": 5, "Buy": 5, "Top Pick": 5, "Positive": 5, "Outperform": 5, "Outperformer": 5, "Market Outperform": 5, "Sector Outperform": 5,
+	"Overweight": 4, "Equal Weight": 4, "Sector Weight": 4, "Peer Perform": 4, "In-Line": 4, "Inline": 4,
+	"Neutral": 3, "Market Perform": 3, "Sector Perform": 3, "Hold": 3,
+	"Sell": 2, "Reduce": 2, "Negative": 2, "Underweight": 2, "Underperform": 2, "Sector Underperform": 2,
+}
+
+// ratingsPerBrokerage is the number of recent ratings of every brokerage
+// counted in the recommendations.
+const ratingsPerBrokerage = 5
+
+type stockRatingKey struct {
+	ticker    string
+	brokerage string
+	time      time.Time
+}
+
+// StockRatingRepository has the semantics of the cockroach one. It holds no
+// stock prices nor companies, so the current price and the implied upside
+// are always empty and a sector matches no rating.
+type StockRatingRepository struct {
+	mu      sync.RWMutex
+	ratings map[stockRatingKey]entity.StockRating
+}
+
+func NewStockRatingRepository() *StockRatingRepository {
+	return &StockRatingRepository{ratings: make(map[stockRatingKey]entity.StockRating)}
+}
+
+func (srr *StockRatingRepository) Save(ctx context.Context, stockRating entity.StockRating) error {
+	stockRating = normalize(stockRating)
+	key := stockRatingKey{stockRating.Ticker, stockRating.Brokerage, stockRating.Time}
+
+	srr.mu.Lock()
+	defer srr.mu.Unlock()
+
+	if _, found := srr.ratings[key]; found {
+		slog.InfoContext(ctx, "skipping duplicate stock rating - probably running the load data again", "data", stockRating)
+		return entity.ErrDuplicateStockRating
+	}
+
+	srr.ratings[key] = stockRating
+	return nil
+}
+
+func (srr *StockRatingRepository) GetStockRatings(ctx context.Context, after entity.StockRatingCursor, pageSize int, search string) ([]entity.StockRating, error) {
+	search = strings.ToUpper(search)
+
+	ratings := srr.all(func(rating entity.StockRating) bool {
+		return isAfter(rating, after) && strings.HasPrefix(strings.ToUpper(rating.Ticker), search)
+	})
+
+	slices.SortFunc(ratings, func(a, b entity.StockRating) int {
+		return cmp.Or(strings.Compare(a.Ticker, b.Ticker), strings.Compare(a.Brokerage, b.Brokerage), b.Time.Compare(a.Time))
+	})
+
+	return ratings[:min(len(ratings), max(pageSize, 0))], nil
+}
+
+func (srr *StockRatingRepository) GetStockRecommendations(ctx context.Context, pageSize int, sortBy string, sector string) ([]entity.StockRatingAggregate, error) {
+	if sortBy != entity.RecommendationSortDefault && sortBy != entity.RecommendationSortUpside {
+		return nil, fmt.Errorf("unknown recommendations sort %q", sortBy)
+	}
+
+	recommendations := []entity.StockRatingAggregate{}
+	if sector != "" {
+		return recommendations, nil
+	}
+
+	for ticker, ratings := range srr.byTicker() {
+		recommendation := aggregate(ticker, ratings)
+		if recommendation.TargetPriceChange > 0 {
+			recommendations = append(recommendations, recommendation)
+		}
+	}
+
+	// Without stock prices the implied upside is always empty, so both sorts
+	// only differ by the target price change.
+	slices.SortFunc(recommendations, func(a, b entity.StockRatingAggregate) int {
+		order := []int{cmp.Compare(b.StrongBuyRatings, a.StrongBuyRatings), cmp.Compare(b.BuyRatings, a.BuyRatings)}
+		if sortBy == entity.RecommendationSortDefault {
+			order = append(order, cmp.Compare(b.TargetPriceChange, a.TargetPriceChange))
+		}
+
+		return cmp.Or(append(order, b.Time.Compare(a.Time), cmp.Compare(b.Score, a.Score), strings.Compare(a.Ticker, b.Ticker))...)
+	})
+
+	return recommendations[:min(len(recommendations), max(pageSize, 0))], nil
+}
+
+func (srr *StockRatingRepository) GetTickers(ctx context.Context) ([]string, error) {
+	tickers := []string{}
+	for ticker := range srr.byTicker() {
+		tickers = append(tickers, ticker)
+	}
+
+	slices.Sort(tickers)
+	return tickers, nil
+}
+
+func (srr *StockRatingRepository) GetLatestStockRatings(ctx context.Context, tickers []string, limit int) ([]entity.StockRating, error) {
+	ratings := srr.all(func(rating entity.StockRating) bool {
+		return slices.Contains(tickers, rating.Ticker)
+	})
+
+	slices.SortFunc(ratings, func(a, b entity.StockRating) int {
+		return cmp.Or(strings.Compare(a.Ticker, b.Ticker), b.Time.Compare(a.Time), strings.Compare(a.Brokerage, b.Brokerage))
+	})
+
+	latest := []entity.StockRating{}
+	position := 0
+	for i, rating := range ratings {
+		position = nextPosition(ratings, i, position, func(r entity.StockRating) string { return r.Ticker })
+		if position < limit {
+			latest = append(latest, rating)
+		}
+	}
+
+	return latest, nil
+}
+
+func (srr *StockRatingRepository) all(keep func(entity.StockRating) bool) []entity.StockRating {
+	srr.mu.RLock()
+	defer srr.mu.RUnlock()
+
+	ratings := []entity.StockRating{}
+	for _, rating := range srr.ratings {
+		if keep(rating) {
+			ratings = append(ratings, normalize(rating))
+		}
+	}
+
+	return ratings
+}
+
+func (srr *StockRatingRepository) byTicker() map[string][]entity.StockRating {
+	srr.mu.RLock()
+	defer srr.mu.RUnlock()
+
+	tickers := make(map[string][]entity.StockRating)
+	for _, rating := range srr.ratings {
+		tickers[rating.Ticker] = append(tickers[rating.Ticker], rating)
+	}
+
+	return tickers
+}
+
+// nextPosition returns the position of ratings[i] among the sorted ratings
+// sharing its group, given the position of the previous one.
+func nextPosition(ratings []entity.StockRating, i int, previous int, group func(entity.StockRating) string) int {
+	if i > 0 && group(ratings[i-1]) == group(ratings[i]) {
+		return previous + 1
+	}
+
+	return 0
+}
+
+// isAfter tells whether rating comes after the cursor in the ticker,
+// brokerage, newest first order.
+func isAfter(rating entity.StockRating, after entity.StockRatingCursor) bool {
+	if after.IsZero() || rating.Ticker > after.Ticker {
+		return true
+	}
+
+	if rating.Ticker != after.Ticker || after.Brokerage == "" {
+		return false
+	}
+
+	return rating.Brokerage > after.Brokerage || (rating.Brokerage == after.Brokerage && rating.Time.Before(after.Time))
+}
+
+// aggregate summarises the recent ratings of every brokerage of a ticker and
+// the consensus of their latest targets.
+func aggregate(ticker string, ratings []entity.StockRating) entity.StockRatingAggregate {
+	slices.SortFunc(ratings, func(a, b entity.StockRating) int {
+		return cmp.Or(strings.Compare(a.Brokerage, b.Brokerage), b.Time.Compare(a.Time))
+	})
+
+	recommendation := entity.StockRatingAggregate{Ticker: ticker}
+	var priceChange, score float64
+	var ratingSum, rated, counted int
+	var targets []float64
+
+	position := 0
+	for i, rating := range ratings {
+		position = nextPosition(ratings, i, position, func(r entity.StockRating) string { return r.Brokerage })
+		if position == 0 && rating.TargetToValue != nil && *rating.TargetToValue > 0 {
+			targets = append(targets, *rating.TargetToValue)
+		}
+
+		if position >= ratingsPerBrokerage {
+			continue
+		}
+
+		counted++
+		priceChange += rating.TargetPriceChange
+		score += float64(rating.Score)
+		if rating.Time.After(recommendation.Time) {
+			recommendation.Time = rating.Time
+		}
+
+		value, ok := ratingValues[rating.RatingTo]
+		switch value {
+		case 5:
+			recommendation.StrongBuyRatings++
+		case 4:
+			recommendation.BuyRatings++
+		case 3:
+			recommendation.HoldRatings++
+		case 2:
+			recommendation.SellRatings++
+		}
+
+		if ok {
+			ratingSum += value
+			rated++
+		}
+	}
+
+	recommendation.TargetPriceChange = round(priceChange/float64(counted)*100, 2)
+	recommendation.Score = float32(score / float64(counted))
+	if rated > 0 {
+		recommendation.Rating = consensusRating(round(float64(ratingSum)/float64(rated), 1))
+	}
+
+	if len(targets) > 0 {
+		slices.Sort(targets)
+		mean := 0.0
+		for _, target := range targets {
+			mean += target
+		}
+
+		recommendation.ConsensusTargetMedian = pointer(round(median(targets), 2))
+		recommendation.ConsensusTargetMean = pointer(round(mean/float64(len(targets)), 2))
+		recommendation.ConsensusTargetHigh = pointer(targets[len(targets)-1])
+		recommendation.ConsensusTargetLow = pointer(targets[0])
+	}
+
+	return recommendation
+}
+
+// median returns the median of the sorted values, the mean of the two middle
+// ones when there is an even number of them.
+func median(values []float64) float64 {
+	return (values[(len(values)-1)/2] + values[len(values)/2]) / 2
+}
+
+func consensusRating(rating float64) string {
+	switch {
+	case rating >= 4.5:
+		return "Strong Buy"
+	case rating >= 3.5:
+		return "Buy"
+	case rating >= 2.5:
+		return "Hold"
+	case rating >= 1.5:
+		return "Sell"
+	default:
+		return "Strong Sell"
+	}
+}
+
+// normalize stores the rating as the database columns would: timestamps in
+// UTC with microseconds, the price change and score with 2 decimals and the
+// targets with 4. It also copies the pointers so the caller cannot change the
+// stored rating, and is applied again to the ratings read for the same
+// reason.
+func normalize(rating entity.StockRating) entity.StockRating {
+	rating.Time = rating.Time.UTC().Truncate(time.Microsecond)
+	rating.TargetPriceChange = round(rating.TargetPriceChange, 2)
+	rating.Score = float32(round(float64(rating.Score), 2))
+	rating.ImpliedUpside = nil
+
+	if rating.TargetFromValue != nil {
+		rating.TargetFromValue = pointer(round(*rating.TargetFromValue, 4))
+	}
+
+	if rating.TargetToValue != nil {
+		rating.TargetToValue = pointer(round(*rating.TargetToValue, 4))
+	}
+
+	if rating.Currency != nil {
+		rating.Currency = pointer(*rating.Currency)
+	}
+
+	return rating
+}
+
+func round(value float64, decimals int) float64 {
+	scale := math.Pow10(decimals)
+	return math.Round(value*scale) / scale
+}
+
+func pointer[T any](value T) *T {
+	return &value
+}
@@ -0,0 +1,34 @@
+package memory
+
+import (
+	"testing"
+
+	"github.com/rubenpad/srs/internal/domain/entity"
+	"github.com/rubenpad/srs/internal/infrastructure/storage/storagetest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStockRatingRepository(t *testing.T) {
+	storagetest.StockRatingRepository(t, func(t *testing.T) entity.IStockRatingRepository {
+		return NewStockRatingRepository()
+	})
+}
+
+func TestMedian(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []float64
+		median float64
+	}{
+		{"one target", []float64{210}, 210},
+		{"odd number of targets", []float64{180, 200, 260}, 200},
+		{"even number of targets", []float64{180, 200, 220, 260}, 210},
+		{"repeated targets", []float64{200, 200, 200, 300}, 200},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.median, median(tt.values))
+		})
+	}
+}
@@ -0,0 +1,54 @@
+// Package sqlite stores the stock ratings in a SQLite file, for single node
+// deployments.
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	_ "modernc.org/sqlite"
+)
+
+// schema mirrors the stock_rating table of the migrations. SQLite has no
+// decimals, so the rounding of those columns is done by the queries, and
+// the times are stored as microseconds since the epoch to keep their order.
+const schema = `
+	CREATE TABLE IF NOT EXISTS stock_rating (
+		ticker TEXT NOT NULL,
+		brokerage TEXT NOT NULL,
+		time INTEGER NOT NULL,
+		action TEXT NOT NULL,
+		company TEXT NOT NULL,
+		rating_from TEXT NOT NULL,
+		rating_to TEXT NOT NULL,
+		target_from TEXT NOT NULL,
+		target_to TEXT NOT NULL,
+		target_from_value REAL NULL,
+		target_to_value REAL NULL,
+		currency TEXT NULL,
+		target_price_change REAL NOT NULL,
+		score REAL NOT NULL,
+		created_at INTEGER NOT NULL DEFAULT (unixepoch()),
+		PRIMARY KEY (ticker, brokerage, time DESC)
+	)`
+
+// Open opens the database at path, ":memory:" for one that is not saved,
+// and creates the tables missing.
+func Open(ctx context.Context, path string) (*sql.DB, error) {
+	db, err := sql.Open("sqlite", "file:"+path+"?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)")
+	if err != nil {
+		return nil, fmt.Errorf("error opening %s: %w", path, err)
+	}
+
+	// SQLite writes one transaction at a time anyway, and every connection to
+	// ":memory:" would open a different database.
+	db.SetMaxOpenConns(1)
+
+	if _, err := db.ExecContext(ctx, schema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("error creating the tables of %s: %w", path, err)
+	}
+
+	return db, nil
+}
@@ -0,0 +1,365 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/rubenpad/srs/internal/domain/entity"
+)
+
+// The columns are rounded as the DECIMAL columns of CockroachDB.
+const insertQuery = `INSERT INTO stock_rating (
+				brokerage,
+				action,
+				company,
+				ticker,
+				rating_from,
+				rating_to,
+				target_from,
+				target_to,
+				target_from_value,
+				target_to_value,
+				currency,
+				time,
+				target_price_change,
+				score)
+			  VALUES (
+			  	@brokerage,
+				@action,
+				@company,
+				@ticker,
+				@rating_from,
+				@rating_to,
+				@target_from,
+				@target_to,
+				ROUND(@target_from_value, 4),
+				ROUND(@target_to_value, 4),
+				@currency,
+				@time,
+				ROUND(@target_price_change, 2),
+				ROUND(@score, 2))
+			  ON CONFLICT DO NOTHING`
+
+// There are no stock prices in SQLite, so the implied upside is always empty.
+const selectStockRating = `
+			brokerage,
+			action,
+			company,
+			ticker,
+			rating_from,
+			rating_to,
+			target_from,
+			target_to,
+			target_from_value,
+			target_to_value,
+			currency,
+			time,
+			target_price_change,
+			score`
+
+var recommendationsOrderBy = map[string]string{
+	entity.RecommendationSortDefault: "strong_buy_ratings DESC, buy_ratings DESC, target_price_change DESC, time DESC, score DESC",
+	entity.RecommendationSortUpside:  "implied_upside DESC NULLS LAST, strong_buy_ratings DESC, buy_ratings DESC, time DESC",
+}
+
+// StockRatingRepository has the semantics of the cockroach one. It holds no
+// stock prices nor companies, so the current price and the implied upside
+// are always empty and a sector matches no rating.
+type StockRatingRepository struct {
+	db *sql.DB
+}
+
+func NewStockRatingRepository(db *sql.DB) *StockRatingRepository {
+	return &StockRatingRepository{db}
+}
+
+func (srr *StockRatingRepository) GetStockRatings(ctx context.Context, after entity.StockRatingCursor, pageSize int, search string) ([]entity.StockRating, error) {
+	query := `
+		SELECT ` + selectStockRating + `
+		FROM stock_rating
+		WHERE (@ticker = ''
+			OR ticker > @ticker
+			OR (ticker = @ticker AND @brokerage != '' AND (brokerage > @brokerage OR (brokerage = @brokerage AND time < @time))))
+		AND SUBSTR(UPPER(ticker), 1, LENGTH(@search)) = UPPER(@search)
+		ORDER BY ticker ASC, brokerage ASC, time DESC
+		LIMIT @pageSize
+	`
+
+	rows, err := srr.db.QueryContext(ctx, query,
+		sql.Named("ticker", after.Ticker),
+		sql.Named("brokerage", after.Brokerage),
+		sql.Named("time", after.Time.UnixMicro()),
+		sql.Named("search", search),
+		sql.Named("pageSize", pageSize),
+	)
+
+	if err != nil {
+		errorMessage := "error getting stock ratings"
+		slog.ErrorContext(ctx, errorMessage, "error", err)
+		return nil, errors.New(errorMessage)
+	}
+
+	return collectStockRatings(rows)
+}
+
+func (srr *StockRatingRepository) Save(ctx context.Context, stockRating entity.StockRating) error {
+	result, err := srr.db.ExecContext(ctx, insertQuery,
+		sql.Named("brokerage", stockRating.Brokerage),
+		sql.Named("action", stockRating.Action),
+		sql.Named("company", stockRating.Company),
+		sql.Named("ticker", stockRating.Ticker),
+		sql.Named("rating_from", stockRating.RatingFrom),
+		sql.Named("rating_to", stockRating.RatingTo),
+		sql.Named("target_from", stockRating.TargetFrom),
+		sql.Named("target_to", stockRating.TargetTo),
+		sql.Named("target_from_value", stockRating.TargetFromValue),
+		sql.Named("target_to_value", stockRating.TargetToValue),
+		sql.Named("currency", stockRating.Currency),
+		sql.Named("time", stockRating.Time.UnixMicro()),
+		sql.Named("target_price_change", stockRating.TargetPriceChange),
+		sql.Named("score", float64(stockRating.Score)),
+	)
+
+	if err != nil {
+		slog.ErrorContext(ctx, "error saving stock rating", "error", err)
+		return err
+	}
+
+	if inserted, err := result.RowsAffected(); err == nil && inserted == 0 {
+		slog.InfoContext(ctx, "skipping duplicate stock rating - probably running the load data again", "data", stockRating)
+		return entity.ErrDuplicateStockRating
+	}
+
+	return nil
+}
+
+func (srr *StockRatingRepository) GetStockRecommendations(ctx context.Context, pageSize int, sortBy string, sector string) ([]entity.StockRatingAggregate, error) {
+	orderBy, ok := recommendationsOrderBy[sortBy]
+	if !ok {
+		return nil, fmt.Errorf("unknown recommendations sort %q", sortBy)
+	}
+
+	// SQLite has no percentile_cont: the median is the average of the one or
+	// two targets in the middle.
+	query := `
+		WITH ranked_stock_ratings AS
+		(SELECT
+			ticker,
+			brokerage,
+			rating_to,
+			time,
+			target_price_change,
+			score,
+			target_to_value,
+			ROW_NUMBER() OVER (PARTITION BY ticker, brokerage ORDER BY time DESC) AS rn
+		FROM stock_rating
+		WHERE @sector = ''),
+		latest_stock_ratings AS
+		(SELECT
+			ticker,
+			MAX(time) AS time,
+			AVG(target_price_change) AS avg_price_change,
+			AVG(score) AS score,
+
+			COUNT(CASE WHEN rating_to IN ('Strong-Buy', 'Buy', 'Top Pick', 'Positive', 'Outperform', 'Outperformer', 'Market Outperform', 'Sector Outperform') THEN 1 ELSE NULL END) AS strong_buy_ratings,
+			COUNT(CASE WHEN rating_to IN ('Overweight', 'Equal Weight', 'Sector Weight', 'Peer Perform', 'In-Line', 'Inline') THEN 1 ELSE NULL END) AS buy_ratings,
+			COUNT(CASE WHEN rating_to IN ('Neutral', 'Market Perform', 'Sector Perform', 'Hold') THEN 1 ELSE NULL END) AS hold_ratings,
+			COUNT(CASE WHEN rating_to IN ('Sell', 'Reduce', 'Negative', 'Underweight', 'Underperform', 'Sector Underperform') THEN 1 ELSE NULL END) AS sell_ratings,
+
+			ROUND(AVG(
+				CASE
+					WHEN rating_to IN ('Strong-Buy', 'Buy', 'Top Pick', 'Positive', 'Outperform', 'Outperformer', 'Market Outperform', 'Sector Outperform') THEN 5
+					WHEN rating_to IN ('Overweight', 'Equal Weight', 'Sector Weight', 'Peer Perform', 'In-Line', 'Inline') THEN 4
+					WHEN rating_to IN ('Neutral', 'Market Perform', 'Sector Perform', 'Hold') THEN 3
+					WHEN rating_to IN ('Sell', 'Reduce', 'Negative', 'Underweight', 'Underperform', 'Sector Underperform') THEN 2
+				END), 1) AS rating
+		FROM ranked_stock_ratings
+		WHERE rn <= 5
+		GROUP BY ticker),
+		active_targets AS
+		(SELECT
+			ticker,
+			target_to_value AS target,
+			ROW_NUMBER() OVER (PARTITION BY ticker ORDER BY target_to_value) AS position,
+			COUNT(*) OVER (PARTITION BY ticker) AS total
+		FROM ranked_stock_ratings
+		WHERE rn = 1 AND target_to_value > 0),
+		consensus_targets AS
+		(SELECT
+			ticker,
+			AVG(CASE WHEN position IN ((total + 1) / 2, (total + 2) / 2) THEN target END) AS median,
+			AVG(target) AS mean,
+			MAX(target) AS high,
+			MIN(target) AS low
+		FROM active_targets
+		GROUP BY ticker)
+		SELECT
+			lsr.ticker,
+			lsr.time,
+			lsr.strong_buy_ratings,
+			lsr.buy_ratings,
+			lsr.hold_ratings,
+			lsr.sell_ratings,
+			(CASE
+				WHEN lsr.rating BETWEEN 4.5 AND 5 THEN 'Strong Buy'
+				WHEN lsr.rating BETWEEN 3.5 AND 4.4 THEN 'Buy'
+				WHEN lsr.rating BETWEEN 2.5 AND 3.4 THEN 'Hold'
+				WHEN lsr.rating BETWEEN 1.5 AND 2.4 THEN 'Sell'
+				WHEN lsr.rating BETWEEN 1.0 AND 1.4 THEN 'Strong Sell'
+				ELSE ''
+			END) AS rating,
+			ROUND(lsr.avg_price_change * 100, 2) AS target_price_change,
+			lsr.score,
+			ROUND(ct.median, 2) AS consensus_target_median,
+			ROUND(ct.mean, 2) AS consensus_target_mean,
+			ct.high AS consensus_target_high,
+			ct.low AS consensus_target_low,
+			NULL AS implied_upside
+		FROM latest_stock_ratings lsr
+		LEFT JOIN consensus_targets ct ON ct.ticker = lsr.ticker
+		WHERE ROUND(lsr.avg_price_change * 100, 2) > 0
+		ORDER BY ` + orderBy + `
+		LIMIT @pageSize
+	`
+
+	rows, err := srr.db.QueryContext(ctx, query, sql.Named("sector", sector), sql.Named("pageSize", pageSize))
+	if err != nil {
+		errorMessage := "error getting stock ratings"
+		slog.ErrorContext(ctx, errorMessage, "error", err)
+		return nil, errors.New(errorMessage)
+	}
+
+	defer rows.Close()
+
+	recommendations := []entity.StockRatingAggregate{}
+	for rows.Next() {
+		var recommendation entity.StockRatingAggregate
+		var recommendationTime int64
+		var score float64
+		err := rows.Scan(
+			&recommendation.Ticker,
+			&recommendationTime,
+			&recommendation.StrongBuyRatings,
+			&recommendation.BuyRatings,
+			&recommendation.HoldRatings,
+			&recommendation.SellRatings,
+			&recommendation.Rating,
+			&recommendation.TargetPriceChange,
+			&score,
+			&recommendation.ConsensusTargetMedian,
+			&recommendation.ConsensusTargetMean,
+			&recommendation.ConsensusTargetHigh,
+			&recommendation.ConsensusTargetLow,
+			&recommendation.ImpliedUpside,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		recommendation.Time = time.UnixMicro(recommendationTime).UTC()
+		recommendation.Score = float32(score)
+		recommendations = append(recommendations, recommendation)
+	}
+
+	return recommendations, rows.Err()
+}
+
+func (srr *StockRatingRepository) GetTickers(ctx context.Context) ([]string, error) {
+	rows, err := srr.db.QueryContext(ctx, `SELECT DISTINCT ticker FROM stock_rating ORDER BY ticker ASC`)
+
+	if err != nil {
+		errorMessage := "error getting tickers"
+		slog.ErrorContext(ctx, errorMessage, "error", err)
+		return nil, errors.New(errorMessage)
+	}
+
+	defer rows.Close()
+
+	tickers := []string{}
+	for rows.Next() {
+		var ticker string
+		if err := rows.Scan(&ticker); err != nil {
+			return nil, err
+		}
+
+		tickers = append(tickers, ticker)
+	}
+
+	return tickers, rows.Err()
+}
+
+func (srr *StockRatingRepository) GetLatestStockRatings(ctx context.Context, tickers []string, limit int) ([]entity.StockRating, error) {
+	// SQLite has no arrays, the tickers are given as a JSON array.
+	tickerList, err := json.Marshal(tickers)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT ` + selectStockRating + `
+		FROM (
+			SELECT
+				*,
+				ROW_NUMBER() OVER (PARTITION BY ticker ORDER BY time DESC, brokerage ASC) AS position
+			FROM stock_rating
+			WHERE ticker IN (SELECT value FROM json_each(@tickers))
+		) AS ranked
+		WHERE position <= @limit
+		ORDER BY ticker ASC, time DESC, brokerage ASC
+	`
+
+	rows, err := srr.db.QueryContext(ctx, query, sql.Named("tickers", string(tickerList)), sql.Named("limit", limit))
+	if err != nil {
+		errorMessage := "error getting latest stock ratings"
+		slog.ErrorContext(ctx, errorMessage, "error", err)
+		return nil, errors.New(errorMessage)
+	}
+
+	return collectStockRatings(rows)
+}
+
+// HealthCheck verifies the database file can still be read.
+func (srr *StockRatingRepository) HealthCheck(ctx context.Context) error {
+	return srr.db.PingContext(ctx)
+}
+
+func collectStockRatings(rows *sql.Rows) ([]entity.StockRating, error) {
+	defer rows.Close()
+
+	ratings := []entity.StockRating{}
+	for rows.Next() {
+		var rating entity.StockRating
+		var ratingTime int64
+		var score float64
+		err := rows.Scan(
+			&rating.Brokerage,
+			&rating.Action,
+			&rating.Company,
+			&rating.Ticker,
+			&rating.RatingFrom,
+			&rating.RatingTo,
+			&rating.TargetFrom,
+			&rating.TargetTo,
+			&rating.TargetFromValue,
+			&rating.TargetToValue,
+			&rating.Currency,
+			&ratingTime,
+			&rating.TargetPriceChange,
+			&score,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		rating.Time = time.UnixMicro(ratingTime).UTC()
+		rating.Score = float32(score)
+		ratings = append(ratings, rating)
+	}
+
+	return ratings, rows.Err()
+}
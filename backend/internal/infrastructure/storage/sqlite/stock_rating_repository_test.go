@@ -0,0 +1,21 @@
+package sqlite
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/rubenpad/srs/internal/domain/entity"
+	"github.com/rubenpad/srs/internal/infrastructure/storage/storagetest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStockRatingRepository(t *testing.T) {
+	storagetest.StockRatingRepository(t, func(t *testing.T) entity.IStockRatingRepository {
+		db, err := Open(context.Background(), filepath.Join(t.TempDir(), "srs.db"))
+		require.NoError(t, err)
+		t.Cleanup(func() { db.Close() })
+
+		return NewStockRatingRepository(db)
+	})
+}
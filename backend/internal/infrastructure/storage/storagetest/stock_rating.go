@@ -0,0 +1,271 @@
+// Package storagetest verifies that the storage backends behave the same.
+package storagetest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rubenpad/srs/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// StockRatingRepository runs the conformance tests of an
+// entity.IStockRatingRepository. newRepository must return an empty
+// repository on every call, with no stock prices nor companies.
+func StockRatingRepository(t *testing.T, newRepository func(t *testing.T) entity.IStockRatingRepository) {
+	t.Run("Save detects duplicates", func(t *testing.T) {
+		testSaveDetectsDuplicates(t, newRepository(t))
+	})
+	t.Run("GetStockRatings pages with the cursor", func(t *testing.T) {
+		testGetStockRatingsPages(t, newRepository(t))
+	})
+	t.Run("GetStockRatings searches by ticker prefix", func(t *testing.T) {
+		testGetStockRatingsSearch(t, newRepository(t))
+	})
+	t.Run("GetStockRecommendations aggregates the recent ratings", func(t *testing.T) {
+		testGetStockRecommendations(t, newRepository(t))
+	})
+	t.Run("GetTickers", func(t *testing.T) {
+		testGetTickers(t, newRepository(t))
+	})
+	t.Run("GetLatestStockRatings", func(t *testing.T) {
+		testGetLatestStockRatings(t, newRepository(t))
+	})
+	t.Run("empty repository", func(t *testing.T) {
+		testEmptyRepository(t, newRepository(t))
+	})
+}
+
+var day = time.Date(2025, time.March, 10, 14, 30, 0, 0, time.UTC)
+
+func rating(ticker, brokerage string, daysAgo int, ratingTo string, targetPriceChange float64, targetTo *float64) entity.StockRating {
+	return entity.StockRating{
+		Ticker:            ticker,
+		Brokerage:         brokerage,
+		Time:              day.AddDate(0, 0, -daysAgo),
+		Action:            "target raised by",
+		Company:           ticker + " Inc.",
+		RatingFrom:        "Hold",
+		RatingTo:          ratingTo,
+		TargetFrom:        "$100.00",
+		TargetTo:          "$120.00",
+		TargetFromValue:   pointer(100.0),
+		TargetToValue:     targetTo,
+		Currency:          pointer("USD"),
+		TargetPriceChange: targetPriceChange,
+		Score:             1.5,
+	}
+}
+
+func save(t *testing.T, repository entity.IStockRatingRepository, ratings ...entity.StockRating) {
+	t.Helper()
+	for _, stockRating := range ratings {
+		require.NoError(t, repository.Save(context.Background(), stockRating))
+	}
+}
+
+func testSaveDetectsDuplicates(t *testing.T, repository entity.IStockRatingRepository) {
+	ctx := context.Background()
+	stored := rating("AAPL", "Goldman Sachs", 0, "Buy", 0.2, pointer(120.0))
+	save(t, repository, stored)
+
+	duplicate := rating("AAPL", "Goldman Sachs", 0, "Sell", -0.1, nil)
+	assert.ErrorIs(t, repository.Save(ctx, duplicate), entity.ErrDuplicateStockRating, "the same ticker, brokerage and time is a duplicate")
+
+	save(t, repository, rating("AAPL", "Goldman Sachs", 1, "Buy", 0.2, nil), rating("AAPL", "Barclays", 0, "Buy", 0.2, nil))
+
+	ratings, err := repository.GetStockRatings(ctx, entity.StockRatingCursor{}, 10, "")
+	require.NoError(t, err)
+	require.Len(t, ratings, 3)
+	assert.Equal(t, stored, ratings[1], "the duplicate does not replace the stored rating")
+}
+
+func testGetStockRatingsPages(t *testing.T, repository entity.IStockRatingRepository) {
+	ctx := context.Background()
+	expected := []entity.StockRating{
+		rating("AAPL", "Barclays", 0, "Buy", 0.1, nil),
+		rating("AAPL", "Barclays", 3, "Hold", 0.1, nil),
+		rating("AAPL", "Goldman Sachs", 1, "Buy", 0.1, nil),
+		rating("MSFT", "Barclays", 2, "Buy", 0.1, nil),
+		rating("MSFT", "Citigroup", 0, "Buy", 0.1, nil),
+		rating("TSLA", "Barclays", 5, "Sell", 0.1, nil),
+		rating("TSLA", "Barclays", 6, "Sell", 0.1, nil),
+	}
+
+	// Saved in another order than the expected one.
+	for i := len(expected) - 1; i >= 0; i -= 2 {
+		save(t, repository, expected[i])
+	}
+	for i := len(expected) - 2; i >= 0; i -= 2 {
+		save(t, repository, expected[i])
+	}
+
+	var pages []entity.StockRating
+	cursor := entity.StockRatingCursor{}
+	for range len(expected) {
+		page, err := repository.GetStockRatings(ctx, cursor, 2, "")
+		require.NoError(t, err)
+		if len(page) == 0 {
+			break
+		}
+
+		assert.LessOrEqual(t, len(page), 2)
+		pages = append(pages, page...)
+		cursor = entity.NewStockRatingCursor(page[len(page)-1])
+	}
+
+	assert.Equal(t, expected, pages, "ordered by ticker, brokerage and newest first")
+
+	page, err := repository.GetStockRatings(ctx, entity.StockRatingCursor{Ticker: "AAPL"}, 10, "")
+	require.NoError(t, err)
+	assert.Equal(t, expected[3:], page, "a cursor with only the ticker skips all of its ratings")
+}
+
+func testGetStockRatingsSearch(t *testing.T, repository entity.IStockRatingRepository) {
+	ctx := context.Background()
+	save(t, repository,
+		rating("AAPL", "Barclays", 0, "Buy", 0.1, nil),
+		rating("AMZN", "Barclays", 0, "Buy", 0.1, nil),
+		rating("MSFT", "Barclays", 0, "Buy", 0.1, nil),
+		rating("BAAP", "Barclays", 0, "Buy", 0.1, nil),
+	)
+
+	tests := []struct {
+		search  string
+		tickers []string
+	}{
+		{search: "a", tickers: []string{"AAPL", "AMZN"}},
+		{search: "Aap", tickers: []string{"AAPL"}},
+		{search: "MSFT", tickers: []string{"MSFT"}},
+		{search: "X", tickers: []string{}},
+	}
+
+	for _, tt := range tests {
+		ratings, err := repository.GetStockRatings(ctx, entity.StockRatingCursor{}, 10, tt.search)
+		require.NoError(t, err)
+
+		tickers := []string{}
+		for _, stockRating := range ratings {
+			tickers = append(tickers, stockRating.Ticker)
+		}
+		assert.Equal(t, tt.tickers, tickers, "search %q", tt.search)
+	}
+}
+
+func testGetStockRecommendations(t *testing.T, repository entity.IStockRatingRepository) {
+	ctx := context.Background()
+	save(t, repository,
+		// AAPL: one rating of every kind, the oldest Barclays one is not the
+		// active target and the Citigroup one has no target.
+		rating("AAPL", "Barclays", 0, "Buy", 0.1, pointer(200.0)),
+		rating("AAPL", "Barclays", 4, "Sell", 0.5, pointer(150.0)),
+		rating("AAPL", "Goldman Sachs", 1, "Overweight", 0.2, pointer(220.0)),
+		rating("AAPL", "Citigroup", 2, "Hold", 0.3, nil),
+		// MSFT: counts the 5 most recent ratings of the brokerage only.
+		rating("MSFT", "Barclays", 1, "Strong-Buy", 0.1, pointer(500.0)),
+		rating("MSFT", "Barclays", 2, "Strong-Buy", 0.1, pointer(480.0)),
+		rating("MSFT", "Barclays", 3, "Strong-Buy", 0.1, pointer(460.0)),
+		rating("MSFT", "Barclays", 4, "Strong-Buy", 0.1, pointer(440.0)),
+		rating("MSFT", "Barclays", 5, "Strong-Buy", 0.1, pointer(420.0)),
+		rating("MSFT", "Barclays", 6, "Sell", -0.9, pointer(100.0)),
+		// TSLA: a negative target change is not recommended.
+		rating("TSLA", "Barclays", 0, "Buy", -0.1, pointer(150.0)),
+	)
+
+	recommendations, err := repository.GetStockRecommendations(ctx, 10, entity.RecommendationSortDefault, "")
+	require.NoError(t, err)
+	require.Len(t, recommendations, 2)
+
+	msft := recommendations[0]
+	assert.Equal(t, "MSFT", msft.Ticker)
+	assert.True(t, day.AddDate(0, 0, -1).Equal(msft.Time))
+	assert.Equal(t, []int{5, 0, 0, 0}, []int{msft.StrongBuyRatings, msft.BuyRatings, msft.HoldRatings, msft.SellRatings})
+	assert.Equal(t, "Strong Buy", msft.Rating)
+	assert.InDelta(t, 10.0, msft.TargetPriceChange, 0.001)
+	assert.InDelta(t, 500.0, *msft.ConsensusTargetMedian, 0.001, "only the latest target of every brokerage")
+
+	aapl := recommendations[1]
+	assert.Equal(t, "AAPL", aapl.Ticker)
+	assert.True(t, day.Equal(aapl.Time), "the time of the newest rating")
+	assert.Equal(t, []int{1, 1, 1, 1}, []int{aapl.StrongBuyRatings, aapl.BuyRatings, aapl.HoldRatings, aapl.SellRatings})
+	assert.Equal(t, "Buy", aapl.Rating, "the average of 5, 4, 3 and 2")
+	assert.InDelta(t, 27.5, aapl.TargetPriceChange, 0.001)
+	assert.InDelta(t, 1.5, aapl.Score, 0.001)
+	require.NotNil(t, aapl.ConsensusTargetMedian)
+	assert.InDelta(t, 210.0, *aapl.ConsensusTargetMedian, 0.001)
+	assert.InDelta(t, 210.0, *aapl.ConsensusTargetMean, 0.001)
+	assert.InDelta(t, 220.0, *aapl.ConsensusTargetHigh, 0.001)
+	assert.InDelta(t, 200.0, *aapl.ConsensusTargetLow, 0.001)
+	assert.Nil(t, aapl.CurrentPrice, "no stock prices are stored")
+	assert.Nil(t, aapl.ImpliedUpside)
+
+	recommendations, err = repository.GetStockRecommendations(ctx, 1, entity.RecommendationSortUpside, "")
+	require.NoError(t, err)
+	require.Len(t, recommendations, 1)
+	assert.Equal(t, "MSFT", recommendations[0].Ticker, "without upside the strong buy ratings decide")
+
+	_, err = repository.GetStockRecommendations(ctx, 10, "popularity", "")
+	assert.Error(t, err)
+}
+
+func testGetTickers(t *testing.T, repository entity.IStockRatingRepository) {
+	save(t, repository,
+		rating("TSLA", "Barclays", 0, "Buy", 0.1, nil),
+		rating("AAPL", "Barclays", 0, "Buy", 0.1, nil),
+		rating("AAPL", "Citigroup", 0, "Buy", 0.1, nil),
+	)
+
+	tickers, err := repository.GetTickers(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"AAPL", "TSLA"}, tickers)
+}
+
+func testGetLatestStockRatings(t *testing.T, repository entity.IStockRatingRepository) {
+	save(t, repository,
+		rating("AAPL", "Barclays", 2, "Buy", 0.1, nil),
+		rating("AAPL", "Citigroup", 0, "Buy", 0.1, nil),
+		rating("AAPL", "Barclays", 0, "Buy", 0.1, nil),
+		rating("MSFT", "Barclays", 1, "Buy", 0.1, nil),
+		rating("TSLA", "Barclays", 0, "Buy", 0.1, nil),
+	)
+
+	ratings, err := repository.GetLatestStockRatings(context.Background(), []string{"MSFT", "AAPL"}, 2)
+	require.NoError(t, err)
+
+	expected := []entity.StockRating{
+		rating("AAPL", "Barclays", 0, "Buy", 0.1, nil),
+		rating("AAPL", "Citigroup", 0, "Buy", 0.1, nil),
+		rating("MSFT", "Barclays", 1, "Buy", 0.1, nil),
+	}
+	assert.Equal(t, expected, ratings, "newest first, by brokerage on the same time")
+}
+
+func testEmptyRepository(t *testing.T, repository entity.IStockRatingRepository) {
+	ctx := context.Background()
+
+	ratings, err := repository.GetStockRatings(ctx, entity.StockRatingCursor{}, 10, "")
+	require.NoError(t, err)
+	assert.NotNil(t, ratings, "empty lists are rendered as []")
+	assert.Empty(t, ratings)
+
+	recommendations, err := repository.GetStockRecommendations(ctx, 10, entity.RecommendationSortDefault, "")
+	require.NoError(t, err)
+	assert.NotNil(t, recommendations)
+	assert.Empty(t, recommendations)
+
+	tickers, err := repository.GetTickers(ctx)
+	require.NoError(t, err)
+	assert.NotNil(t, tickers)
+	assert.Empty(t, tickers)
+
+	latest, err := repository.GetLatestStockRatings(ctx, []string{"AAPL"}, 5)
+	require.NoError(t, err)
+	assert.NotNil(t, latest)
+	assert.Empty(t, latest)
+}
+
+func pointer[T any](value T) *T {
+	return &value
+}
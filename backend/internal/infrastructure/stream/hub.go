@@ -0,0 +1,128 @@
+package stream
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"sync"
+
+	"github.com/rubenpad/srs/internal/domain/entity"
+)
+
+const (
+	EventRating   = "rating"
+	EventProgress = "progress"
+
+	subscriptionBufferSize = 64
+)
+
+type Event struct {
+	Name   string
+	Ticker string
+	Data   any
+}
+
+// Subscription receives the hub events through Events until it is
+// unsubscribed or the hub is closed.
+type Subscription struct {
+	Events  chan Event
+	tickers map[string]struct{}
+}
+
+func (s *Subscription) accepts(event Event) bool {
+	if event.Ticker == "" || len(s.tickers) == 0 {
+		return true
+	}
+
+	_, ok := s.tickers[event.Ticker]
+	return ok
+}
+
+// Hub fans out the saved stock ratings and ingestion progress to the stream
+// subscribers. Publishing never blocks: a subscriber that falls behind loses
+// the events that do not fit in its buffer.
+type Hub struct {
+	mu            sync.Mutex
+	closed        bool
+	subscriptions map[*Subscription]struct{}
+}
+
+func NewHub() *Hub {
+	return &Hub{subscriptions: map[*Subscription]struct{}{}}
+}
+
+// Subscribe returns a subscription to the rating events of tickers, or of
+// every ticker when tickers is empty. Progress events are always delivered.
+func (h *Hub) Subscribe(tickers []string) *Subscription {
+	subscription := &Subscription{
+		Events:  make(chan Event, subscriptionBufferSize),
+		tickers: make(map[string]struct{}, len(tickers)),
+	}
+
+	for _, ticker := range tickers {
+		subscription.tickers[strings.ToUpper(ticker)] = struct{}{}
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		close(subscription.Events)
+		return subscription
+	}
+
+	h.subscriptions[subscription] = struct{}{}
+	return subscription
+}
+
+func (h *Hub) Unsubscribe(subscription *Subscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, ok := h.subscriptions[subscription]; ok {
+		delete(h.subscriptions, subscription)
+		close(subscription.Events)
+	}
+}
+
+func (h *Hub) Publish(event Event) {
+	event.Ticker = strings.ToUpper(event.Ticker)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for subscription := range h.subscriptions {
+		if !subscription.accepts(event) {
+			continue
+		}
+
+		select {
+		case subscription.Events <- event:
+		default:
+			slog.Warn("stream subscriber is falling behind, dropping event", "event", event.Name)
+		}
+	}
+}
+
+// Close ends every subscription so the streams finish before the server
+// shuts down.
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.closed = true
+	for subscription := range h.subscriptions {
+		delete(h.subscriptions, subscription)
+		close(subscription.Events)
+	}
+}
+
+// StockRatingSaved implements entity.StockRatingListener.
+func (h *Hub) StockRatingSaved(ctx context.Context, rating entity.StockRating) {
+	h.Publish(Event{Name: EventRating, Ticker: rating.Ticker, Data: rating})
+}
+
+// IngestionProgressed implements entity.IngestionProgressListener.
+func (h *Hub) IngestionProgressed(ctx context.Context, progress entity.IngestionProgress) {
+	h.Publish(Event{Name: EventProgress, Data: progress})
+}
@@ -0,0 +1,52 @@
+package stream
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rubenpad/srs/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHubFiltersByTicker(t *testing.T) {
+	hub := NewHub()
+	all := hub.Subscribe(nil)
+	apple := hub.Subscribe([]string{"aapl"})
+
+	hub.StockRatingSaved(context.Background(), entity.StockRating{Ticker: "MSFT"})
+	hub.StockRatingSaved(context.Background(), entity.StockRating{Ticker: "AAPL"})
+	hub.IngestionProgressed(context.Background(), entity.IngestionProgress{Status: entity.IngestionStatusRunning})
+	hub.Close()
+
+	assert.Equal(t, []string{"rating:MSFT", "rating:AAPL", "progress:"}, drain(all))
+	assert.Equal(t, []string{"rating:AAPL", "progress:"}, drain(apple))
+}
+
+func TestHubDropsEventsOfSlowSubscribers(t *testing.T) {
+	hub := NewHub()
+	subscription := hub.Subscribe(nil)
+
+	for range subscriptionBufferSize + 10 {
+		hub.Publish(Event{Name: EventRating, Ticker: "AAPL"})
+	}
+	hub.Unsubscribe(subscription)
+
+	assert.Len(t, drain(subscription), subscriptionBufferSize)
+}
+
+func TestHubSubscribeAfterClose(t *testing.T) {
+	hub := NewHub()
+	hub.Close()
+
+	_, open := <-hub.Subscribe(nil).Events
+	assert.False(t, open)
+}
+
+func drain(subscription *Subscription) []string {
+	events := []string{}
+	for event := range subscription.Events {
+		events = append(events, event.Name+":"+event.Ticker)
+	}
+
+	return events
+}
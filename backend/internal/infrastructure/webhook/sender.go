@@ -0,0 +1,79 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/rubenpad/srs/internal/domain/entity"
+)
+
+const (
+	EventHeader     = "X-Srs-Event"
+	DeliveryHeader  = "X-Srs-Delivery"
+	TimestampHeader = "X-Srs-Timestamp"
+	SignatureHeader = "X-Srs-Signature"
+
+	// maxDrainedBodySize bounds the response body read, and discarded, so the
+	// connection can be reused.
+	maxDrainedBodySize = 64 << 10
+)
+
+// Sender posts webhook deliveries signed with HMAC-SHA256. Receivers verify
+// the signature header against Sign(secret, timestamp header, raw body).
+type Sender struct {
+	httpClient *http.Client
+}
+
+func NewSender() *Sender {
+	return &Sender{httpClient: &http.Client{Timeout: 10 * time.Second}}
+}
+
+func (s *Sender) Send(ctx context.Context, webhook entity.Webhook, delivery entity.WebhookDelivery) (int, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.Url, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(EventHeader, delivery.Event)
+	request.Header.Set(DeliveryHeader, strconv.FormatInt(delivery.ID, 10))
+	request.Header.Set(TimestampHeader, timestamp)
+	request.Header.Set(SignatureHeader, Sign(webhook.Secret, timestamp, delivery.Payload))
+
+	response, err := s.httpClient.Do(request)
+	if err != nil {
+		return 0, err
+	}
+
+	defer func() {
+		_, _ = io.Copy(io.Discard, io.LimitReader(response.Body, maxDrainedBodySize))
+		response.Body.Close()
+	}()
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return response.StatusCode, fmt.Errorf("webhook answered with status %d", response.StatusCode)
+	}
+
+	return response.StatusCode, nil
+}
+
+// Sign returns the signature header value for a payload sent at timestamp.
+// The timestamp is part of the signed content so captured requests cannot be
+// replayed later with a fresh timestamp.
+func Sign(secret, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,56 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rubenpad/srs/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSign(t *testing.T) {
+	signature := Sign("secret", "1700000000", []byte(`{"event":"rating.created"}`))
+
+	assert.Equal(t, "sha256=", signature[:7])
+	assert.Len(t, signature, 7+64)
+	assert.Equal(t, signature, Sign("secret", "1700000000", []byte(`{"event":"rating.created"}`)))
+	assert.NotEqual(t, signature, Sign("other", "1700000000", []byte(`{"event":"rating.created"}`)))
+	assert.NotEqual(t, signature, Sign("secret", "1700000001", []byte(`{"event":"rating.created"}`)))
+}
+
+func TestSenderSend(t *testing.T) {
+	payload := []byte(`{"event":"rating.created","data":{}}`)
+
+	testCases := []struct {
+		name           string
+		responseStatus int
+		expectError    bool
+	}{
+		{name: "Accepted", responseStatus: http.StatusNoContent},
+		{name: "Rejected", responseStatus: http.StatusInternalServerError, expectError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				assert.Equal(t, payload, body)
+				assert.Equal(t, entity.EventRatingCreated, r.Header.Get(EventHeader))
+				assert.Equal(t, "42", r.Header.Get(DeliveryHeader))
+				assert.Equal(t, Sign("secret", r.Header.Get(TimestampHeader), body), r.Header.Get(SignatureHeader))
+				w.WriteHeader(tc.responseStatus)
+			}))
+			defer server.Close()
+
+			status, err := NewSender().Send(context.Background(),
+				entity.Webhook{Url: server.URL, Secret: "secret"},
+				entity.WebhookDelivery{ID: 42, Event: entity.EventRatingCreated, Payload: payload})
+
+			assert.Equal(t, tc.responseStatus, status)
+			assert.Equal(t, tc.expectError, err != nil)
+		})
+	}
+}
@@ -5,10 +5,12 @@ export interface IStockRating {
   company: string;
   brokerage: string;
   rating_to: string;
-  target_to: string;
-  target_from: string;
+  target_to: number | null;
+  target_from: number | null;
+  currency: string | null;
   rating_from: string;
   target_price_change: number;
+  implied_upside: number | null;
 }
 
 export interface IStockRecommendation {
@@ -21,29 +23,46 @@ export interface IStockRecommendation {
   sell_ratings: number;
   target_price_change: number;
   strong_buy_ratings: number;
+  current_price: number | null;
+  consensus_target_median: number | null;
+  consensus_target_mean: number | null;
+  consensus_target_high: number | null;
+  consensus_target_low: number | null;
+  implied_upside: number | null;
 }
 
 interface IExternalRecommendation {
   buy: number;
   hold: number;
   sell: number;
-  symbol: number;
   period: string;
   strongBuy: number;
   strongSell: number;
 }
 
 interface IQuote {
-  c: number;
-  h: number;
-  l: number;
-  o: number;
-  t: number;
-  pc: number;
+  current: number;
+  open: number;
+  high: number;
+  low: number;
+  previousClose: number;
+  change: number;
+  percentChange: number;
+}
+
+interface ISectionStatus {
+  status: 'ok' | 'error';
+  code?: 'timeout' | 'upstream_error' | 'not_found';
+  message?: string;
 }
 
 export interface IStockDetails {
   keyFacts: string;
-  quote: IQuote;
-  recommendations: Array<IExternalRecommendation>;
+  quote: IQuote | null;
+  recommendations: Array<IExternalRecommendation> | null;
+  status: {
+    quote: ISectionStatus;
+    recommendations: ISectionStatus;
+    keyFacts: ISectionStatus;
+  };
 }